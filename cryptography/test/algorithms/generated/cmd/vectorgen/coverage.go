@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+// runCoverage implements the "coverage" subcommand.
+func runCoverage(args []string) error {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	fail := flags.Bool("fail", false, "exit with an error if any combination is untested")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	list, err := algorithms.ReadAlgorithms(algorithms.AlgorithmsPath)
+	if err != nil {
+		return err
+	}
+	generators, err := algorithms.SelectGenerators("", "")
+	if err != nil {
+		return err
+	}
+	// The cache is used but not updated.
+	cache, err := algorithms.ReadCache(".")
+	if err != nil {
+		return err
+	}
+	suites, err := algorithms.CollectSuites(generators, cache)
+	if err != nil {
+		return err
+	}
+	untested, err := algorithms.WriteCoverage(os.Stdout, list, suites)
+	if err != nil {
+		return err
+	}
+	if *fail && untested > 0 {
+		return fmt.Errorf("%d untested combinations", untested)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+// writeCorpora writes the manifest of the corpora to algorithms.CorporaPath.
+func writeCorpora(corpora []algorithms.Corpus) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(corpora); err != nil {
+		return err
+	}
+	return writeFileAtomic(algorithms.CorporaPath, func(w io.Writer) error {
+		_, err := w.Write(buf.Bytes())
+		return err
+	})
+}
+
+// runFetch implements the "fetch" subcommand.
+func runFetch(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	cacheDir := flags.String("cache", algorithms.DefaultCacheDir(), "cache directory")
+	pin := flags.Bool("pin", false, "record hashes of files that are not pinned yet in "+algorithms.CorporaPath)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+
+	corpora, err := algorithms.ReadCorpora(algorithms.CorporaPath)
+	if err != nil {
+		return err
+	}
+	// The arguments, such as "wycheproof", select corpora by name or by
+	// directory. Without arguments, every corpus is fetched.
+	matches := func(c algorithms.Corpus, arg string) bool {
+		return c.Name == arg || strings.HasPrefix(c.Name, arg+"/")
+	}
+	for _, arg := range flags.Args() {
+		if !slices.ContainsFunc(corpora, func(c algorithms.Corpus) bool { return matches(c, arg) }) {
+			fmt.Fprintf(flags.Output(), "no corpus %q in %s\n", arg, algorithms.CorporaPath)
+			return errUsage
+		}
+	}
+	selected := func(c algorithms.Corpus) bool {
+		return flags.NArg() == 0 || slices.ContainsFunc(flags.Args(), func(arg string) bool { return matches(c, arg) })
+	}
+	failed := 0
+	pinned := false
+	for i := range corpora {
+		c := &corpora[i]
+		if !selected(*c) {
+			continue
+		}
+		if c.SHA256 == "" && !*pin {
+			slog.Error("not pinned (run with -pin and review the hash)", "corpus", c.Name)
+			failed++
+			continue
+		}
+		sum, err := algorithms.FetchCorpus(*cacheDir, *c)
+		if err != nil {
+			slog.Error("fetch failed", "corpus", c.Name, "err", err)
+			failed++
+			continue
+		}
+		if c.SHA256 == "" {
+			c.SHA256 = sum
+			pinned = true
+			slog.Info("pinned", "corpus", c.Name, "sha256", sum)
+		}
+	}
+	if pinned {
+		if err := writeCorpora(corpora); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d corpora failed", failed, len(corpora))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/emit"
+)
+
+// jwkOutputPath is the Dart test file of the "jwk" subcommand. It is in
+// package:jwk, which depends on package:cryptography.
+const jwkOutputPath = "../../../../jwk/test/jwk_vectors_test.dart"
+
+const jwkDartComment = `// GENERATED CODE - DO NOT MODIFY BY HAND.
+//
+// Generated by "go run ./cmd/vectorgen jwk" in
+// cryptography/test/algorithms/generated.
+// The keys were encoded with Go standard library and golang.org/x/crypto.
+
+// ignore_for_file: implementation_imports, unused_element, unused_local_variable
+
+`
+
+const jwkDartImports = `import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:cryptography/cryptography.dart';
+import 'package:cryptography/src/utils.dart';
+import 'package:jwk/jwk.dart';
+import 'package:test/test.dart';
+
+`
+
+// runJwk implements the "jwk" subcommand.
+func runJwk(args []string) error {
+	flags := flag.NewFlagSet("jwk", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	suites, err := algorithms.JwkSuites()
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(jwkOutputPath, func(w io.Writer) error {
+		return emit.WriteDartFile(w, jwkDartComment, jwkDartImports, suites)
+	}); err != nil {
+		return err
+	}
+	slog.Info("wrote", "path", jwkOutputPath, "suites", len(suites))
+	return nil
+}
@@ -0,0 +1,272 @@
+// Command vectorgen writes "generated_test.dart", a Dart test file that
+// checks package:cryptography against test vectors computed with Go.
+//
+// Run it in the root of the module:
+//
+//	go run ./cmd/vectorgen
+//
+// The generators are in package internal/algorithms, which is tested against
+// known answers from the RFCs with "go test ./...". Package internal/emit
+// writes the Dart and JSON files and package internal/spec reads spec files.
+//
+// The default output has one Dart test for every vector. With "-emit data",
+// the vectors are written to "generated_vectors.json" instead and the Dart test
+// file only has one function per suite that runs a decoded vector. This is
+// much faster to analyze and compile.
+//
+// External test vector corpora are downloaded with:
+//
+//	go run ./cmd/vectorgen fetch
+//
+// Names such as "wycheproof" after "fetch" only download the corpora of
+// that directory of corpora.json.
+//
+// The generated Dart files are not committed. The Dart CI workflow
+// generates them and runs "dart analyze" and "dart test" on them.
+//
+// The fetched Wycheproof files are converted to "wycheproof_test.dart", with a
+// Dart test for every valid and invalid test case, with:
+//
+//	go run ./cmd/vectorgen wycheproof
+//
+// Likewise, the NIST CAVP response files of SHA, HMAC and AES-GCM are
+// converted to "cavp_test.dart" with:
+//
+//	go run ./cmd/vectorgen cavp
+//
+// JSON Web Key fixtures are written to "jwk_vectors_test.dart" in the tests
+// of package:jwk, which imports and exports them, with:
+//
+//	go run ./cmd/vectorgen jwk
+//
+// Algorithms and parameters of package:cryptography that have no vectors are
+// listed with:
+//
+//	go run ./cmd/vectorgen coverage
+//
+// The output files are written to the directory given with "-out". Only some
+// generators are run with "-algorithms", a comma-separated list of generator
+// names such as "blake2b,hash", and "-skip" excludes generators:
+//
+//	go run ./cmd/vectorgen -algorithms blake2b,hash -out /tmp/vectors
+//
+// Inputs without special values are pseudorandom bytes that are reproduced
+// with the seed in the header of the Dart file (see
+// internal/algorithms/prng.go). Another seed is set with "-seed". The header
+// has every flag and spec option that changes the output.
+//
+// A JSON spec file given with "-spec" selects generators and sets lengths
+// such as the AES key lengths (see internal/spec).
+//
+// Progress is logged to standard error. Output files are replaced atomically,
+// so a failed run leaves the previous files unchanged. The exit code is 1 if
+// generation fails and 2 for invalid arguments.
+//
+// Each algorithm is described by one or more suites. A suite is a list of
+// vectors and a snippet of Dart code that is run for every vector. The fields
+// of a vector are declared as Dart local variables before the snippet.
+//
+// To add an algorithm family, write a file such as foo.go in
+// internal/algorithms with a function that returns its suites, and register
+// it in an init function of the file with the files of the helpers that it
+// calls (TestGeneratorFiles lists missing ones). Add foo_test.go next to it
+// with a test that checks a vector against a known answer or another
+// implementation. The Covers of every suite must be in algorithms.json.
+// Nothing else needs to change: main only dispatches subcommands.
+//
+// Suites are cached in "generated_cache.json" in the output directory, which
+// is not committed, and a generator only runs again when a Go file of the
+// generator, the flags it depends on or the module versions change. Use
+// "-force" to run every generator.
+//
+// The output is byte-identical for identical vectors: suites are sorted by
+// name and vectors by name (with numbers compared by value), so a diff of the
+// generated files only shows changed vectors.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/emit"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+const outputPath = "generated_test.dart"
+
+// errUsage is returned for invalid command line arguments.
+var errUsage = errors.New("usage error")
+
+func main() {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "fetch":
+		err = runFetch(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "coverage":
+		err = runCoverage(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "wycheproof":
+		err = runWycheproof(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "cavp":
+		err = runCavp(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "jwk":
+		err = runJwk(os.Args[2:])
+	default:
+		err = generate(os.Args[1:])
+	}
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		slog.Error("failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	emitMode := flags.String("emit", "tests", `"tests" writes a Dart test for every vector, "data" writes the vectors to `+emit.DataPath+` and a Dart test that loads them`)
+	out := flags.String("out", ".", "directory of the output files")
+	names := flags.String("algorithms", "", `comma-separated names of the generators to run, such as "blake2b,hash" (default every generator)`)
+	skip := flags.String("skip", "", "comma-separated names of generators not to run")
+	force := flags.Bool("force", false, "run every generator even if "+algorithms.CachePath+" has its suites")
+	flags.IntVar(&algorithms.Cycles, "cycles", algorithms.DefaultCycles, "number of cycles of the cycle suites")
+	flags.Uint64Var(&algorithms.Seed, "seed", algorithms.DefaultSeed, "seed of the pseudorandom inputs")
+	specPath := flags.String("spec", "", "JSON file that selects generators and lengths (see internal/spec)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if *specPath != "" {
+		var err error
+		if algorithms.Spec, err = spec.Read(*specPath); err != nil {
+			return err
+		}
+		set := map[string]bool{}
+		flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		if !set["algorithms"] {
+			*names = strings.Join(algorithms.Spec.Algorithms, ",")
+		}
+		if !set["skip"] {
+			*skip = strings.Join(algorithms.Spec.Skip, ",")
+		}
+		if !set["cycles"] && algorithms.Spec.Cycles > 0 {
+			algorithms.Cycles = algorithms.Spec.Cycles
+		}
+		if !set["seed"] && algorithms.Spec.Seed != nil {
+			algorithms.Seed = *algorithms.Spec.Seed
+		}
+	}
+	if *emitMode != "tests" && *emitMode != "data" {
+		fmt.Fprintf(flags.Output(), "invalid value %q for flag -emit\n", *emitMode)
+		flags.Usage()
+		return errUsage
+	}
+	if algorithms.Cycles < 1 {
+		fmt.Fprintf(flags.Output(), "invalid value %d for flag -cycles\n", algorithms.Cycles)
+		flags.Usage()
+		return errUsage
+	}
+	generators, err := algorithms.SelectGenerators(*names, *skip)
+	if err != nil {
+		fmt.Fprintln(flags.Output(), err)
+		flags.Usage()
+		return errUsage
+	}
+
+	if err := algorithms.SelfCheck(); err != nil {
+		return err
+	}
+
+	cache, err := algorithms.ReadCache(*out)
+	if err != nil {
+		slog.Warn("ignoring cache", "err", err)
+		cache = algorithms.NewCache()
+	}
+	if *force {
+		cache = algorithms.NewCache()
+	}
+	suites, err := algorithms.CollectSuites(generators, cache)
+	if err != nil {
+		return err
+	}
+
+	// The comment has the flags that change the output, not -out and -force.
+	var commandArgs []string
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name != "out" && f.Name != "force" {
+			commandArgs = append(commandArgs, "-"+f.Name, f.Value.String())
+		}
+	})
+	comment := emit.Comment(commandArgs, algorithms.GeneratorFlags(generators))
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	output := filepath.Join(*out, outputPath)
+	if *emitMode == "data" {
+		if err := writeFileAtomic(filepath.Join(*out, emit.DataPath), func(w io.Writer) error {
+			return emit.WriteData(w, suites)
+		}); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(output, func(w io.Writer) error {
+			return emit.WriteDataDart(w, comment, suites, *out)
+		}); err != nil {
+			return err
+		}
+	} else if err := writeFileAtomic(output, func(w io.Writer) error {
+		return emit.WriteDart(w, comment, suites)
+	}); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(*out, algorithms.CachePath), cache.Write); err != nil {
+		return err
+	}
+	slog.Info("wrote", "path", output, "suites", len(suites))
+	return nil
+}
+
+// writeFileAtomic writes a file by writing a temporary file in the same
+// directory and renaming it. An existing file is left unchanged if anything
+// fails.
+func writeFileAtomic(path string, write func(w io.Writer) error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		tmp.Close()
+		if removeErr := os.Remove(tmp.Name()); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+			slog.Warn("removing temporary file", "path", tmp.Name(), "err", removeErr)
+		}
+	}()
+	w := bufio.NewWriter(tmp)
+	if err := write(w); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/emit"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, mode := range []string{"tests", "data"} {
+		t.Run(mode, func(t *testing.T) {
+			out := t.TempDir()
+			if err := generate([]string{"-algorithms", "siphash", "-emit", mode, "-out", out}); err != nil {
+				t.Fatal(err)
+			}
+			b, err := os.ReadFile(filepath.Join(out, outputPath))
+			if err != nil {
+				t.Fatal(err)
+			}
+			header := "go run ./cmd/vectorgen -algorithms siphash -emit " + mode
+			if !strings.Contains(string(b), header) {
+				t.Errorf("%s does not have the header %q", outputPath, header)
+			}
+			paths := []string{algorithms.CachePath}
+			if mode == "data" {
+				paths = append(paths, emit.DataPath)
+			}
+			for _, path := range paths {
+				if _, err := os.Stat(filepath.Join(out, path)); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateUsage(t *testing.T) {
+	for _, args := range [][]string{
+		{"-emit", "yaml"},
+		{"-cycles", "0"},
+		{"-algorithms", "no-such-generator"},
+		{"-no-such-flag"},
+	} {
+		if err := generate(append(args, "-out", t.TempDir())); !errors.Is(err, errUsage) {
+			t.Errorf("generate(%q) = %v, want errUsage", args, err)
+		}
+	}
+}
+
+func TestFetchUsage(t *testing.T) {
+	t.Chdir(filepath.Join("..", ".."))
+	if err := runFetch([]string{"-cache", t.TempDir(), "no-such-corpus"}); !errors.Is(err, errUsage) {
+		t.Errorf("got %v, want errUsage", err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/emit"
+)
+
+// wycheproofOutputPath is the Dart test file of "go run ./cmd/vectorgen wycheproof".
+const wycheproofOutputPath = "wycheproof_test.dart"
+
+const wycheproofDartComment = `// GENERATED CODE - DO NOT MODIFY BY HAND.
+//
+// Generated by "go run ./cmd/vectorgen wycheproof" in test/algorithms/generated.
+// The test cases are from Wycheproof (https://github.com/C2SP/wycheproof).
+
+// ignore_for_file: unused_element, unused_local_variable
+
+`
+
+// runWycheproof implements the "wycheproof" subcommand.
+func runWycheproof(args []string) error {
+	flags := flag.NewFlagSet("wycheproof", flag.ContinueOnError)
+	cacheDir := flags.String("cache", algorithms.DefaultCacheDir(), "cache directory of \"go run ./cmd/vectorgen fetch\"")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	suites, err := algorithms.WycheproofSuites(*cacheDir)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(wycheproofOutputPath, func(w io.Writer) error {
+		return emit.WriteDart(w, wycheproofDartComment, suites)
+	}); err != nil {
+		return err
+	}
+	slog.Info("wrote", "path", wycheproofOutputPath, "suites", len(suites))
+	return nil
+}
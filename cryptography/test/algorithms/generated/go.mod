@@ -0,0 +1,16 @@
+module github.com/dint-dev/cryptography/cryptography/test/algorithms/generated
+
+go 1.25.0
+
+require (
+	filippo.io/edwards25519 v1.2.0
+	github.com/cloudflare/circl v1.6.1
+	github.com/dchest/blake2b v1.0.0
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.1
+	golang.org/x/crypto v0.54.0
+)
+
+require (
+	github.com/bwesterb/go-ristretto v1.2.3 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
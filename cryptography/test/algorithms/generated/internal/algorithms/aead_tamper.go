@@ -0,0 +1,154 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Decryption of modified secret boxes, which must fail with
+// SecretBoxAuthenticationError. Every vector is checked to fail in Go too.
+// The HMAC of AesCbc only covers the cipher text, so a modified nonce or AAD
+// is not detected and AES-CBC only has the MAC and cipher text cases.
+
+const aeadTamperBody = `
+final algorithm = %s;
+await expectLater(
+  algorithm.decrypt(
+    SecretBox(cipherText, nonce: nonce, mac: Mac(mac)),
+    secretKey: SecretKey(secretKey),
+    aad: aad,
+  ),
+  throwsA(isA<SecretBoxAuthenticationError>()),
+);
+`
+
+// flipBit returns a copy of b with the lowest bit of b[i] flipped.
+func flipBit(b []byte, i int) []byte {
+	out := append([]byte(nil), b...)
+	out[i] ^= 1
+	return out
+}
+
+// secretBoxFields are the fields of a secret box.
+type secretBoxFields struct {
+	nonce, aad, cipherText, mac []byte
+}
+
+func init() {
+	register(newGenerator("modified-secret-boxes", aeadTamperSuites).
+		uses("aes_cbc.go", "bytes.go", "dart.go", "suite.go"))
+}
+
+func aeadTamperSuites() ([]*Suite, error) {
+	key := sequence(0, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	chacha, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	xchacha, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	aeadOpen := func(aead cipher.AEAD) func(b secretBoxFields) error {
+		return func(b secretBoxFields) error {
+			_, err := aead.Open(nil, b.nonce, concat(b.cipherText, b.mac), b.aad)
+			return err
+		}
+	}
+	aeadSeal := func(aead cipher.AEAD) func(nonce, clearText, aad []byte) secretBoxFields {
+		return func(nonce, clearText, aad []byte) secretBoxFields {
+			sealed := aead.Seal(nil, nonce, clearText, aad)
+			n := len(clearText)
+			return secretBoxFields{nonce, aad, sealed[:n], sealed[n:]}
+		}
+	}
+	cbcMac := aesCbcMacs[0]
+
+	var suites []*Suite
+	for _, c := range []struct {
+		name, dart, covers string
+		nonceLength        int
+		authenticatesNonce bool
+		seal               func(nonce, clearText, aad []byte) secretBoxFields
+		open               func(b secretBoxFields) error
+	}{
+		{"aes-gcm", "AesGcm.with256bits()", "AesGcm", 12, true, aeadSeal(gcm), aeadOpen(gcm)},
+		{"chacha20-poly1305", "Chacha20.poly1305Aead()", "Chacha20.poly1305Aead", 12, true, aeadSeal(chacha), aeadOpen(chacha)},
+		{"xchacha20-poly1305", "Xchacha20.poly1305Aead()", "Xchacha20.poly1305Aead", 24, true, aeadSeal(xchacha), aeadOpen(xchacha)},
+		{
+			"aes-cbc", "AesCbc.with256bits(macAlgorithm: Hmac.sha256())", "AesCbc macAlgorithm=Hmac.sha256", 16, false,
+			func(nonce, clearText, aad []byte) secretBoxFields {
+				// aesCbcEncrypt only fails for invalid keys.
+				cipherText, _ := aesCbcEncrypt(key, nonce, clearText)
+				return secretBoxFields{nonce, aad, cipherText, cbcMac.mac(key, cipherText)}
+			},
+			func(b secretBoxFields) error {
+				if !hmac.Equal(cbcMac.mac(key, b.cipherText), b.mac) {
+					return errors.New("wrong MAC")
+				}
+				return nil
+			},
+		},
+	} {
+		s := &Suite{
+			Name:   c.name + ": modified secret box",
+			Body:   fmt.Sprintf(aeadTamperBody, c.dart),
+			Covers: []string{c.covers},
+		}
+		nonce := sequence(0x80, c.nonceLength)
+		for _, n := range []int{1, 17, 64} {
+			var aad []byte
+			if c.authenticatesNonce {
+				aad = sequence(0xa0, 13)
+			}
+			b := c.seal(nonce, sequence(0x01, n), aad)
+			type modification struct {
+				name string
+				box  secretBoxFields
+			}
+			modifications := []modification{
+				{"first MAC byte", secretBoxFields{b.nonce, b.aad, b.cipherText, flipBit(b.mac, 0)}},
+				{"last MAC byte", secretBoxFields{b.nonce, b.aad, b.cipherText, flipBit(b.mac, len(b.mac)-1)}},
+				{"last cipher text byte", secretBoxFields{b.nonce, b.aad, flipBit(b.cipherText, len(b.cipherText)-1), b.mac}},
+				{"truncated MAC", secretBoxFields{b.nonce, b.aad, b.cipherText, b.mac[:len(b.mac)-1]}},
+			}
+			if c.authenticatesNonce {
+				modifications = append(modifications,
+					modification{"first nonce byte", secretBoxFields{flipBit(b.nonce, 0), b.aad, b.cipherText, b.mac}},
+					modification{"last AAD byte", secretBoxFields{b.nonce, flipBit(b.aad, len(b.aad)-1), b.cipherText, b.mac}},
+					modification{"missing AAD", secretBoxFields{b.nonce, nil, b.cipherText, b.mac}},
+				)
+			}
+			for _, m := range modifications {
+				if c.open(m.box) == nil {
+					return nil, fmt.Errorf("%s: %s: modified secret box was accepted", c.name, m.name)
+				}
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%s, modified %s", describeLength(n), m.name),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", m.box.nonce},
+						{"aad", m.box.aad},
+						{"cipherText", m.box.cipherText},
+						{"mac", m.box.mac},
+					},
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,24 @@
+package algorithms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAeadTamper(t *testing.T) {
+	suites, err := aeadTamperSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	findVector(t, suites, "aes-gcm: modified secret box", "17 bytes, modified first nonce byte")
+	for _, s := range suites {
+		if s.Name != "aes-cbc: modified secret box" {
+			continue
+		}
+		for _, v := range s.Vectors {
+			if strings.Contains(v.Name, "nonce") || strings.Contains(v.Name, "AAD") {
+				t.Errorf("%s: HMAC of AES-CBC does not authenticate it", v.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
+// AES with 192-bit keys in every mode. The Web Cryptography API does not
+// support 192-bit keys, so BrowserCryptography returns the pure Dart
+// implementations and these suites test them in the browser as well. Each
+// suite has the NIST examples and every clear text length up to three
+// blocks.
+
+// aes192Key is the key of the NIST SP 800-38A examples.
+var aes192Key = mustHex("8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b")
+
+// sp80038aClearText is the clear text of the NIST SP 800-38A examples.
+var sp80038aClearText = mustHex("6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710")
+
+func init() {
+	register(newGenerator("aes-192", aes192Suites).
+		uses("aes_cbc.go", "aes_ctr.go", "aes_gcm.go", "bytes.go", "chacha20_poly1305.go", "dart.go", "suite.go"))
+}
+
+func aes192Suites() ([]*Suite, error) {
+	block, err := aes.NewCipher(aes192Key)
+	if err != nil {
+		return nil, err
+	}
+	var lengths []int
+	for n := 0; n <= 3*aes.BlockSize; n++ {
+		lengths = append(lengths, n)
+	}
+
+	cbc := &Suite{
+		Name:   "aes-192: cbc",
+		Body:   fmt.Sprintf(aesCbcBody, 192, "MacAlgorithm.empty"),
+		Covers: []string{"AesCbc secretKeyLength=24 macAlgorithm=empty"},
+	}
+	noMac := aesCbcMacs[1]
+	// F.2.3 followed by a block of padding.
+	v, err := aesCbcVector(noMac, aes192Key, sequence(0, aes.BlockSize), sp80038aClearText)
+	if err != nil {
+		return nil, err
+	}
+	v.Name = "SP 800-38A F.2.3"
+	cbc.Vectors = append(cbc.Vectors, v)
+	for _, n := range lengths {
+		v, err := aesCbcVector(noMac, aes192Key, sequence(0x80, aes.BlockSize), sequence(0x01, n))
+		if err != nil {
+			return nil, err
+		}
+		cbc.Vectors = append(cbc.Vectors, v)
+	}
+
+	ctr := &Suite{
+		Name:   "aes-192: ctr",
+		Body:   fmt.Sprintf(aesCtrBody, 192),
+		Covers: []string{"AesCtr secretKeyLength=24 counterBits=64 macAlgorithm=empty"},
+	}
+	ctrVector := func(name string, nonce, clearText []byte) {
+		cipherText := make([]byte, len(clearText))
+		cipher.NewCTR(block, nonce).XORKeyStream(cipherText, clearText)
+		ctr.Vectors = append(ctr.Vectors, Vector{
+			Name: name,
+			Fields: []Field{
+				{"secretKey", aes192Key},
+				{"nonce", nonce},
+				{"clearText", clearText},
+				{"cipherText", cipherText},
+			},
+		})
+	}
+	ctrVector("SP 800-38A F.5.3", mustHex("f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff"), sp80038aClearText)
+	for _, n := range lengths {
+		ctrVector(describeLength(n), concat(sequence(0x80, 8), make([]byte, 8)), sequence(0x01, n))
+	}
+
+	gcm := &Suite{
+		Name:   "aes-192: gcm",
+		Body:   fmt.Sprintf(aesGcmBody, 192),
+		Covers: []string{"AesGcm secretKeyLength=24 nonceLength=12"},
+	}
+	gcmVector := func(name string, key, nonce, clearText, aad []byte) error {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return err
+		}
+		sealed := aead.Seal(nil, nonce, clearText, aad)
+		gcm.Vectors = append(gcm.Vectors, Vector{
+			Name: name,
+			Fields: []Field{
+				{"secretKey", key},
+				{"nonce", nonce},
+				{"aad", aad},
+				{"clearText", clearText},
+				{"cipherText", sealed[:len(clearText)]},
+				{"mac", sealed[len(clearText):]},
+			},
+		})
+		return nil
+	}
+	// Test cases 7 and 8 of the GCM specification.
+	if err := gcmVector("GCM specification test case 7", make([]byte, 24), make([]byte, 12), nil, nil); err != nil {
+		return nil, err
+	}
+	if err := gcmVector("GCM specification test case 8", make([]byte, 24), make([]byte, 12), make([]byte, 16), nil); err != nil {
+		return nil, err
+	}
+	for _, n := range lengths {
+		if err := gcmVector(describeLength(n), aes192Key, sequence(0x80, 12), sequence(0x01, n), sequence(0xa0, 13)); err != nil {
+			return nil, err
+		}
+	}
+	addConcatenation(gcm)
+	return []*Suite{cbc, ctr, gcm}, nil
+}
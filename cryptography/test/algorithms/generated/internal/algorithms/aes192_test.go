@@ -0,0 +1,32 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestAes192(t *testing.T) {
+	suites, err := aes192Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		suite, vector, field, want string
+	}{
+		{"aes-192: cbc", "SP 800-38A F.2.3", "cipherText", "4f021db243bc633d7178183a9fa071e8b4d9ada9ad7dedf4e5e738763f69145a571b242012fb7ae07fa9baac3df102e008b0e27988598881d920a9e64f5615cd"},
+		{"aes-192: ctr", "SP 800-38A F.5.3", "cipherText", "1abc932417521ca24f2b0459fe7e6e0b090339ec0aa6faefd5ccc2c6f4ce8e941e36b26bd1ebc670d1bd1d665620abf74f78a7f6d29809585a97daec58c6b050"},
+		{"aes-192: gcm", "GCM specification test case 7", "mac", "cd33b28ac773f74ba00ed1f312572435"},
+		{"aes-192: gcm", "GCM specification test case 8", "cipherText", "98e7247c07f0fe411c267e4384b0f600"},
+		{"aes-192: gcm", "GCM specification test case 8", "mac", "2ff58d80033927ab8ef4d4587514f0fb"},
+	} {
+		v := findVector(t, suites, tc.suite, tc.vector)
+		got := fieldValue(t, v, tc.field).([]byte)
+		if tc.suite == "aes-192: cbc" {
+			// Without the padding block.
+			got = got[:64]
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%s: %s: %s = %x, want %s", tc.suite, tc.vector, tc.field, got, tc.want)
+		}
+	}
+}
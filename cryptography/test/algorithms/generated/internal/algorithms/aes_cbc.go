@@ -0,0 +1,276 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// AES-CBC with PKCS7 padding and HMAC-SHA256 of the cipher text, which is what
+// AesCbc(macAlgorithm: Hmac.sha256()) computes. The MAC uses the AES key.
+// Without a MAC (MacAlgorithm.empty), the output is the same as "openssl enc"
+// and crypto-js with an explicit key and IV.
+
+const aesCbcBody = `
+final algorithm = AesCbc.with%dbits(macAlgorithm: %s);
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(mac),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac(mac)),
+  secretKey: SecretKey(secretKey),
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+// aesCbcBlocksBody compares the cipher text block by block, so a failure
+// reports the first wrong block. In CBC, a cipher text block is also the
+// chaining value of the next block.
+const aesCbcBlocksBody = `
+final algorithm = AesCbc.with256bits(macAlgorithm: MacAlgorithm.empty);
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+for (var i = 0; i < cipherText.length; i += 16) {
+  expect(
+    hexFromBytes(secretBox.cipherText.skip(i).take(16)),
+    hexFromBytes(cipherText.sublist(i, i + 16)),
+    reason: 'block ${i ~/ 16}',
+  );
+}
+expect(secretBox.cipherText.length, cipherText.length);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(secretKey),
+);
+for (var i = 0; i < clearText.length; i += 16) {
+  expect(
+    hexFromBytes(decrypted.skip(i).take(16)),
+    hexFromBytes(clearText.skip(i).take(16)),
+    reason: 'block ${i ~/ 16}',
+  );
+}
+expect(decrypted.length, clearText.length);
+`
+
+const aesCbcPaddingSkip = "AesCbc in package:cryptography only supports PKCS7 padding"
+
+// pkcs7Pad pads the data to a multiple of the block size.
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	n := blockSize - len(data)%blockSize
+	return append(append([]byte(nil), data...), bytes.Repeat([]byte{byte(n)}, n)...)
+}
+
+// zeroPad pads the data with zeros to a multiple of the block size. Unlike
+// PKCS7, nothing is added to data that is already a multiple of the block
+// size, so trailing zeros of the clear text cannot be told from padding.
+func zeroPad(data []byte, blockSize int) []byte {
+	n := (blockSize - len(data)%blockSize) % blockSize
+	return append(append([]byte(nil), data...), make([]byte, n)...)
+}
+
+// aesCbcEncrypt pads and encrypts the clear text.
+func aesCbcEncrypt(key, iv, clearText []byte) ([]byte, error) {
+	return aesCbcEncryptBlocks(key, iv, pkcs7Pad(clearText, aes.BlockSize))
+}
+
+// aesCbcEncryptBlocks encrypts data that is a multiple of the block size.
+func aesCbcEncryptBlocks(key, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("aes-cbc: %d bytes is not a multiple of the block size", len(data))
+	}
+	cipherText := append([]byte(nil), data...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(cipherText, cipherText)
+	return cipherText, nil
+}
+
+// aesCbcMac is the MAC of AES-CBC suites.
+type aesCbcMac struct {
+	// name is the suffix of the suite names.
+	name string
+
+	// dart is a Dart expression for the MacAlgorithm.
+	dart string
+
+	// covers is the macAlgorithm parameter value in algorithms.json.
+	covers string
+
+	// mac returns the MAC of the cipher text, or nil.
+	mac func(key, cipherText []byte) []byte
+}
+
+var aesCbcMacs = []aesCbcMac{
+	{"HMAC-SHA256", "Hmac.sha256()", "Hmac.sha256", func(key, cipherText []byte) []byte {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(cipherText)
+		return mac.Sum(nil)
+	}},
+	{"no MAC", "MacAlgorithm.empty", "empty", func(key, cipherText []byte) []byte {
+		return nil
+	}},
+}
+
+func init() {
+	register(newGenerator("aes-cbc", aesCbcSuites, spec.AesKeyLengths, spec.Seed).
+		uses("aes192.go", "bytes.go", "dart.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func aesCbcSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, m := range aesCbcMacs {
+		for _, keyLength := range aesKeyLengths() {
+			s := &Suite{
+				Name:   fmt.Sprintf("aes-cbc: %d-bit key, %s", 8*keyLength, m.name),
+				Body:   fmt.Sprintf(aesCbcBody, 8*keyLength, m.dart),
+				Covers: []string{fmt.Sprintf("AesCbc secretKeyLength=%d macAlgorithm=%s", keyLength, m.covers)},
+			}
+			// Every length around the first block boundaries, which is
+			// where padding and the last block are easy to get wrong.
+			var lengths []int
+			if keyLength == 32 {
+				for n := 0; n <= 130; n++ {
+					lengths = append(lengths, n)
+				}
+			} else {
+				lengths = []int{0, 1, 15, 16, 17, 31, 32, 33, 130}
+			}
+			key := sequence(0, keyLength)
+			nonce := sequence(0x80, aes.BlockSize)
+			for _, n := range lengths {
+				v, err := aesCbcVector(m, key, nonce, randomBytes(s.Name+": clearText", n))
+				if err != nil {
+					return nil, err
+				}
+				s.Vectors = append(s.Vectors, v)
+			}
+			if m.covers == "empty" && keyLength == 32 {
+				// NIST SP 800-38A F.2.5 followed by a block of padding.
+				v, err := aesCbcVector(m,
+					mustHex("603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4"),
+					sequence(0, aes.BlockSize),
+					sp80038aClearText,
+				)
+				if err != nil {
+					return nil, err
+				}
+				v.Name = "SP 800-38A F.2.5"
+				s.Vectors = append(s.Vectors, v)
+			}
+			suites = append(suites, s)
+		}
+	}
+
+	// A few vectors compared block by block.
+	noMac := aesCbcMacs[1]
+	blocks := &Suite{
+		Name:   "aes-cbc: block by block",
+		Body:   aesCbcBlocksBody,
+		Covers: []string{"AesCbc secretKeyLength=32 macAlgorithm=empty"},
+	}
+	v, err := aesCbcVector(noMac,
+		mustHex("603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4"),
+		sequence(0, aes.BlockSize),
+		sp80038aClearText,
+	)
+	if err != nil {
+		return nil, err
+	}
+	v.Name = "SP 800-38A F.2.5"
+	blocks.Vectors = append(blocks.Vectors, v)
+	for _, n := range []int{17, 48, 100} {
+		v, err := aesCbcVector(noMac, sequence(0, 32), sequence(0x80, aes.BlockSize), sequence(0x01, n))
+		if err != nil {
+			return nil, err
+		}
+		blocks.Vectors = append(blocks.Vectors, v)
+	}
+	suites = append(suites, blocks)
+
+	// The same clear texts with every padding, so that a mismatch with
+	// another library shows which padding it uses.
+	for _, padding := range []struct {
+		name string
+		pad  func(data []byte, blockSize int) []byte
+	}{
+		{"pkcs7", pkcs7Pad},
+		{"zero", zeroPad},
+		{"none", nil},
+	} {
+		s := &Suite{
+			Name: "aes-cbc: " + padding.name + " padding",
+			Skip: aesCbcPaddingSkip,
+		}
+		key := sequence(0, 32)
+		nonce := sequence(0x80, aes.BlockSize)
+		for _, n := range []int{0, 1, 15, 16, 17, 31, 32, 33, 48} {
+			// Without padding, only whole blocks can be encrypted.
+			if padding.pad == nil && n%aes.BlockSize != 0 {
+				continue
+			}
+			clearText := sequence(0x01, n)
+			padded := clearText
+			if padding.pad != nil {
+				padded = padding.pad(clearText, aes.BlockSize)
+			}
+			cipherText, err := aesCbcEncryptBlocks(key, nonce, padded)
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: describeBytes(clearText),
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"padding", padding.name},
+					{"clearText", clearText},
+					{"paddedClearText", padded},
+					{"cipherText", cipherText},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// aesCbcVector returns a vector for the inputs.
+func aesCbcVector(m aesCbcMac, key, nonce, clearText []byte) (Vector, error) {
+	cipherText, err := aesCbcEncrypt(key, nonce, clearText)
+	if err != nil {
+		return Vector{}, err
+	}
+	return Vector{
+		Name: describeBytes(clearText),
+		Fields: []Field{
+			{"secretKey", key},
+			{"nonce", nonce},
+			{"clearText", clearText},
+			{"cipherText", cipherText},
+			{"mac", m.mac(key, cipherText)},
+		},
+	}, nil
+}
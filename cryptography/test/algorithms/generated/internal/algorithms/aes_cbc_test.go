@@ -0,0 +1,42 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestAesCbcEncrypt(t *testing.T) {
+	// RFC 3602 case 1. The second block is the PKCS7 padding.
+	cipherText, err := aesCbcEncrypt(
+		mustHex("06a9214036b8a15b512e03d534120006"),
+		mustHex("3dafba429d9eb430b422da802c9fac41"),
+		[]byte("Single block msg"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(cipherText[:16]), "e353779c1079aeb82708942dbe77181a"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if len(cipherText) != 32 {
+		t.Errorf("got %d bytes, want 32", len(cipherText))
+	}
+
+	suites, err := aesCbcSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "aes-cbc: 256-bit key, no MAC", "SP 800-38A F.2.5")
+	if got, want := hex.EncodeToString(fieldValue(t, v, "cipherText").([]byte)[:64]), "f58c4c04d6e5f1ba779eabfb5f7bfbd69cfc4e967edb808d679f777bc6702c7d39f23369a9d9bacfa530e26304231461b2eb05e2c39be9fcda6c19078c6a9d1b"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	checkHex(t, v, "mac", "")
+
+	// Zero padding adds nothing to whole blocks.
+	checkHex(t, findVector(t, suites, "aes-cbc: zero padding", "16 bytes"), "paddedClearText", hex.EncodeToString(sequence(0x01, 16)))
+	checkHex(t, findVector(t, suites, "aes-cbc: zero padding", "17 bytes"), "paddedClearText", hex.EncodeToString(concat(sequence(0x01, 17), make([]byte, 15))))
+
+	// The block by block suite has the same cipher texts.
+	blocks := findVector(t, suites, "aes-cbc: block by block", "SP 800-38A F.2.5")
+	checkHex(t, blocks, "cipherText", hex.EncodeToString(fieldValue(t, v, "cipherText").([]byte)))
+}
@@ -0,0 +1,89 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// AES-CTR with crypto/cipher. The 16-byte nonce is the initial counter block.
+// Like AesCtr, crypto/cipher increments the whole block as a big-endian
+// integer, so a layout is only a starting block and not a counter width. The
+// carry layouts start two blocks before the last 4 bytes wrap, so their
+// vectors record that the carry goes into the bytes before them. Every carry
+// stays within the low 64 bits, so the vectors are also valid for
+// counterBits = 64.
+
+const aesCtrBody = `
+final algorithm = AesCtr.with%dbits(macAlgorithm: MacAlgorithm.empty);
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(secretKey),
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+// aesCtrLayout is an initial counter block.
+type aesCtrLayout struct {
+	name  string
+	nonce []byte
+}
+
+func init() {
+	register(newGenerator("aes-ctr", aesCtrSuites, spec.AesKeyLengths, spec.InputLengths, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func aesCtrSuites() ([]*Suite, error) {
+	layouts := []aesCtrLayout{
+		{"counter block ending in 4 zero bytes", concat(sequence(0x80, 12), []byte{0, 0, 0, 0})},
+		{"counter block ending in 8 zero bytes", concat(sequence(0x80, 8), make([]byte, 8))},
+		{"last 4 bytes carry into zero bytes", concat(sequence(0x80, 8), []byte{0, 0, 0, 0, 0xff, 0xff, 0xff, 0xfe})},
+		{"last 4 bytes carry into nonzero bytes", concat(sequence(0x80, 12), []byte{0xff, 0xff, 0xff, 0xfe})},
+	}
+	var suites []*Suite
+	for _, keyLength := range aesKeyLengths() {
+		key := sequence(0, keyLength)
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		s := &Suite{
+			Name:   fmt.Sprintf("aes-ctr: %d-bit key", 8*keyLength),
+			Body:   fmt.Sprintf(aesCtrBody, 8*keyLength),
+			Covers: []string{fmt.Sprintf("AesCtr secretKeyLength=%d counterBits=64 macAlgorithm=empty", keyLength)},
+		}
+		for _, layout := range layouts {
+			for _, n := range inputLengths(0, 1, 15, 16, 17, 33, 100) {
+				clearText := randomBytes(s.Name+": clearText", n)
+				cipherText := make([]byte, n)
+				cipher.NewCTR(block, layout.nonce).XORKeyStream(cipherText, clearText)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: layout.name + ", " + describeBytes(clearText),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", layout.nonce},
+						{"clearText", clearText},
+						{"cipherText", cipherText},
+					},
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,32 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/subtle"
+	"testing"
+)
+
+func TestAesCtr(t *testing.T) {
+	suites, err := aesCtrSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "aes-ctr: 128-bit key", "last 4 bytes carry into nonzero bytes, 100 bytes")
+	block, err := aes.NewCipher(fieldValue(t, v, "secretKey").([]byte))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The third block wraps the last 4 bytes and increments the nonce byte
+	// before them, 0x8b.
+	counter := concat(sequence(0x80, 11), []byte{0x8c, 0, 0, 0, 0})
+	want := make([]byte, aes.BlockSize)
+	block.Encrypt(want, counter)
+	clearText := fieldValue(t, v, "clearText").([]byte)
+	cipherText := fieldValue(t, v, "cipherText").([]byte)
+	got := make([]byte, aes.BlockSize)
+	subtle.XORBytes(got, clearText[32:48], cipherText[32:48])
+	if !bytes.Equal(got, want) {
+		t.Errorf("third key stream block %x, want %x", got, want)
+	}
+}
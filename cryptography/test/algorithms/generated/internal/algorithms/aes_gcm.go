@@ -0,0 +1,300 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// AES-GCM with crypto/cipher. Nonces that are not 12 bytes are hashed with
+// GHASH to get the initial counter block.
+
+const aesGcmBody = `
+final algorithm = AesGcm.with%dbits(nonceLength: nonce.length);
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  aad: aad,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(mac),
+);
+expect(
+  hexFromBytes(secretBox.concatenation()),
+  hexFromBytes(concatenation),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox.fromConcatenation(
+    concatenation,
+    nonceLength: nonce.length,
+    macLength: mac.length,
+  ),
+  secretKey: SecretKey(secretKey),
+  aad: aad,
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+// AesGcm has no tag length parameter, so it must accept the full 16-byte
+// tag and reject the truncated ones. The cipher text does not depend on the
+// tag length.
+const aesGcmTagLengthBody = `
+final algorithm = AesGcm.with%dbits();
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  aad: aad,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+final received = SecretBox(cipherText, nonce: nonce, mac: Mac(mac));
+if (mac.length == 16) {
+  final decrypted = await algorithm.decrypt(
+    received,
+    secretKey: SecretKey(secretKey),
+    aad: aad,
+  );
+  expect(
+    hexFromBytes(decrypted),
+    hexFromBytes(clearText),
+  );
+} else {
+  await expectLater(
+    algorithm.decrypt(
+      received,
+      secretKey: SecretKey(secretKey),
+      aad: aad,
+    ),
+    throwsA(isA<SecretBoxAuthenticationError>()),
+  );
+}
+`
+
+func init() {
+	register(newGenerator("aes-gcm", aesGcmSuites, spec.AesKeyLengths, spec.Seed).
+		uses("bytes.go", "chacha20_poly1305.go", "dart.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func aesGcmSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, keyLength := range aesKeyLengths() {
+		for _, nonceLength := range []int{12, 8, 16} {
+			s := &Suite{
+				Name:   fmt.Sprintf("aes-gcm: %d-bit key, %d-byte nonce", 8*keyLength, nonceLength),
+				Body:   fmt.Sprintf(aesGcmBody, 8*keyLength),
+				Covers: []string{fmt.Sprintf("AesGcm secretKeyLength=%d nonceLength=%d", keyLength, nonceLength)},
+			}
+			key := sequence(0, keyLength)
+			nonce := sequence(0x80, nonceLength)
+			block, err := aes.NewCipher(key)
+			if err != nil {
+				return nil, err
+			}
+			gcm, err := cipher.NewGCMWithNonceSize(block, nonceLength)
+			if err != nil {
+				return nil, err
+			}
+			for _, lengths := range []struct{ clearText, aad int }{
+				{0, 0},
+				{0, 13},
+				{1, 0},
+				{15, 0},
+				{16, 0},
+				{17, 13},
+				{64, 20},
+				{100, 0},
+			} {
+				clearText := randomBytes(s.Name+": clearText", lengths.clearText)
+				aad := randomBytes(s.Name+": aad", lengths.aad)
+				sealed := gcm.Seal(nil, nonce, clearText, aad)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%s, %s of AAD", describeBytes(clearText), describeBytes(aad)),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", nonce},
+						{"aad", aad},
+						{"clearText", clearText},
+						{"cipherText", sealed[:len(clearText)]},
+						{"mac", sealed[len(clearText):]},
+					},
+				})
+			}
+			addConcatenation(s)
+			suites = append(suites, s)
+
+			if nonceLength != 12 {
+				continue
+			}
+			// GHASH pads the AAD to 16 bytes before the cipher text.
+			aadLengths := &Suite{
+				Name:   fmt.Sprintf("aes-gcm: %d-bit key, AAD length", 8*keyLength),
+				Body:   s.Body,
+				Covers: s.Covers,
+			}
+			vectors, err := aeadAadLengthVectors(func(clearText, aad []byte) ([]byte, []byte, error) {
+				sealed := gcm.Seal(nil, nonce, clearText, aad)
+				return sealed[:len(clearText)], sealed[len(clearText):], nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range vectors {
+				v.Fields = append([]Field{{"secretKey", key}, {"nonce", nonce}}, v.Fields...)
+				aadLengths.Vectors = append(aadLengths.Vectors, v)
+			}
+			addConcatenation(aadLengths)
+			suites = append(suites, aadLengths)
+
+			// 96, 104, 112, 120 and 128 bits.
+			tagLengths := &Suite{
+				Name:   fmt.Sprintf("aes-gcm: %d-bit key, tag length", 8*keyLength),
+				Body:   fmt.Sprintf(aesGcmTagLengthBody, 8*keyLength),
+				Covers: s.Covers,
+			}
+			for _, tagLength := range []int{12, 13, 14, 15, 16} {
+				truncated, err := cipher.NewGCMWithTagSize(block, tagLength)
+				if err != nil {
+					return nil, err
+				}
+				for _, n := range []int{0, 17} {
+					clearText := sequence(0x01, n)
+					aad := sequence(0xa0, 13)
+					sealed := truncated.Seal(nil, nonce, clearText, aad)
+					tagLengths.Vectors = append(tagLengths.Vectors, Vector{
+						Name: fmt.Sprintf("%d-bit tag, %s", 8*tagLength, describeBytes(clearText)),
+						Fields: []Field{
+							{"secretKey", key},
+							{"nonce", nonce},
+							{"aad", aad},
+							{"clearText", clearText},
+							{"cipherText", sealed[:len(clearText)]},
+							{"mac", sealed[len(clearText):]},
+						},
+					})
+				}
+			}
+			suites = append(suites, tagLengths)
+		}
+	}
+
+	nonces, err := aesGcmNonceSuite()
+	if err != nil {
+		return nil, err
+	}
+	return append(suites, nonces), nil
+}
+
+// aesGcmNonceSuite returns vectors with nonces of many lengths and the
+// initial counter block J0 that GCM derives from them.
+func aesGcmNonceSuite() (*Suite, error) {
+	s := &Suite{
+		Name: "aes-gcm: nonce length",
+		Body: fmt.Sprintf(aesGcmBody, 128),
+		Covers: []string{
+			"AesGcm secretKeyLength=16 nonceLength=8",
+			"AesGcm secretKeyLength=16 nonceLength=16",
+		},
+	}
+	type input struct {
+		name                       string
+		key, nonce, clearText, aad []byte
+	}
+	// Test cases 5 and 6 of the GCM specification.
+	specKey := mustHex("feffe9928665731c6d6a8f9467308308")
+	specClearText := mustHex("d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a721c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b39")
+	specAad := mustHex("feedfacedeadbeeffeedfacedeadbeefabaddad2")
+	inputs := []input{
+		{"GCM specification test case 5", specKey, mustHex("cafebabefacedbad"), specClearText, specAad},
+		{"GCM specification test case 6", specKey, mustHex("9313225df88406e555909c5aff5269aa6a7a9538534f7da1e4c303d2a318a728c3c0c95156809539fcf0e2429a6b525416aedbf5a0de6a57a637b39b"), specClearText, specAad},
+	}
+	for _, n := range []int{4, 8, 11, 12, 13, 15, 16, 17, 32, 60} {
+		inputs = append(inputs, input{fmt.Sprintf("%d-byte nonce", n), sequence(0, 16), sequence(0x80, n), sequence(0x01, 33), sequence(0xa0, 13)})
+	}
+	for _, in := range inputs {
+		block, err := aes.NewCipher(in.key)
+		if err != nil {
+			return nil, err
+		}
+		gcm, err := cipher.NewGCMWithNonceSize(block, len(in.nonce))
+		if err != nil {
+			return nil, err
+		}
+		sealed := gcm.Seal(nil, in.nonce, in.clearText, in.aad)
+		s.Vectors = append(s.Vectors, Vector{
+			Name: in.name,
+			Fields: []Field{
+				{"secretKey", in.key},
+				{"nonce", in.nonce},
+				{"aad", in.aad},
+				{"clearText", in.clearText},
+				{"cipherText", sealed[:len(in.clearText)]},
+				{"mac", sealed[len(in.clearText):]},
+				{"j0", gcmCounterBlock(block, in.nonce)},
+			},
+		})
+	}
+	addConcatenation(s)
+	return s, nil
+}
+
+// gcmCounterBlock returns the initial counter block J0 of GCM (NIST SP
+// 800-38D section 7.1).
+func gcmCounterBlock(block cipher.Block, nonce []byte) []byte {
+	if len(nonce) == 12 {
+		return concat(nonce, []byte{0, 0, 0, 1})
+	}
+	h := make([]byte, 16)
+	block.Encrypt(h, h)
+	lengths := make([]byte, 16)
+	binary.BigEndian.PutUint64(lengths[8:], uint64(8*len(nonce)))
+	return ghash(h, concat(nonce, make([]byte, (16-len(nonce)%16)%16), lengths))
+}
+
+// ghash returns GHASH of data that is a multiple of 16 bytes.
+func ghash(h, data []byte) []byte {
+	y := make([]byte, 16)
+	for ; len(data) > 0; data = data[16:] {
+		for i := range y {
+			y[i] ^= data[i]
+		}
+		y = gcmMul(y, h)
+	}
+	return y
+}
+
+// gcmMul returns the product of x and y in GF(2^128) with the bit order of
+// GCM.
+func gcmMul(x, y []byte) []byte {
+	xHi, xLo := binary.BigEndian.Uint64(x), binary.BigEndian.Uint64(x[8:])
+	vHi, vLo := binary.BigEndian.Uint64(y), binary.BigEndian.Uint64(y[8:])
+	var zHi, zLo uint64
+	for i := 0; i < 128; i++ {
+		bit := xHi >> 63
+		xHi, xLo = xHi<<1|xLo>>63, xLo<<1
+		if bit == 1 {
+			zHi, zLo = zHi^vHi, zLo^vLo
+		}
+		lsb := vLo & 1
+		vHi, vLo = vHi>>1, vLo>>1|vHi<<63
+		if lsb == 1 {
+			vHi ^= 0xe1 << 56
+		}
+	}
+	out := binary.BigEndian.AppendUint64(nil, zHi)
+	return binary.BigEndian.AppendUint64(out, zLo)
+}
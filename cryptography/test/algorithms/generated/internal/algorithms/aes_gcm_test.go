@@ -0,0 +1,49 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+func TestAesGcmTagLength(t *testing.T) {
+	suites, err := aesGcmSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	full := findVector(t, suites, "aes-gcm: 128-bit key, tag length", "128-bit tag, 17 bytes")
+	short := findVector(t, suites, "aes-gcm: 128-bit key, tag length", "96-bit tag, 17 bytes")
+	mac := fieldValue(t, full, "mac").([]byte)
+	checkHex(t, short, "mac", hex.EncodeToString(mac[:12]))
+	checkHex(t, short, "cipherText", hex.EncodeToString(fieldValue(t, full, "cipherText").([]byte)))
+}
+
+func TestAesGcmNonce(t *testing.T) {
+	s, err := aesGcmNonceSuite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, findVector(t, []*Suite{s}, s.Name, "GCM specification test case 5"), "mac", "3612d2e79e3b0785561be14aaca2fccb")
+	checkHex(t, findVector(t, []*Suite{s}, s.Name, "GCM specification test case 6"), "mac", "619cc5aefffe0bfa462af43c1699d050")
+	// The cipher text is CTR with the counter block after J0.
+	for _, v := range s.Vectors {
+		fields := map[string][]byte{}
+		for _, f := range v.Fields {
+			fields[f.Name] = f.Value.([]byte)
+		}
+		block, err := aes.NewCipher(fields["secretKey"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		counter := append([]byte(nil), fields["j0"]...)
+		binary.BigEndian.PutUint32(counter[12:], binary.BigEndian.Uint32(counter[12:])+1)
+		got := make([]byte, len(fields["clearText"]))
+		cipher.NewCTR(block, counter).XORKeyStream(got, fields["clearText"])
+		if !bytes.Equal(got, fields["cipherText"]) {
+			t.Errorf("%s: j0 does not give the cipher text", v.Name)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// AES in OFB and CFB (CFB128) modes, which older Java and PHP systems use.
+// Neither pads, so the cipher text has the length of the clear text. The
+// 128-bit suites start with the NIST SP 800-38A examples.
+
+const aesOfbCfbSkip = "AES-OFB and AES-CFB are not implemented in package:cryptography"
+
+// sp80038aKey128 is the AES-128 key of the NIST SP 800-38A examples.
+var sp80038aKey128 = mustHex("2b7e151628aed2a6abf7158809cf4f3c")
+
+func init() {
+	register(newGenerator("aes-ofb-cfb", aesOfbCfbSuites, spec.AesKeyLengths, spec.InputLengths).
+		uses("aes192.go", "bytes.go", "dart.go", "spec.go", "suite.go"))
+}
+
+func aesOfbCfbSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, mode := range []struct {
+		name, example string
+		stream        func(block cipher.Block, iv []byte) cipher.Stream
+	}{
+		{"cfb", "SP 800-38A F.3.13", cipher.NewCFBEncrypter},
+		{"ofb", "SP 800-38A F.4.1", cipher.NewOFB},
+	} {
+		for _, keyLength := range aesKeyLengths() {
+			s := &Suite{
+				Name: fmt.Sprintf("aes-%s: %d-bit key", mode.name, 8*keyLength),
+				Skip: aesOfbCfbSkip,
+			}
+			add := func(name string, key, iv, clearText []byte) error {
+				block, err := aes.NewCipher(key)
+				if err != nil {
+					return err
+				}
+				cipherText := make([]byte, len(clearText))
+				mode.stream(block, iv).XORKeyStream(cipherText, clearText)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: name,
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", iv},
+						{"clearText", clearText},
+						{"cipherText", cipherText},
+					},
+				})
+				return nil
+			}
+			if keyLength == 16 {
+				if err := add(mode.example, sp80038aKey128, sequence(0, aes.BlockSize), sp80038aClearText); err != nil {
+					return nil, err
+				}
+			}
+			for _, n := range inputLengths(0, 1, 15, 16, 17, 33, 100) {
+				clearText := sequence(0x01, n)
+				if err := add(describeBytes(clearText), sequence(0, keyLength), sequence(0x80, aes.BlockSize), clearText); err != nil {
+					return nil, err
+				}
+			}
+			suites = append(suites, s)
+		}
+	}
+	return suites, nil
+}
@@ -0,0 +1,23 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestAesOfbCfb(t *testing.T) {
+	suites, err := aesOfbCfbSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first two blocks of the NIST examples.
+	for _, c := range []struct{ suite, vector, want string }{
+		{"aes-cfb: 128-bit key", "SP 800-38A F.3.13", "3b3fd92eb72dad20333449f8e83cfb4ac8a64537a0b3a93fcde3cdad9f1ce58b"},
+		{"aes-ofb: 128-bit key", "SP 800-38A F.4.1", "3b3fd92eb72dad20333449f8e83cfb4a7789508d16918f03f53c52dac54ed825"},
+	} {
+		v := findVector(t, suites, c.suite, c.vector)
+		if got := hex.EncodeToString(v.Fields[3].Value.([]byte)[:32]); got != c.want {
+			t.Errorf("%s: got %s, want %s", c.vector, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,447 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Argon2 (RFC 9106) version 0x13. The memory size is in KiB and is rounded
+// down to a multiple of 4*parallelism blocks. golang.org/x/crypto does not
+// support the secret and associated data inputs, so they are empty in the
+// Argon2id vectors.
+//
+// golang.org/x/crypto does not implement Argon2d either, so the Argon2i and
+// Argon2d vectors are computed with argon2Key, which is checked against
+// golang.org/x/crypto and the RFC 9106 test vectors.
+
+const argon2idBody = `
+final algorithm = Argon2id(
+  parallelism: parallelism,
+  memorySize: memorySize,
+  iterations: iterations,
+  hashLength: expected.length,
+);
+final secretKey = await algorithm.deriveKey(
+  secretKey: SecretKey(password),
+  nonce: salt,
+);
+expect(
+  hexFromBytes(await secretKey.extractBytes()),
+  hexFromBytes(expected),
+);
+`
+
+const argon2idSkip = "Argon2id in package:cryptography throws UnimplementedError"
+
+// argon2Params are the parameters of an Argon2 vector.
+type argon2Params struct {
+	memorySize, iterations, parallelism, hashLength int
+}
+
+// argon2DefaultParams are the parameters that a suite does not vary.
+var argon2DefaultParams = argon2Params{
+	memorySize:  64,
+	iterations:  2,
+	parallelism: 1,
+	hashLength:  32,
+}
+
+// argon2Salt is the salt of the vectors that do not vary the salt.
+var argon2Salt = sequence(0x80, 16)
+
+// argon2idVector returns an Argon2id vector. The cost is the memory size
+// times the number of iterations.
+func argon2idVector(p argon2Params, password, salt []byte) Vector {
+	return Vector{
+		Name: fmt.Sprintf("m=%d KiB, t=%d, p=%d, %d-byte salt, %d-byte output", p.memorySize, p.iterations, p.parallelism, len(salt), p.hashLength),
+		Fields: []Field{
+			{"password", password},
+			{"salt", salt},
+			{"memorySize", p.memorySize},
+			{"iterations", p.iterations},
+			{"parallelism", p.parallelism},
+			{"expected", argon2.IDKey(password, salt, uint32(p.iterations), uint32(p.memorySize), uint8(p.parallelism), uint32(p.hashLength))},
+		},
+		Cost: p.memorySize * p.iterations,
+	}
+}
+
+func init() {
+	register(newGenerator("argon2id", argon2idSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func argon2idSuites() ([]*Suite, error) {
+	// Every combination of the parameters in algorithms.json.
+	grid := &Suite{
+		Name: "argon2id: parallelism and hash length",
+		Body: argon2idBody,
+		Skip: argon2idSkip,
+	}
+	for _, parallelism := range []int{1, 2, 4} {
+		for _, hashLength := range []int{16, 32, 64} {
+			p := argon2DefaultParams
+			p.parallelism = parallelism
+			p.hashLength = hashLength
+			grid.Covers = append(grid.Covers, fmt.Sprintf("Argon2id parallelism=%d hashLength=%d", parallelism, hashLength))
+			grid.Vectors = append(grid.Vectors, argon2idVector(p, []byte("password"), argon2Salt))
+		}
+	}
+
+	// 37 KiB with 4 lanes is rounded down to 32 blocks.
+	memory := &Suite{
+		Name:   "argon2id: memory size",
+		Body:   argon2idBody,
+		Skip:   argon2idSkip,
+		Covers: []string{"Argon2id", "Argon2id parallelism=4"},
+	}
+	for _, tc := range []struct{ memorySize, parallelism int }{
+		{8, 1}, {32, 1}, {1024, 1}, {65536, 1}, {37, 4},
+	} {
+		p := argon2DefaultParams
+		p.memorySize = tc.memorySize
+		p.parallelism = tc.parallelism
+		memory.Vectors = append(memory.Vectors, argon2idVector(p, []byte("password"), argon2Salt))
+	}
+
+	iterations := &Suite{
+		Name:   "argon2id: iterations",
+		Body:   argon2idBody,
+		Skip:   argon2idSkip,
+		Covers: []string{"Argon2id"},
+	}
+	for _, n := range []int{1, 3, 10} {
+		p := argon2DefaultParams
+		p.iterations = n
+		iterations.Vectors = append(iterations.Vectors, argon2idVector(p, []byte("password"), argon2Salt))
+	}
+
+	salts := &Suite{
+		Name:   "argon2id: salt length",
+		Body:   argon2idBody,
+		Skip:   argon2idSkip,
+		Covers: []string{"Argon2id"},
+	}
+	for _, n := range []int{8, 32, 64} {
+		salts.Vectors = append(salts.Vectors, argon2idVector(argon2DefaultParams, []byte("password"), sequence(0x80, n)))
+	}
+
+	// Outputs longer than 64 bytes use the variable-length hash H'.
+	outputs := &Suite{
+		Name: "argon2id: output length",
+		Body: argon2idBody,
+		Skip: argon2idSkip,
+	}
+	for _, n := range []int{4, 63, 65, 128, 1000} {
+		p := argon2DefaultParams
+		p.hashLength = n
+		outputs.Vectors = append(outputs.Vectors, argon2idVector(p, []byte("password"), argon2Salt))
+	}
+	return []*Suite{grid, memory, iterations, salts, outputs}, nil
+}
+
+const argon2VariantsSkip = "Argon2i and Argon2d are not implemented in package:cryptography"
+
+// argon2Vector returns a vector of the Argon2 type. Unlike argon2idVector,
+// the vector has the secret and associated data inputs.
+func argon2Vector(typ int, p argon2Params, password, salt, secret, data []byte) Vector {
+	name := fmt.Sprintf("m=%d KiB, t=%d, p=%d, %d-byte salt, %d-byte output", p.memorySize, p.iterations, p.parallelism, len(salt), p.hashLength)
+	if len(secret) > 0 || len(data) > 0 {
+		name += fmt.Sprintf(", %d-byte secret, %d-byte associated data", len(secret), len(data))
+	}
+	return Vector{
+		Name: name,
+		Fields: []Field{
+			{"password", password},
+			{"salt", salt},
+			{"secret", secret},
+			{"associatedData", data},
+			{"memorySize", p.memorySize},
+			{"iterations", p.iterations},
+			{"parallelism", p.parallelism},
+			{"expected", argon2Key(typ, password, salt, secret, data, p.iterations, p.memorySize, p.parallelism, p.hashLength)},
+		},
+		Cost: p.memorySize * p.iterations,
+	}
+}
+
+func init() {
+	register(newGenerator("argon2-variants", argon2VariantSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+// argon2VariantSuites returns the Argon2i and Argon2d vectors, and a suite
+// with the same inputs for every type so that an implementation that
+// computes the wrong type fails.
+func argon2VariantSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, typ := range []int{argon2i, argon2d} {
+		name := argon2TypeNames[typ]
+		grid := &Suite{Name: name + ": parallelism and hash length", Skip: argon2VariantsSkip}
+		for _, parallelism := range []int{1, 2, 4} {
+			for _, hashLength := range []int{16, 32, 64} {
+				p := argon2DefaultParams
+				p.parallelism = parallelism
+				p.hashLength = hashLength
+				grid.Vectors = append(grid.Vectors, argon2Vector(typ, p, []byte("password"), argon2Salt, nil, nil))
+			}
+		}
+
+		// Argon2i computes the block indices from the position instead of
+		// the memory, so more than 128 blocks per segment need a second
+		// address block.
+		memory := &Suite{Name: name + ": memory size and iterations", Skip: argon2VariantsSkip}
+		for _, tc := range []struct{ memorySize, iterations, parallelism int }{
+			{8, 1, 1}, {37, 2, 4}, {1024, 1, 1}, {1024, 3, 2},
+		} {
+			p := argon2DefaultParams
+			p.memorySize = tc.memorySize
+			p.iterations = tc.iterations
+			p.parallelism = tc.parallelism
+			memory.Vectors = append(memory.Vectors, argon2Vector(typ, p, []byte("password"), argon2Salt, nil, nil))
+		}
+
+		// RFC 9106 section 5.
+		rfc := &Suite{Name: name + ": secret and associated data", Skip: argon2VariantsSkip}
+		p := argon2Params{memorySize: 32, iterations: 3, parallelism: 4, hashLength: 32}
+		rfc.Vectors = append(rfc.Vectors,
+			argon2Vector(typ, p, bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 16), bytes.Repeat([]byte{3}, 8), bytes.Repeat([]byte{4}, 12)),
+			argon2Vector(typ, argon2DefaultParams, []byte("password"), argon2Salt, sequence(0x40, 32), nil),
+			argon2Vector(typ, argon2DefaultParams, []byte("password"), argon2Salt, nil, []byte("associated data")),
+		)
+		suites = append(suites, grid, memory, rfc)
+	}
+
+	types := &Suite{Name: "argon2: types", Skip: argon2VariantsSkip}
+	for _, typ := range []int{argon2d, argon2i, argon2id} {
+		for _, p := range []argon2Params{
+			argon2DefaultParams,
+			{memorySize: 32, iterations: 3, parallelism: 4, hashLength: 32},
+		} {
+			v := argon2Vector(typ, p, []byte("password"), argon2Salt, nil, nil)
+			v.Name = argon2TypeNames[typ] + ", " + v.Name
+			v.Fields = append([]Field{{"type", argon2TypeNames[typ]}}, v.Fields...)
+			types.Vectors = append(types.Vectors, v)
+		}
+	}
+	return append(suites, types), nil
+}
+
+// Argon2 types (the y parameter of RFC 9106).
+const (
+	argon2d  = 0
+	argon2i  = 1
+	argon2id = 2
+)
+
+// argon2TypeNames are the names of the Argon2 types.
+var argon2TypeNames = []string{argon2d: "argon2d", argon2i: "argon2i", argon2id: "argon2id"}
+
+// argon2Block is a 1 KiB block of Argon2 memory.
+type argon2Block [128]uint64
+
+// argon2Key implements Argon2 of the type. Like golang.org/x/crypto, the
+// memory size is rounded down to a multiple of 4*parallelism blocks and is
+// at least 8*parallelism blocks.
+func argon2Key(typ int, password, salt, secret, data []byte, iterations, memorySize, parallelism, hashLength int) []byte {
+	const syncPoints = 4
+	memory := memorySize / (syncPoints * parallelism) * (syncPoints * parallelism)
+	if memory < 2*syncPoints*parallelism {
+		memory = 2 * syncPoints * parallelism
+	}
+	laneLength := memory / parallelism
+	segmentLength := laneLength / syncPoints
+
+	h0, _ := blake2b.New512(nil)
+	for _, n := range []int{parallelism, hashLength, memorySize, iterations, 0x13, typ} {
+		h0.Write(binary.LittleEndian.AppendUint32(nil, uint32(n)))
+	}
+	for _, b := range [][]byte{password, salt, secret, data} {
+		h0.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(b))))
+		h0.Write(b)
+	}
+	seed := h0.Sum(nil)
+
+	B := make([]argon2Block, memory)
+	for lane := 0; lane < parallelism; lane++ {
+		for i := 0; i < 2; i++ {
+			in := concat(seed, binary.LittleEndian.AppendUint32(nil, uint32(i)), binary.LittleEndian.AppendUint32(nil, uint32(lane)))
+			b := argon2Hash(in, 1024)
+			for j := range B[lane*laneLength+i] {
+				B[lane*laneLength+i][j] = binary.LittleEndian.Uint64(b[8*j:])
+			}
+		}
+	}
+
+	var zero argon2Block
+	for pass := 0; pass < iterations; pass++ {
+		for slice := 0; slice < syncPoints; slice++ {
+			for lane := 0; lane < parallelism; lane++ {
+				independent := typ == argon2i || (typ == argon2id && pass == 0 && slice < syncPoints/2)
+				var input, addresses argon2Block
+				input[0] = uint64(pass)
+				input[1] = uint64(lane)
+				input[2] = uint64(slice)
+				input[3] = uint64(memory)
+				input[4] = uint64(iterations)
+				input[5] = uint64(typ)
+				nextAddresses := func() {
+					input[6]++
+					argon2Compress(&addresses, &zero, &input, false)
+					argon2Compress(&addresses, &zero, &addresses, false)
+				}
+				index := 0
+				if pass == 0 && slice == 0 {
+					// The first two blocks of each lane are computed above.
+					index = 2
+					if independent {
+						nextAddresses()
+					}
+				}
+				offset := lane*laneLength + slice*segmentLength + index
+				for ; index < segmentLength; index, offset = index+1, offset+1 {
+					prev := offset - 1
+					if index == 0 && slice == 0 {
+						prev += laneLength
+					}
+					var random uint64
+					if independent {
+						if index%len(addresses) == 0 {
+							nextAddresses()
+						}
+						random = addresses[index%len(addresses)]
+					} else {
+						random = B[prev][0]
+					}
+
+					// The reference block (RFC 9106 section 3.4.1.2).
+					refLane := int(random>>32) % parallelism
+					if pass == 0 && slice == 0 {
+						refLane = lane
+					}
+					var area, start int
+					switch {
+					case pass == 0 && refLane == lane:
+						area = slice*segmentLength + index - 1
+					case pass == 0:
+						area = slice * segmentLength
+						if index == 0 {
+							area--
+						}
+					case refLane == lane:
+						area = laneLength - segmentLength + index - 1
+						start = (slice + 1) % syncPoints * segmentLength
+					default:
+						area = laneLength - segmentLength
+						if index == 0 {
+							area--
+						}
+						start = (slice + 1) % syncPoints * segmentLength
+					}
+					x := random & 0xffffffff
+					x = x * x >> 32
+					x = uint64(area) * x >> 32
+					ref := refLane*laneLength + (start+area-1-int(x))%laneLength
+
+					argon2Compress(&B[offset], &B[prev], &B[ref], pass > 0)
+				}
+			}
+		}
+	}
+
+	var c argon2Block
+	for lane := 0; lane < parallelism; lane++ {
+		for i, w := range B[lane*laneLength+laneLength-1] {
+			c[i] ^= w
+		}
+	}
+	var final []byte
+	for _, w := range c {
+		final = binary.LittleEndian.AppendUint64(final, w)
+	}
+	return argon2Hash(final, hashLength)
+}
+
+// argon2Hash is the variable-length hash function H' of RFC 9106.
+func argon2Hash(in []byte, n int) []byte {
+	in = concat(binary.LittleEndian.AppendUint32(nil, uint32(n)), in)
+	if n <= blake2b.Size {
+		h, _ := blake2b.New(n, nil)
+		h.Write(in)
+		return h.Sum(nil)
+	}
+	v := blake2b.Sum512(in)
+	out := append([]byte(nil), v[:32]...)
+	for n-len(out) > blake2b.Size {
+		v = blake2b.Sum512(v[:])
+		out = append(out, v[:32]...)
+	}
+	h, _ := blake2b.New(n-len(out), nil)
+	h.Write(v[:])
+	return h.Sum(out)
+}
+
+// argon2Compress sets out to the compression function G(x, y), or XORs it
+// into out.
+func argon2Compress(out, x, y *argon2Block, xor bool) {
+	var r argon2Block
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+	q := r
+	var v [16]uint64
+	// Rows of 16 words, then columns of 2 words in every row.
+	for row := 0; row < 8; row++ {
+		copy(v[:], q[16*row:])
+		argon2Permute(&v)
+		copy(q[16*row:], v[:])
+	}
+	for col := 0; col < 8; col++ {
+		for i := 0; i < 8; i++ {
+			v[2*i] = q[16*i+2*col]
+			v[2*i+1] = q[16*i+2*col+1]
+		}
+		argon2Permute(&v)
+		for i := 0; i < 8; i++ {
+			q[16*i+2*col] = v[2*i]
+			q[16*i+2*col+1] = v[2*i+1]
+		}
+	}
+	for i := range out {
+		if xor {
+			out[i] ^= r[i] ^ q[i]
+		} else {
+			out[i] = r[i] ^ q[i]
+		}
+	}
+}
+
+// argon2Permute is the permutation P, a BLAKE2b round with multiplications.
+func argon2Permute(v *[16]uint64) {
+	gb := func(a, b, c, d int) {
+		fBlaMka := func(x, y uint64) uint64 {
+			return x + y + 2*uint64(uint32(x))*uint64(uint32(y))
+		}
+		v[a] = fBlaMka(v[a], v[b])
+		v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+		v[c] = fBlaMka(v[c], v[d])
+		v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+		v[a] = fBlaMka(v[a], v[b])
+		v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+		v[c] = fBlaMka(v[c], v[d])
+		v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+	}
+	gb(0, 4, 8, 12)
+	gb(1, 5, 9, 13)
+	gb(2, 6, 10, 14)
+	gb(3, 7, 11, 15)
+	gb(0, 5, 10, 15)
+	gb(1, 6, 11, 12)
+	gb(2, 7, 8, 13)
+	gb(3, 4, 9, 14)
+}
@@ -0,0 +1,45 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestArgon2id(t *testing.T) {
+	// The test of the reference implementation with t=2, m=2^16 and p=1.
+	v := argon2idVector(argon2Params{memorySize: 65536, iterations: 2, parallelism: 1, hashLength: 32}, []byte("password"), []byte("somesalt"))
+	checkHex(t, v, "expected", "09316115d5cf24ed5a15a31a3ba326e5cf32edc24702987c02b6566f61913cf7")
+}
+
+func TestArgon2Key(t *testing.T) {
+	// The implementation agrees with golang.org/x/crypto.
+	for _, p := range []argon2Params{
+		{memorySize: 64, iterations: 2, parallelism: 1, hashLength: 32},
+		{memorySize: 37, iterations: 3, parallelism: 4, hashLength: 100},
+		{memorySize: 1024, iterations: 1, parallelism: 2, hashLength: 16},
+	} {
+		password, salt := []byte("password"), sequence(0x80, 16)
+		if got, want := argon2Key(argon2i, password, salt, nil, nil, p.iterations, p.memorySize, p.parallelism, p.hashLength), argon2.Key(password, salt, uint32(p.iterations), uint32(p.memorySize), uint8(p.parallelism), uint32(p.hashLength)); !bytes.Equal(got, want) {
+			t.Errorf("argon2i %+v: %x, want %x", p, got, want)
+		}
+		if got, want := argon2Key(argon2id, password, salt, nil, nil, p.iterations, p.memorySize, p.parallelism, p.hashLength), argon2.IDKey(password, salt, uint32(p.iterations), uint32(p.memorySize), uint8(p.parallelism), uint32(p.hashLength)); !bytes.Equal(got, want) {
+			t.Errorf("argon2id %+v: %x, want %x", p, got, want)
+		}
+	}
+
+	// RFC 9106 section 5.
+	suites, err := argon2VariantSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const name = "m=32 KiB, t=3, p=4, 16-byte salt, 32-byte output, 8-byte secret, 12-byte associated data"
+	checkHex(t, findVector(t, suites, "argon2d: secret and associated data", name), "expected", "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb")
+	checkHex(t, findVector(t, suites, "argon2i: secret and associated data", name), "expected", "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8")
+	id := argon2Key(argon2id, bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 16), bytes.Repeat([]byte{3}, 8), bytes.Repeat([]byte{4}, 12), 3, 32, 4, 32)
+	if got, want := hex.EncodeToString(id), "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"; got != want {
+		t.Errorf("argon2id = %s, want %s", got, want)
+	}
+}
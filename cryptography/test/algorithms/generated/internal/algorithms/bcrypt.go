@@ -0,0 +1,98 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+// bcrypt with the "$2a$" prefix of golang.org/x/crypto/bcrypt. The bcrypt
+// package generates random salts and rejects passwords longer than 72 bytes,
+// so the hash is computed here with its Blowfish setup. The key is the
+// password with a trailing zero byte and Blowfish only uses its first 72
+// bytes, so longer passwords have the same hash as their 72-byte prefix.
+
+const bcryptSkip = "bcrypt is not implemented in package:cryptography"
+
+// bcryptEncoding is the base64 alphabet of bcrypt.
+var bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// bcryptHash returns the modular crypt format string of the password.
+func bcryptHash(password, salt []byte, cost int) (string, error) {
+	key := concat(password, []byte{0})
+	c, err := blowfish.NewSaltedCipher(key, salt)
+	if err != nil {
+		return "", err
+	}
+	for i := 0; i < 1<<cost; i++ {
+		blowfish.ExpandKey(key, c)
+		blowfish.ExpandKey(salt, c)
+	}
+	data := []byte("OrpheanBeholderScryDoubt")
+	for i := 0; i < len(data); i += 8 {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(data[i:i+8], data[i:i+8])
+		}
+	}
+	// Like the C implementations, only 23 of the 24 bytes are encoded.
+	return fmt.Sprintf("$2a$%02d$%s%s", cost, bcryptEncoding.EncodeToString(salt), bcryptEncoding.EncodeToString(data[:23])), nil
+}
+
+// bcryptCost returns the cost of bcrypt. Expanding the Blowfish key
+// schedule encrypts 521 blocks, about 50 units.
+func bcryptCost(cost int) int {
+	return 2 * 50 * (1 << cost)
+}
+
+func init() {
+	register(newGenerator("bcrypt", bcryptSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func bcryptSuites() ([]*Suite, error) {
+	salts := [][]byte{sequence(0x10, 16), make([]byte, 16), bytes.Repeat([]byte{0xff}, 16)}
+	long := []byte("The quick brown fox jumps over the lazy dog. The quick brown fox jumps over the lazy dog.")
+	type input struct {
+		name     string
+		password []byte
+	}
+	inputs := []input{
+		{"empty password", nil},
+		{`password "password"`, []byte("password")},
+		{`password "U*U"`, []byte("U*U")},
+		{fmt.Sprintf("password %+q", "pässwörd"), []byte("pässwörd")},
+	}
+	// The 73-byte and longer passwords have the hash of the 72-byte one.
+	for _, n := range []int{71, 72, 73, len(long)} {
+		inputs = append(inputs, input{fmt.Sprintf("%d-byte password", n), long[:n]})
+	}
+
+	s := &Suite{Name: "bcrypt", Skip: bcryptSkip}
+	for _, cost := range []int{4, 10, 12} {
+		for i, salt := range salts {
+			for j, in := range inputs {
+				// Costs 10 and 12 only use the first salt and two passwords.
+				if cost > 4 && (i > 0 || (j != 1 && j != len(inputs)-1)) {
+					continue
+				}
+				hash, err := bcryptHash(in.password, salt, cost)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", in.name, err)
+				}
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("cost %d, salt %d, %s", cost, i+1, in.name),
+					Fields: []Field{
+						{"password", in.password},
+						{"salt", salt},
+						{"cost", cost},
+						{"expected", hash},
+					},
+					Cost: bcryptCost(cost),
+				})
+			}
+		}
+	}
+	return []*Suite{s}, nil
+}
@@ -0,0 +1,36 @@
+package algorithms
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcrypt(t *testing.T) {
+	// From the OpenBSD tests.
+	salt, err := bcryptEncoding.DecodeString("CCCCCCCCCCCCCCCCCCCCC.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := bcryptHash([]byte("U*U"), salt, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW"; hash != want {
+		t.Errorf("bcryptHash = %s, want %s", hash, want)
+	}
+
+	suites, err := bcryptSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range suites[0].Vectors {
+		password := v.Fields[0].Value.([]byte)
+		if len(password) > 72 {
+			password = password[:72]
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(v.Fields[3].Value.(string)), password); err != nil {
+			t.Errorf("%s: %v", v.Name, err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package algorithms
+
+import (
+	"fmt"
+	"hash"
+	"io"
+
+	dchestblake2b "github.com/dchest/blake2b"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+)
+
+// BLAKE2 (RFC 7693). Dart Blake2b and Blake2s compute the unkeyed hash with
+// the maximum digest length. The other parameters of the parameter block
+// are not implemented in package:cryptography yet.
+
+const blake2ParametersSkip = "BLAKE2 parameters are not implemented in package:cryptography"
+
+// blake2HmacSkip is the reason for skipping HMAC-BLAKE2. RFC 2104 uses the
+// block size of the hash, which is 128 bytes for BLAKE2b and 64 bytes for
+// BLAKE2s.
+const blake2HmacSkip = "Blake2b and Blake2s in package:cryptography have blockLengthInBytes 64 and 32 instead of 128 and 64"
+
+const blake2bBody = `
+final hash = await Blake2b().hash(data);
+expect(
+  hexFromBytes(hash.bytes),
+  hexFromBytes(expected),
+);
+`
+
+// blake2bSum hashes the data with the BLAKE2b parameters.
+func blake2bSum(config *dchestblake2b.Config, data []byte) ([]byte, error) {
+	h, err := dchestblake2b.New(config)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+func init() {
+	register(newGenerator("blake2b", blake2bSuites, spec.InputLengths, spec.Seed).
+		uses("bytes.go", "dart.go", "hash.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func blake2bSuites() ([]*Suite, error) {
+	unkeyed := &Suite{
+		Name:   "blake2b",
+		Body:   blake2bBody,
+		Covers: []string{"Blake2b"},
+	}
+	for _, n := range inputLengths(0, 1, 127, 128, 129, 255, 256, 1000) {
+		data := randomBytes(unkeyed.Name+": data", n)
+		expected, err := blake2bSum(&dchestblake2b.Config{}, data)
+		if err != nil {
+			return nil, err
+		}
+		unkeyed.Vectors = append(unkeyed.Vectors, Vector{
+			Name: describeBytes(data),
+			Fields: []Field{
+				{"data", data},
+				{"expected", expected},
+			},
+		})
+	}
+
+	parameters := &Suite{
+		Name: "blake2b: key, salt and personalization",
+		Skip: blake2ParametersSkip,
+	}
+	for _, keyLength := range []int{0, 32, 64} {
+		for _, saltLength := range []int{0, 8, 16} {
+			for _, personalLength := range []int{0, 16} {
+				key := sequence(0x40, keyLength)
+				salt := sequence(0x80, saltLength)
+				personal := sequence(0xc0, personalLength)
+				data := sequence(0, 3)
+				expected, err := blake2bSum(&dchestblake2b.Config{Key: key, Salt: salt, Person: personal}, data)
+				if err != nil {
+					return nil, err
+				}
+				parameters.Vectors = append(parameters.Vectors, Vector{
+					Name: fmt.Sprintf("%d-byte key, %d-byte salt, %d-byte personalization", keyLength, saltLength, personalLength),
+					Fields: []Field{
+						{"secretKey", key},
+						{"salt", salt},
+						{"personalization", personal},
+						{"data", data},
+						{"expected", expected},
+					},
+				})
+			}
+		}
+	}
+
+	// The digest length is part of the parameter block, so every length
+	// gives an unrelated hash.
+	lengths := &Suite{
+		Name: "blake2b: digest lengths",
+		Skip: blake2ParametersSkip,
+	}
+	for size := 1; size <= 64; size++ {
+		data := sequence(0, 3)
+		expected, err := blake2bSum(&dchestblake2b.Config{Size: uint8(size)}, data)
+		if err != nil {
+			return nil, err
+		}
+		lengths.Vectors = append(lengths.Vectors, Vector{
+			Name: describeCount(size, "byte"),
+			Fields: []Field{
+				{"hashLength", size},
+				{"data", data},
+				{"expected", expected},
+			},
+		})
+	}
+	hmacHash := hashAlgorithm{
+		name: "blake2b",
+		dart: "Blake2b",
+		new: func() hash.Hash {
+			h, _ := blake2b.New512(nil)
+			return h
+		},
+	}
+	return []*Suite{
+		unkeyed, parameters, lengths,
+		blake2HmacSuite(hmacHash),
+		hmacEmptyKeySuite(hmacHash),
+	}, nil
+}
+
+// blake2HmacSuite returns HMAC vectors of an unkeyed BLAKE2 hash.
+func blake2HmacSuite(a hashAlgorithm) *Suite {
+	s := &Suite{
+		Name:   "hmac-" + a.name,
+		Body:   fmt.Sprintf(hmacBody, a.dart),
+		Skip:   blake2HmacSkip,
+		Covers: []string{"Hmac hashAlgorithm=" + a.dart},
+	}
+	for _, keyLength := range hmacKeyLengths(a.new().BlockSize()) {
+		s.Vectors = append(s.Vectors, hmacVectors(a, keyLength)...)
+	}
+	return s
+}
+
+func init() {
+	register(newGenerator("blake2x", blake2xSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+// blake2xSuites returns BLAKE2Xb and BLAKE2Xs vectors. The output length is a
+// parameter of the XOF, so it changes every byte of the output.
+func blake2xSuites() ([]*Suite, error) {
+	const skip = "BLAKE2X is not implemented in package:cryptography"
+	xb := &Suite{Name: "blake2xb", Skip: skip}
+	xs := &Suite{Name: "blake2xs", Skip: skip}
+	for _, keyLength := range []int{0, 32} {
+		for _, outputLength := range []int{1, 32, 33, 64, 65, 257, 4096} {
+			key := sequence(0x40, keyLength)
+			data := sequence(0, 3)
+			name := fmt.Sprintf("%d-byte key, %d-byte output", keyLength, outputLength)
+
+			b, err := blake2b.NewXOF(uint32(outputLength), key)
+			if err != nil {
+				return nil, err
+			}
+			b.Write(data)
+			expected := make([]byte, outputLength)
+			if _, err := io.ReadFull(b, expected); err != nil {
+				return nil, err
+			}
+			xb.Vectors = append(xb.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"secretKey", key},
+					{"data", data},
+					{"outputLength", outputLength},
+					{"expected", expected},
+				},
+			})
+
+			s, err := blake2s.NewXOF(uint16(outputLength), key)
+			if err != nil {
+				return nil, err
+			}
+			s.Write(data)
+			expected = make([]byte, outputLength)
+			if _, err := io.ReadFull(s, expected); err != nil {
+				return nil, err
+			}
+			xs.Vectors = append(xs.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"secretKey", key},
+					{"data", data},
+					{"outputLength", outputLength},
+					{"expected", expected},
+				},
+			})
+		}
+	}
+	return []*Suite{xb, xs}, nil
+}
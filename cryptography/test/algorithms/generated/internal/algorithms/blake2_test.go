@@ -0,0 +1,55 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	dchestblake2b "github.com/dchest/blake2b"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestBlake2b(t *testing.T) {
+	// RFC 7693 appendix A: BLAKE2b-512("abc").
+	got, err := blake2bSum(&dchestblake2b.Config{}, []byte("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"
+	if hex.EncodeToString(got) != want {
+		t.Errorf("got %x, want %s", got, want)
+	}
+	// golang.org/x/crypto/blake2b for the digest lengths it supports.
+	for _, size := range []int{32, 48} {
+		h, err := blake2b.New(size, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte("abc"))
+		got, err := blake2bSum(&dchestblake2b.Config{Size: uint8(size)}, []byte("abc"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("size %d: got %x, want %x", size, got, want)
+		}
+	}
+}
+
+func TestBlake2Hmac(t *testing.T) {
+	suites, err := blake2bSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// HMAC-BLAKE2b-512 with a 128-byte key is not hashed first, so it is
+	// the same as the hash of (key XOR opad) || hash((key XOR ipad) || data).
+	key := sequence(0, 128)
+	inner, outer := make([]byte, 128), make([]byte, 128)
+	for i, b := range key {
+		inner[i], outer[i] = b^0x36, b^0x5c
+	}
+	innerHash := blake2b.Sum512(inner)
+	want := blake2b.Sum512(append(outer, innerHash[:]...))
+	v := findVector(t, suites, "hmac-blake2b", "128-byte key, 0 bytes")
+	checkHex(t, v, "expected", hex.EncodeToString(want[:]))
+}
@@ -0,0 +1,193 @@
+package algorithms
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"math/bits"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/blake2s"
+)
+
+// BLAKE2s (RFC 7693) with the full parameter block. golang.org/x/crypto only
+// supports keys and 16 or 32-byte digests, so this is a small implementation
+// that is checked against it in the tests.
+
+var blake2sIV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var blake2sSigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+func blake2sCompress(h *[8]uint32, block []byte, t uint64, final bool) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+	var v [16]uint32
+	copy(v[:8], h[:])
+	copy(v[8:], blake2sIV[:])
+	v[12] ^= uint32(t)
+	v[13] ^= uint32(t >> 32)
+	if final {
+		v[14] = ^v[14]
+	}
+	g := func(a, b, c, d int, x, y uint32) {
+		v[a] = v[a] + v[b] + x
+		v[d] = bits.RotateLeft32(v[d]^v[a], -16)
+		v[c] = v[c] + v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -12)
+		v[a] = v[a] + v[b] + y
+		v[d] = bits.RotateLeft32(v[d]^v[a], -8)
+		v[c] = v[c] + v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -7)
+	}
+	for _, s := range blake2sSigma {
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+// blake2sSum returns the BLAKE2s hash with the given digest length (1 to 32
+// bytes), key (up to 32 bytes), salt and personalization (up to 8 bytes,
+// padded with zeros).
+func blake2sSum(size int, key, salt, personal, data []byte) ([]byte, error) {
+	if size < 1 || size > 32 || len(key) > 32 || len(salt) > 8 || len(personal) > 8 {
+		return nil, fmt.Errorf("invalid BLAKE2s parameters: size %d, %d-byte key, %d-byte salt, %d-byte personalization", size, len(key), len(salt), len(personal))
+	}
+	var parameterBlock [32]byte
+	parameterBlock[0] = byte(size)
+	parameterBlock[1] = byte(len(key))
+	parameterBlock[2] = 1 // fanout
+	parameterBlock[3] = 1 // depth
+	copy(parameterBlock[16:], salt)
+	copy(parameterBlock[24:], personal)
+	h := blake2sIV
+	for i := range h {
+		h[i] ^= binary.LittleEndian.Uint32(parameterBlock[4*i:])
+	}
+
+	input := data
+	if len(key) > 0 {
+		input = concat(key, make([]byte, 64-len(key)), data)
+	}
+	var t uint64
+	for len(input) > 64 {
+		t += 64
+		blake2sCompress(&h, input[:64], t, false)
+		input = input[64:]
+	}
+	var last [64]byte
+	copy(last[:], input)
+	t += uint64(len(input))
+	blake2sCompress(&h, last[:], t, true)
+
+	out := make([]byte, 32)
+	for i, x := range h {
+		binary.LittleEndian.PutUint32(out[4*i:], x)
+	}
+	return out[:size], nil
+}
+
+const blake2sBody = `
+final hash = await Blake2s().hash(data);
+expect(
+  hexFromBytes(hash.bytes),
+  hexFromBytes(expected),
+);
+`
+
+func init() {
+	register(newGenerator("blake2s", blake2sSuites, spec.InputLengths, spec.Seed).
+		uses("blake2.go", "bytes.go", "dart.go", "hash.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func blake2sSuites() ([]*Suite, error) {
+	unkeyed := &Suite{
+		Name:   "blake2s",
+		Body:   blake2sBody,
+		Covers: []string{"Blake2s"},
+	}
+	for _, n := range inputLengths(0, 1, 63, 64, 65, 127, 128, 1000) {
+		data := randomBytes(unkeyed.Name+": data", n)
+		expected, err := blake2sSum(32, nil, nil, nil, data)
+		if err != nil {
+			return nil, err
+		}
+		unkeyed.Vectors = append(unkeyed.Vectors, Vector{
+			Name: describeBytes(data),
+			Fields: []Field{
+				{"data", data},
+				{"expected", expected},
+			},
+		})
+	}
+
+	parameters := &Suite{
+		Name: "blake2s: digest length, key, salt and personalization",
+		Skip: blake2ParametersSkip,
+	}
+	for _, size := range []int{16, 20, 28, 32} {
+		for _, keyLength := range []int{0, 32} {
+			for _, saltAndPersonal := range []bool{false, true} {
+				key := sequence(0x40, keyLength)
+				var salt, personal []byte
+				if saltAndPersonal {
+					salt = sequence(0x80, 8)
+					personal = sequence(0xc0, 8)
+				}
+				data := sequence(0, 3)
+				expected, err := blake2sSum(size, key, salt, personal, data)
+				if err != nil {
+					return nil, err
+				}
+				parameters.Vectors = append(parameters.Vectors, Vector{
+					Name: fmt.Sprintf("BLAKE2s-%d, %d-byte key, %d-byte salt, %d-byte personalization", 8*size, keyLength, len(salt), len(personal)),
+					Fields: []Field{
+						{"hashLength", size},
+						{"secretKey", key},
+						{"salt", salt},
+						{"personalization", personal},
+						{"data", data},
+						{"expected", expected},
+					},
+				})
+			}
+		}
+	}
+	hmacHash := hashAlgorithm{
+		name: "blake2s",
+		dart: "Blake2s",
+		new: func() hash.Hash {
+			h, _ := blake2s.New256(nil)
+			return h
+		},
+	}
+	return []*Suite{
+		unkeyed, parameters,
+		blake2HmacSuite(hmacHash),
+		hmacEmptyKeySuite(hmacHash),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/hex"
+	"hash"
+	"testing"
+
+	"golang.org/x/crypto/blake2s"
+)
+
+func TestBlake2sSum(t *testing.T) {
+	// RFC 7693 appendix B: BLAKE2s-256("abc").
+	got, err := blake2sSum(32, nil, nil, nil, []byte("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"; hex.EncodeToString(got) != want {
+		t.Errorf("got %x, want %s", got, want)
+	}
+	// Keyed hashes of golang.org/x/crypto/blake2s.
+	key := sequence(0, 32)
+	for _, n := range []int{0, 1, 64, 65, 200} {
+		data := make([]byte, n)
+		for _, size := range []int{16, 32} {
+			var h hash.Hash
+			if size == 16 {
+				h, err = blake2s.New128(key)
+			} else {
+				h, err = blake2s.New256(key)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Write(data)
+			got, err := blake2sSum(size, key, nil, nil, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("%d bytes, size %d: got %x, want %x", n, size, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package algorithms
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// NaCl crypto_box (Curve25519-XSalsa20-Poly1305). The precomputed shared key
+// is crypto_box_beforenm: HSalsa20 of the X25519 shared secret.
+//
+// libsodium crypto_box_seal encrypts to a recipient with a fresh ephemeral
+// key pair. The nonce is BLAKE2b-192 of the ephemeral public key and the
+// recipient public key, and the sealed box is the ephemeral public key
+// followed by the crypto_box output.
+
+const boxXsalsa20Poly1305Skip = "XSalsa20-Poly1305 is not implemented in package:cryptography"
+
+func init() {
+	register(newGenerator("box", boxSuites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func boxSuites() ([]*Suite, error) {
+	s := &Suite{
+		Name: "nacl-box: Curve25519-XSalsa20-Poly1305",
+		Skip: boxXsalsa20Poly1305Skip,
+	}
+	var senderPrivateKey, recipientPrivateKey [32]byte
+	copy(senderPrivateKey[:], sequence(0x00, 32))
+	copy(recipientPrivateKey[:], sequence(0x40, 32))
+	senderPublicKey, err := curve25519.X25519(senderPrivateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	recipientPublicKey, err := curve25519.X25519(recipientPrivateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	var peerPublicKey, sharedKey [32]byte
+	copy(peerPublicKey[:], recipientPublicKey)
+	box.Precompute(&sharedKey, &peerPublicKey, &senderPrivateKey)
+	var nonce [24]byte
+	copy(nonce[:], sequence(0x80, 24))
+	for _, n := range []int{0, 1, 16, 32, 64, 65, 100} {
+		clearText := randomBytes(s.Name+": clearText", n)
+		combined := box.SealAfterPrecomputation(nil, clearText, &nonce, &sharedKey)
+		s.Vectors = append(s.Vectors, Vector{
+			Name: describeBytes(clearText),
+			Fields: []Field{
+				{"senderPrivateKey", senderPrivateKey[:]},
+				{"senderPublicKey", senderPublicKey},
+				{"recipientPrivateKey", recipientPrivateKey[:]},
+				{"recipientPublicKey", recipientPublicKey},
+				{"sharedKey", sharedKey[:]},
+				{"nonce", nonce[:]},
+				{"clearText", clearText},
+				{"cipherText", combined[box.Overhead:]},
+				{"mac", combined[:box.Overhead]},
+				{"combined", combined},
+			},
+		})
+	}
+	sealed, err := boxSealSuite()
+	if err != nil {
+		return nil, err
+	}
+	return []*Suite{s, sealed}, nil
+}
+
+// boxSeal returns the crypto_box_seal sealed box of the clear text and its
+// nonce.
+func boxSeal(clearText []byte, recipientPublicKey, ephemeralPrivateKey *[32]byte) (sealed, nonce []byte, err error) {
+	ephemeralPublicKey, err := curve25519.X25519(ephemeralPrivateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	h, err := blake2b.New(24, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	h.Write(ephemeralPublicKey)
+	h.Write(recipientPublicKey[:])
+	nonce = h.Sum(nil)
+	var n [24]byte
+	copy(n[:], nonce)
+	return box.Seal(ephemeralPublicKey, clearText, &n, recipientPublicKey, ephemeralPrivateKey), nonce, nil
+}
+
+func boxSealSuite() (*Suite, error) {
+	s := &Suite{
+		Name: "libsodium: crypto_box_seal",
+		Skip: boxXsalsa20Poly1305Skip,
+	}
+	var recipientPrivateKey, recipientPublicKey [32]byte
+	copy(recipientPrivateKey[:], sequence(0x40, 32))
+	publicKey, err := curve25519.X25519(recipientPrivateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	copy(recipientPublicKey[:], publicKey)
+	for i, start := range []byte{0x80, 0xc0} {
+		var ephemeralPrivateKey [32]byte
+		copy(ephemeralPrivateKey[:], sequence(start, 32))
+		for _, clearText := range [][]byte{nil, []byte("Hello, World!"), sequence(0, 64), sequence(0, 100)} {
+			sealed, nonce, err := boxSeal(clearText, &recipientPublicKey, &ephemeralPrivateKey)
+			if err != nil {
+				return nil, err
+			}
+			opened, ok := box.OpenAnonymous(nil, sealed, &recipientPublicKey, &recipientPrivateKey)
+			if !ok || !bytes.Equal(opened, clearText) {
+				return nil, fmt.Errorf("%s: box.OpenAnonymous rejected the sealed box", s.Name)
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("ephemeral key %d, %s", i+1, describeBytes(clearText)),
+				Fields: []Field{
+					{"recipientPrivateKey", recipientPrivateKey[:]},
+					{"recipientPublicKey", recipientPublicKey[:]},
+					{"ephemeralPrivateKey", ephemeralPrivateKey[:]},
+					{"ephemeralPublicKey", sealed[:32]},
+					{"nonce", nonce},
+					{"clearText", clearText},
+					{"sealed", sealed},
+				},
+			})
+		}
+	}
+	return s, nil
+}
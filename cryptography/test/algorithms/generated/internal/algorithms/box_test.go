@@ -0,0 +1,45 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestBox(t *testing.T) {
+	suites, err := boxSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sealing with the recipient's key pair must give the same shared key.
+	v := suites[0].Vectors[0]
+	var recipientPrivateKey, senderPublicKey, sharedKey [32]byte
+	for _, f := range v.Fields {
+		switch f.Name {
+		case "recipientPrivateKey":
+			copy(recipientPrivateKey[:], f.Value.([]byte))
+		case "senderPublicKey":
+			copy(senderPublicKey[:], f.Value.([]byte))
+		}
+	}
+	box.Precompute(&sharedKey, &senderPublicKey, &recipientPrivateKey)
+	checkHex(t, v, "sharedKey", hex.EncodeToString(sharedKey[:]))
+}
+
+func TestBoxSeal(t *testing.T) {
+	s, err := boxSealSuite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// box.SealAnonymous reads the ephemeral private key from rand.
+	v := findVector(t, []*Suite{s}, s.Name, "ephemeral key 1, 13 bytes")
+	var recipientPublicKey [32]byte
+	copy(recipientPublicKey[:], v.Fields[1].Value.([]byte))
+	sealed, err := box.SealAnonymous(nil, []byte("Hello, World!"), &recipientPublicKey, bytes.NewReader(sequence(0x80, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "sealed", hex.EncodeToString(sealed))
+}
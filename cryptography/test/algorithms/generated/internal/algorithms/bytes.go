@@ -0,0 +1,31 @@
+package algorithms
+
+import "encoding/hex"
+
+// concat returns a new slice that contains the slices.
+func concat(slices ...[]byte) []byte {
+	var out []byte
+	for _, s := range slices {
+		out = append(out, s...)
+	}
+	return out
+}
+
+// sequence returns n bytes counting up from start. Keys and nonces use it so
+// they are easy to recognize in the output.
+func sequence(start byte, n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = start + byte(i)
+	}
+	return out
+}
+
+// mustHex decodes a hex string that is known to be valid.
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
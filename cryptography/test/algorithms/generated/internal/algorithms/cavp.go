@@ -0,0 +1,353 @@
+package algorithms
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// HMACVS truncates the MAC to Tlen bytes.
+const cavpHmacBody = `
+final mac = await Hmac(%s()).calculateMac(
+  data,
+  secretKey: SecretKey(secretKey),
+);
+expect(
+  hexFromBytes(mac.bytes.sublist(0, expected.length)),
+  hexFromBytes(expected),
+);
+`
+
+// rspSection is a list of records of a response file that follow the same
+// bracketed parameters, such as "[L=20]".
+type rspSection struct {
+	params  map[string]string
+	records []map[string]string
+}
+
+// parseRsp parses a CAVP response file. Records are "name = value" lines
+// separated by empty lines. A line without "=", such as "FAIL", is a name
+// with an empty value.
+func parseRsp(data []byte) ([]rspSection, error) {
+	var sections []rspSection
+	var record map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<24)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			record = nil
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated parameter %q", lineNumber, line)
+			}
+			if len(sections) == 0 || len(sections[len(sections)-1].records) > 0 {
+				sections = append(sections, rspSection{params: map[string]string{}})
+			}
+			name, value, _ := strings.Cut(line[1:len(line)-1], "=")
+			sections[len(sections)-1].params[strings.TrimSpace(name)] = strings.TrimSpace(value)
+			record = nil
+		default:
+			if len(sections) == 0 {
+				sections = append(sections, rspSection{params: map[string]string{}})
+			}
+			if record == nil {
+				record = map[string]string{}
+				s := &sections[len(sections)-1]
+				s.records = append(s.records, record)
+			}
+			name, value, _ := strings.Cut(line, "=")
+			record[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return sections, scanner.Err()
+}
+
+// rspInt returns a decimal value of a record or the parameters.
+func rspInt(values map[string]string, name string) (int, error) {
+	value, ok := values[name]
+	if !ok {
+		return 0, fmt.Errorf("no %q", name)
+	}
+	return strconv.Atoi(value)
+}
+
+// rspHex returns a hex value of a record.
+func rspHex(record map[string]string, name string) ([]byte, error) {
+	value, ok := record[name]
+	if !ok {
+		return nil, fmt.Errorf("no %q", name)
+	}
+	return hex.DecodeString(value)
+}
+
+// cavpArchive is a zip file of response files in corporaPath.
+type cavpArchive struct {
+	corpus string
+
+	// convert returns the suites of a response file, or nil for files that
+	// are not converted, such as Monte Carlo tests.
+	convert func(name string, sections []rspSection) ([]*Suite, error)
+}
+
+var cavpArchives = []cavpArchive{
+	{"cavp/shabytetestvectors.zip", cavpShaSuites},
+	{"cavp/sha-3bytetestvectors.zip", cavpShaSuites},
+	{"cavp/hmactestvectors.zip", cavpHmacSuites},
+	{"cavp/gcmtestvectors.zip", cavpGcmSuites},
+}
+
+// cavpShaHashes maps the prefixes of SHAVS file names to hashAlgorithms.
+var cavpShaHashes = map[string]string{
+	"SHA1":       "sha-1",
+	"SHA224":     "sha-224",
+	"SHA256":     "sha-256",
+	"SHA384":     "sha-384",
+	"SHA512":     "sha-512",
+	"SHA512_224": "sha-512/224",
+	"SHA512_256": "sha-512/256",
+	"SHA3_224":   "sha3-224",
+	"SHA3_256":   "sha3-256",
+	"SHA3_384":   "sha3-384",
+	"SHA3_512":   "sha3-512",
+}
+
+// findHashAlgorithm returns the entry of hashAlgorithms with the name.
+func findHashAlgorithm(name string) (hashAlgorithm, error) {
+	for _, h := range hashAlgorithms {
+		if h.name == name {
+			return h, nil
+		}
+	}
+	return hashAlgorithm{}, fmt.Errorf("no hash %q", name)
+}
+
+// cavpShaSuites converts SHAVS and SHA3VS short and long message files, such
+// as "SHA256ShortMsg.rsp" and "SHA3_256LongMsg.rsp". The messages of
+// byte-oriented implementations are whole bytes. An empty message is written
+// as "Msg = 00" with "Len = 0".
+func cavpShaSuites(name string, sections []rspSection) ([]*Suite, error) {
+	var prefix, kind string
+	switch {
+	case strings.HasSuffix(name, "ShortMsg.rsp"):
+		prefix, kind = strings.TrimSuffix(name, "ShortMsg.rsp"), "short messages"
+	case strings.HasSuffix(name, "LongMsg.rsp"):
+		prefix, kind = strings.TrimSuffix(name, "LongMsg.rsp"), "long messages"
+	default:
+		return nil, nil
+	}
+	h, err := findHashAlgorithm(cavpShaHashes[prefix])
+	if err != nil {
+		return nil, err
+	}
+	s := &Suite{
+		Name: "cavp: " + h.name + ", " + kind,
+		Skip: h.skip,
+	}
+	if h.dart != "" {
+		s.Body = fmt.Sprintf(hashBody, h.dart)
+	}
+	for _, section := range sections {
+		for _, r := range section.records {
+			bits, err := rspInt(r, "Len")
+			if err != nil {
+				return nil, err
+			}
+			if bits%8 != 0 {
+				return nil, fmt.Errorf("Len = %d is not whole bytes", bits)
+			}
+			msg, err := rspHex(r, "Msg")
+			if err != nil {
+				return nil, err
+			}
+			md, err := rspHex(r, "MD")
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("Len = %d", bits),
+				Fields: []Field{
+					{"data", msg[:bits/8]},
+					{"expected", md},
+				},
+			})
+		}
+	}
+	return []*Suite{s}, nil
+}
+
+// cavpHmacHashes maps the [L=...] digest lengths of HMAC.rsp to
+// hashAlgorithms.
+var cavpHmacHashes = map[int]string{
+	20: "sha-1",
+	28: "sha-224",
+	32: "sha-256",
+	48: "sha-384",
+	64: "sha-512",
+}
+
+// cavpHmacSuites converts HMAC.rsp.
+func cavpHmacSuites(name string, sections []rspSection) ([]*Suite, error) {
+	if name != "HMAC.rsp" {
+		return nil, nil
+	}
+	var suites []*Suite
+	for _, section := range sections {
+		l, err := rspInt(section.params, "L")
+		if err != nil {
+			return nil, err
+		}
+		h, err := findHashAlgorithm(cavpHmacHashes[l])
+		if err != nil {
+			return nil, fmt.Errorf("L = %d: %w", l, err)
+		}
+		s := &Suite{Name: "cavp: hmac-" + h.name, Body: fmt.Sprintf(cavpHmacBody, h.dart)}
+		for _, r := range section.records {
+			count, err := rspInt(r, "Count")
+			if err != nil {
+				return nil, err
+			}
+			key, err := rspHex(r, "Key")
+			if err != nil {
+				return nil, err
+			}
+			msg, err := rspHex(r, "Msg")
+			if err != nil {
+				return nil, err
+			}
+			mac, err := rspHex(r, "Mac")
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("Count = %d, Klen = %d, Tlen = %d", count, len(key), len(mac)),
+				Fields: []Field{
+					{"secretKey", key},
+					{"data", msg},
+					{"expected", mac},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// cavpGcmSuites converts the GCMVS files with external IVs, such as
+// "gcmEncryptExtIV128.rsp" and "gcmDecrypt128.rsp". Decryption records with
+// "FAIL" instead of "PT" must be rejected. Sections with truncated tags or
+// IVs shorter than 4 bytes are skipped: secret boxes of package:cryptography
+// have 16-byte MACs and AesGcm requires nonces of at least 4 bytes.
+func cavpGcmSuites(name string, sections []rspSection) ([]*Suite, error) {
+	var keySize, direction string
+	switch {
+	case strings.HasPrefix(name, "gcmEncryptExtIV"):
+		keySize, direction = strings.TrimSuffix(strings.TrimPrefix(name, "gcmEncryptExtIV"), ".rsp"), "encryption"
+	case strings.HasPrefix(name, "gcmDecrypt"):
+		keySize, direction = strings.TrimSuffix(strings.TrimPrefix(name, "gcmDecrypt"), ".rsp"), "decryption"
+	default:
+		return nil, nil
+	}
+	s := &Suite{
+		Name: "cavp: aes-gcm, " + keySize + "-bit key, " + direction,
+		Body: fmt.Sprintf(wycheproofAeadBody, "AesGcm.with"+keySize+"bits(nonceLength: nonce.length)"),
+	}
+	skipped := 0
+	for _, section := range sections {
+		var lengths [4]int
+		for i, param := range []string{"IVlen", "PTlen", "AADlen", "Taglen"} {
+			var err error
+			if lengths[i], err = rspInt(section.params, param); err != nil {
+				return nil, err
+			}
+		}
+		ivBits, ptBits, aadBits, tagBits := lengths[0], lengths[1], lengths[2], lengths[3]
+		if tagBits != 128 || ivBits < 32 {
+			skipped += len(section.records)
+			continue
+		}
+		for _, r := range section.records {
+			count, err := rspInt(r, "Count")
+			if err != nil {
+				return nil, err
+			}
+			_, fail := r["FAIL"]
+			values := map[string][]byte{}
+			for _, name := range []string{"Key", "IV", "PT", "AAD", "CT", "Tag"} {
+				if name == "PT" && fail {
+					continue
+				}
+				if values[name], err = rspHex(r, name); err != nil {
+					return nil, err
+				}
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("IVlen = %d, PTlen = %d, AADlen = %d, Count = %d", ivBits, ptBits, aadBits, count),
+				Fields: []Field{
+					{"secretKey", values["Key"]},
+					{"nonce", values["IV"]},
+					{"aad", values["AAD"]},
+					{"clearText", values["PT"]},
+					{"cipherText", values["CT"]},
+					{"mac", values["Tag"]},
+					{"valid", !fail},
+				},
+			})
+		}
+	}
+	slog.Info("converted", "suite", s.Name, "vectors", len(s.Vectors), "skipped", skipped)
+	return []*Suite{s}, nil
+}
+
+// CavpSuites converts the response files of the CAVP archives in the cache
+// directory.
+func CavpSuites(cacheDir string) ([]*Suite, error) {
+	var suites []*Suite
+	for _, a := range cavpArchives {
+		data, err := openCorpus(cacheDir, a.corpus)
+		if err != nil {
+			return nil, err
+		}
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", a.corpus, err)
+		}
+		for _, f := range r.File {
+			name := path.Base(f.Name)
+			if !strings.HasSuffix(name, ".rsp") {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", a.corpus, err)
+			}
+			rsp, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", a.corpus, f.Name, err)
+			}
+			sections, err := parseRsp(rsp)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", a.corpus, f.Name, err)
+			}
+			s, err := a.convert(name, sections)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", a.corpus, f.Name, err)
+			}
+			suites = append(suites, s...)
+		}
+	}
+	if err := canonicalize(suites); err != nil {
+		return nil, err
+	}
+	return suites, nil
+}
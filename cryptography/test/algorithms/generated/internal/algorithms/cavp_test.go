@@ -0,0 +1,136 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestParseRsp(t *testing.T) {
+	// From SHA256ShortMsg.rsp.
+	const sha = `#  CAVS 11.0
+#  "SHA-256 ShortMsg" information
+#  SHA-256 tests are configured for BYTE oriented implementations
+
+[L = 32]
+
+Len = 0
+Msg = 00
+MD = e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+
+Len = 8
+Msg = d3
+MD = 28969cdfa74a12c82f3bad960b0b000aca2ac329deea5c2328ebc6f2ba9802c1
+`
+	sections, err := parseRsp([]byte(sha))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sections) != 1 || sections[0].params["L"] != "32" || len(sections[0].records) != 2 {
+		t.Fatalf("sections are %v", sections)
+	}
+	suites, err := cavpShaSuites("SHA256ShortMsg.rsp", sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Len = 0", "Len = 8"} {
+		v := findVector(t, suites, "cavp: sha-256, short messages", name)
+		sum := sha256.Sum256(v.Fields[0].Value.([]byte))
+		checkHex(t, v, "expected", hex.EncodeToString(sum[:]))
+	}
+	if suites, err := cavpShaSuites("SHA256Monte.rsp", sections); err != nil || suites != nil {
+		t.Errorf("Monte Carlo file converted to %v, %v", suites, err)
+	}
+
+	// From HMAC.rsp, with the MAC truncated to Tlen bytes.
+	const mac = `[L=32]
+
+Count = 30
+Klen = 40
+Tlen = 16
+Key = 6f35628d65813435534b5d67fbdb54cb33403d04e843103e6399f806cb5df95febbdd61236f33245
+Msg = 752cff52e4b90768558e5369e75d97c69643509a5e5904e0a386cbe4d0970ef73f918f675945a9aefe26daea27587e8dc909dd56fd0468805f834039b345f855cfe19c44b55af241fff3ffcd8045cd5c288e6c4e284c3720570b58e4d47b8feeedc52fd1401f698a209fccfa3b4c0d9a797b046a2759f82a54c41ccd7b5f592b
+Mac = 05d1243e6465ed9620c9aec1c351a186
+`
+	sections, err = parseRsp([]byte(mac))
+	if err != nil {
+		t.Fatal(err)
+	}
+	suites, err = cavpHmacSuites("HMAC.rsp", sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "cavp: hmac-sha-256", "Count = 30, Klen = 40, Tlen = 16")
+	h := hmac.New(sha256.New, v.Fields[0].Value.([]byte))
+	h.Write(v.Fields[1].Value.([]byte))
+	checkHex(t, v, "expected", hex.EncodeToString(h.Sum(nil)[:16]))
+
+	// From gcmDecrypt128.rsp, with a section of truncated tags.
+	const gcm = `[Keylen = 128]
+[IVlen = 96]
+[PTlen = 0]
+[AADlen = 0]
+[Taglen = 128]
+
+Count = 0
+Key = cf063a34d4a9a76c2c86787d3f96db71
+IV = 113b9785971864c83b01c787
+CT = 
+AAD = 
+Tag = 72ac8493e3a5228b5d130a69d2510e42
+PT = 
+
+Count = 1
+Key = a49a5e26a2f8cb63d05546c2a62f5343
+IV = 907763b19b9b4ab6bd4f0281
+CT = 
+AAD = 
+Tag = a2be08210d8c470a8df6e8fbd79ec5cf
+FAIL
+
+[Keylen = 128]
+[IVlen = 96]
+[PTlen = 0]
+[AADlen = 0]
+[Taglen = 120]
+
+Count = 0
+Key = 00000000000000000000000000000000
+IV = 000000000000000000000000
+CT = 
+AAD = 
+Tag = 000000000000000000000000000000
+PT = 
+`
+	sections, err = parseRsp([]byte(gcm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sections) != 2 {
+		t.Fatalf("%d sections, want 2", len(sections))
+	}
+	suites, err = cavpGcmSuites("gcmDecrypt128.rsp", sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(suites[0].Vectors); n != 2 {
+		t.Fatalf("%d vectors, want 2", n)
+	}
+	for i, v := range suites[0].Vectors {
+		block, err := aes.NewCipher(v.Fields[0].Value.([]byte))
+		if err != nil {
+			t.Fatal(err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = aead.Open(nil, v.Fields[1].Value.([]byte), concat(v.Fields[4].Value.([]byte), v.Fields[5].Value.([]byte)), v.Fields[2].Value.([]byte))
+		if valid := v.Fields[6].Value.(bool); valid != (err == nil) || valid != (i == 0) {
+			t.Errorf("%s: valid is %v, crypto/cipher returned %v", v.Name, valid, err)
+		}
+	}
+}
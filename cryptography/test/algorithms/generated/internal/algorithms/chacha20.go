@@ -0,0 +1,84 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/chacha20"
+)
+
+// ChaCha20 (RFC 8439) without a MAC. The key stream index is the byte offset
+// in the key stream: block counter * 64 + offset in the block.
+
+const chacha20Body = `
+final algorithm = Chacha20(macAlgorithm: MacAlgorithm.empty);
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  keyStreamIndex: keyStreamIndex,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(secretKey),
+  keyStreamIndex: keyStreamIndex,
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+// chacha20XOR encrypts the clear text with the key stream starting at the
+// key stream index.
+func chacha20XOR(key, nonce, clearText []byte, keyStreamIndex int) ([]byte, error) {
+	c, err := chacha20.NewUnauthenticatedCipher(key, nonce)
+	if err != nil {
+		return nil, err
+	}
+	c.SetCounter(uint32(keyStreamIndex / 64))
+	skipped := make([]byte, keyStreamIndex%64)
+	c.XORKeyStream(skipped, skipped)
+	cipherText := make([]byte, len(clearText))
+	c.XORKeyStream(cipherText, clearText)
+	return cipherText, nil
+}
+
+func init() {
+	register(newGenerator("chacha20", chacha20Suites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func chacha20Suites() ([]*Suite, error) {
+	s := &Suite{
+		Name:   "chacha20",
+		Body:   chacha20Body,
+		Covers: []string{"Chacha20 macAlgorithm=empty"},
+	}
+	key := sequence(0, chacha20.KeySize)
+	nonce := sequence(0x80, chacha20.NonceSize)
+	for _, keyStreamIndex := range []int{0, 1, 63, 64, 65, 127, 128, 1000, 64 * 1000, 64*0xfffff000 + 17} {
+		for _, n := range []int{0, 1, 64, 100} {
+			clearText := randomBytes(s.Name+": clearText", n)
+			cipherText, err := chacha20XOR(key, nonce, clearText, keyStreamIndex)
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("keyStreamIndex %d, %s", keyStreamIndex, describeBytes(clearText)),
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"keyStreamIndex", keyStreamIndex},
+					{"clearText", clearText},
+					{"cipherText", cipherText},
+				},
+			})
+		}
+	}
+	return []*Suite{s}, nil
+}
@@ -0,0 +1,175 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// ChaCha20-Poly1305 AEAD (RFC 8439) with golang.org/x/crypto.
+
+const chacha20Poly1305Body = `
+final algorithm = Chacha20.poly1305Aead();
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  aad: aad,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+  reason: 'cipherText',
+);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(mac),
+  reason: 'mac',
+);
+expect(
+  hexFromBytes(secretBox.concatenation()),
+  hexFromBytes(concatenation),
+  reason: 'concatenation',
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox.fromConcatenation(
+    concatenation,
+    nonceLength: nonce.length,
+    macLength: mac.length,
+  ),
+  secretKey: SecretKey(secretKey),
+  aad: aad,
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+// chacha20Poly1305Seal returns the cipher text and the tag.
+func chacha20Poly1305Seal(key, nonce, clearText, aad []byte) (cipherText, mac []byte, err error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed := aead.Seal(nil, nonce, clearText, aad)
+	return sealed[:len(clearText)], sealed[len(clearText):], nil
+}
+
+func init() {
+	register(newGenerator("chacha20-poly1305", chacha20Poly1305Suites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func chacha20Poly1305Suites() ([]*Suite, error) {
+	s := &Suite{
+		Name:   "chacha20-poly1305",
+		Body:   chacha20Poly1305Body,
+		Covers: []string{"Chacha20.poly1305Aead"},
+	}
+	key := sequence(0, chacha20poly1305.KeySize)
+	nonce := sequence(0x80, chacha20poly1305.NonceSize)
+	for _, tc := range []struct {
+		name           string
+		clearText, aad int
+	}{
+		{"empty clear text, empty AAD", 0, 0},
+		{"AAD only", 0, 12},
+		{"1 byte, empty AAD", 1, 0},
+		{"16 bytes, empty AAD", 16, 0},
+		{"63 bytes, 12 bytes of AAD", 63, 12},
+		{"64 bytes, 12 bytes of AAD", 64, 12},
+		{"65 bytes, 17 bytes of AAD", 65, 17},
+		{"200 bytes, 1 byte of AAD", 200, 1},
+		{"1024 bytes, 100 bytes of AAD", 1024, 100},
+	} {
+		clearText := randomBytes(s.Name+": clearText", tc.clearText)
+		aad := randomBytes(s.Name+": aad", tc.aad)
+		cipherText, mac, err := chacha20Poly1305Seal(key, nonce, clearText, aad)
+		if err != nil {
+			return nil, err
+		}
+		s.Vectors = append(s.Vectors, Vector{
+			Name: tc.name,
+			Fields: []Field{
+				{"secretKey", key},
+				{"nonce", nonce},
+				{"aad", aad},
+				{"clearText", clearText},
+				{"cipherText", cipherText},
+				{"mac", mac},
+			},
+		})
+	}
+
+	// Poly1305 pads the AAD and the cipher text to 16 bytes separately, so
+	// lengths around 16 with and without clear text.
+	aadLengths := &Suite{
+		Name:   "chacha20-poly1305: AAD length",
+		Body:   chacha20Poly1305Body,
+		Covers: s.Covers,
+	}
+	vectors, err := aeadAadLengthVectors(func(clearText, aad []byte) ([]byte, []byte, error) {
+		return chacha20Poly1305Seal(key, nonce, clearText, aad)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vectors {
+		v.Fields = append([]Field{{"secretKey", key}, {"nonce", nonce}}, v.Fields...)
+		aadLengths.Vectors = append(aadLengths.Vectors, v)
+	}
+	addConcatenation(s)
+	addConcatenation(aadLengths)
+	return []*Suite{s, aadLengths}, nil
+}
+
+// aeadAadLengths are the AAD lengths of the "AAD length" suites.
+var aeadAadLengths = []int{0, 1, 15, 16, 17, 1000}
+
+// aeadAadLengthVectors returns vectors with every AAD length and 0 or 17
+// bytes of clear text. The fields are aad, clearText, cipherText and mac.
+func aeadAadLengthVectors(seal func(clearText, aad []byte) (cipherText, mac []byte, err error)) ([]Vector, error) {
+	var vectors []Vector
+	for _, clearTextLength := range []int{0, 17} {
+		for _, aadLength := range aeadAadLengths {
+			clearText := sequence(0x01, clearTextLength)
+			aad := sequence(0xa0, aadLength)
+			cipherText, mac, err := seal(clearText, aad)
+			if err != nil {
+				return nil, err
+			}
+			vectors = append(vectors, Vector{
+				Name: fmt.Sprintf("%s, %s of AAD", describeBytes(clearText), describeBytes(aad)),
+				Fields: []Field{
+					{"aad", aad},
+					{"clearText", clearText},
+					{"cipherText", cipherText},
+					{"mac", mac},
+				},
+			})
+		}
+	}
+	return vectors, nil
+}
+
+// addConcatenation adds the field concatenation, which is what
+// SecretBox.concatenation() returns (nonce, cipher text and MAC), to the
+// vectors of an AEAD suite.
+func addConcatenation(s *Suite) {
+	for i, v := range s.Vectors {
+		var nonce, cipherText, mac []byte
+		for _, f := range v.Fields {
+			switch f.Name {
+			case "nonce":
+				nonce = f.Value.([]byte)
+			case "cipherText":
+				cipherText = f.Value.([]byte)
+			case "mac":
+				mac = f.Value.([]byte)
+			}
+		}
+		s.Vectors[i].Fields = append(v.Fields, Field{"concatenation", concat(nonce, cipherText, mac)})
+	}
+}
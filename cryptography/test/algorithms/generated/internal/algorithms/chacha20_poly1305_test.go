@@ -0,0 +1,75 @@
+package algorithms
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestChacha20Poly1305Seal(t *testing.T) {
+	// RFC 8439 section 2.8.2.
+	clearText := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+	cipherText, mac, err := chacha20Poly1305Seal(
+		mustHex("808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f"),
+		mustHex("070000004041424344454647"),
+		clearText,
+		mustHex("50515253c0c1c2c3c4c5c6c7"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(cipherText[:16]), "d31a8d34648e60db7b86afbc53ef7ec2"; got != want {
+		t.Errorf("cipherText starts with %s, want %s", got, want)
+	}
+	if got, want := hex.EncodeToString(mac), "1ae10b594f09e26a7e902ecbd0600691"; got != want {
+		t.Errorf("mac = %s, want %s", got, want)
+	}
+}
+
+func TestAeadAadLengths(t *testing.T) {
+	suites, err := chacha20Poly1305Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The AAD must change the tag and not the cipher text.
+	a := findVector(t, suites, "chacha20-poly1305: AAD length", "17 bytes, 15 bytes of AAD")
+	b := findVector(t, suites, "chacha20-poly1305: AAD length", "17 bytes, 16 bytes of AAD")
+	if !bytes.Equal(a.Fields[4].Value.([]byte), b.Fields[4].Value.([]byte)) {
+		t.Error("cipher texts differ")
+	}
+	if bytes.Equal(a.Fields[5].Value.([]byte), b.Fields[5].Value.([]byte)) {
+		t.Error("tags are equal")
+	}
+}
+
+func TestSecretBoxConcatenation(t *testing.T) {
+	suites, err := chacha20Poly1305Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "chacha20-poly1305", "1 byte, empty AAD")
+	cipherText, mac, err := chacha20Poly1305Seal(sequence(0, 32), sequence(0x80, 12), randomBytes("chacha20-poly1305: clearText", 1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "concatenation", hex.EncodeToString(concat(sequence(0x80, 12), cipherText, mac)))
+
+	// Every body that reads the field has it.
+	for _, g := range []func() ([]*Suite, error){aesGcmSuites, aes192Suites, chacha20Poly1305Suites} {
+		suites, err := g()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range suites {
+			if !strings.Contains(s.Body, "concatenation") {
+				continue
+			}
+			for _, v := range s.Vectors {
+				if v.Fields[len(v.Fields)-1].Name != "concatenation" {
+					t.Errorf("%s: %s: no concatenation", s.Name, v.Name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestChacha20XOR(t *testing.T) {
+	// RFC 8439 section 2.4.2: the message is encrypted with block counter 1.
+	clearText := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+	cipherText, err := chacha20XOR(
+		mustHex("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"),
+		mustHex("000000000000004a00000000"),
+		clearText,
+		64,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(cipherText[:16]), "6e2e359a2568f98041ba0728dd0d6981"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
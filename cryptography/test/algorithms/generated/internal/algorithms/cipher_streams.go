@@ -0,0 +1,87 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Encryption and decryption of clear texts delivered in chunks, as with
+// encryptStream and decryptStream. The "chunkLength" field is the length of
+// every chunk but the last, which has the rest. The cipher text and MAC are
+// the same as from one-shot encryption, so a streaming implementation is
+// checked against the same output as encrypt and decrypt.
+
+const cipherStreamSkip = "encryptStream and decryptStream are not implemented in package:cryptography"
+
+// cipherStreamChunkLengths are the lengths of the chunks: a byte, a length
+// that is not a divisor of any block size, an AES block and a typical buffer.
+var cipherStreamChunkLengths = []int{1, 7, 16, 4096}
+
+// cipherStreamLengths are clear text lengths that are not multiples of any
+// chunk length.
+var cipherStreamLengths = []int{100, 8200}
+
+func init() {
+	register(newGenerator("cipher-streams", cipherStreamSuites).
+		uses("bytes.go", "dart.go", "suite.go"))
+}
+
+func cipherStreamSuites() ([]*Suite, error) {
+	key := sequence(0, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	chacha, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	xchacha, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites []*Suite
+	for _, c := range []struct {
+		name string
+		aead cipher.AEAD
+	}{
+		{"aes-gcm", gcm},
+		{"chacha20-poly1305", chacha},
+		{"xchacha20-poly1305", xchacha},
+	} {
+		s := &Suite{
+			Name: c.name + ": streams",
+			Skip: cipherStreamSkip,
+		}
+		nonce := sequence(0x80, c.aead.NonceSize())
+		aad := sequence(0xa0, 13)
+		for _, n := range cipherStreamLengths {
+			clearText := sequence(0x01, n)
+			sealed := c.aead.Seal(nil, nonce, clearText, aad)
+			for _, chunkLength := range cipherStreamChunkLengths {
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%s in chunks of %s", describeBytes(clearText), describeCount(chunkLength, "byte")),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", nonce},
+						{"aad", aad},
+						{"clearText", clearText},
+						{"chunkLength", chunkLength},
+						{"cipherText", sealed[:n]},
+						{"mac", sealed[n:]},
+					},
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
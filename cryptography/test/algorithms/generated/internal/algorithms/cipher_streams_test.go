@@ -0,0 +1,22 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestCipherStream(t *testing.T) {
+	suites, err := cipherStreamSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The chunks do not change the output.
+	v := findVector(t, suites, "chacha20-poly1305: streams", "100 bytes in chunks of 7 bytes")
+	cipherText, mac, err := chacha20Poly1305Seal(sequence(0, 32), sequence(0x80, 12), sequence(0x01, 100), sequence(0xa0, 13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "cipherText", hex.EncodeToString(cipherText))
+	checkHex(t, v, "mac", hex.EncodeToString(mac))
+	findVector(t, suites, "aes-gcm: streams", "8200 bytes in chunks of 1 byte")
+}
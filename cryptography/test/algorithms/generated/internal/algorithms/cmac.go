@@ -0,0 +1,101 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// AES-CMAC (NIST SP 800-38B, RFC 4493). Neither the standard library nor
+// golang.org/x/crypto has CMAC, so this is a small implementation that is
+// checked against RFC 4493 in the tests.
+
+// cmacDouble multiplies a block by x in GF(2^128).
+func cmacDouble(b []byte) []byte {
+	out := make([]byte, len(b))
+	carry := b[0] >> 7
+	for i := 0; i < len(b)-1; i++ {
+		out[i] = b[i]<<1 | b[i+1]>>7
+	}
+	out[len(b)-1] = b[len(b)-1] << 1
+	if carry != 0 {
+		out[len(b)-1] ^= 0x87
+	}
+	return out
+}
+
+// aesCmac returns the 16-byte AES-CMAC tag.
+func aesCmac(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cmac(block, data), nil
+}
+
+func cmac(block cipher.Block, data []byte) []byte {
+	n := block.BlockSize()
+	l := make([]byte, n)
+	block.Encrypt(l, l)
+	k1 := cmacDouble(l)
+	k2 := cmacDouble(k1)
+
+	// The last block is XORed with K1 if it is complete and padded and
+	// XORed with K2 otherwise.
+	var last []byte
+	if len(data) > 0 && len(data)%n == 0 {
+		last = make([]byte, n)
+		subtle.XORBytes(last, data[len(data)-n:], k1)
+		data = data[:len(data)-n]
+	} else {
+		rest := len(data) % n
+		last = make([]byte, n)
+		copy(last, data[len(data)-rest:])
+		last[rest] = 0x80
+		subtle.XORBytes(last, last, k2)
+		data = data[:len(data)-rest]
+	}
+	x := make([]byte, n)
+	for len(data) > 0 {
+		subtle.XORBytes(x, x, data[:n])
+		block.Encrypt(x, x)
+		data = data[n:]
+	}
+	subtle.XORBytes(x, x, last)
+	block.Encrypt(x, x)
+	return x
+}
+
+func init() {
+	register(newGenerator("cmac", cmacSuites, spec.AesKeyLengths, spec.InputLengths, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func cmacSuites() ([]*Suite, error) {
+	s := &Suite{
+		Name: "aes-cmac",
+		Skip: "AES-CMAC is not implemented in package:cryptography",
+	}
+	for _, keyLength := range aesKeyLengths() {
+		for _, n := range inputLengths(0, 15, 16, 17, 64) {
+			key := sequence(0, keyLength)
+			data := randomBytes(s.Name+": data", n)
+			mac, err := aesCmac(key, data)
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("%d-bit key, %s", 8*keyLength, describeBytes(data)),
+				Fields: []Field{
+					{"secretKey", key},
+					{"data", data},
+					{"mac", mac},
+				},
+			})
+		}
+	}
+	return []*Suite{s}, nil
+}
@@ -0,0 +1,29 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestAesCmac(t *testing.T) {
+	// RFC 4493 section 4.
+	key := mustHex("2b7e151628aed2a6abf7158809cf4f3c")
+	message := mustHex("6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710")
+	for _, tc := range []struct {
+		length int
+		want   string
+	}{
+		{0, "bb1d6929e95937287fa37d129b756746"},
+		{16, "070a16b46b4d4144f79bdd9dd04a287c"},
+		{40, "dfa66747de9ae63030ca32611497c827"},
+		{64, "51f0bebf7e3b9d92fc49741779363cfe"},
+	} {
+		got, err := aesCmac(key, message[:tc.length])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tc.want {
+			t.Errorf("%d bytes: got %x, want %s", tc.length, got, tc.want)
+		}
+	}
+}
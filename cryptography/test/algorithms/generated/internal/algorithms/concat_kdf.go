@@ -0,0 +1,132 @@
+package algorithms
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// Single-step KDFs of ECIES and JWE. Both hash a 32-bit big-endian block
+// counter, starting at 1, with the shared secret and the other info:
+//
+//   - ANSI X9.63 (SEC 1 section 3.6.1): Hash(Z || counter || SharedInfo)
+//   - NIST SP 800-56A ConcatKDF (SP 800-56C option 1 with a hash):
+//     Hash(counter || Z || OtherInfo)
+
+const (
+	x963KdfSkip   = "ANSI X9.63 KDF is not implemented in package:cryptography"
+	concatKdfSkip = "ConcatKDF is not implemented in package:cryptography"
+)
+
+// singleStepKdfHash is a hash function of the KDF suites.
+type singleStepKdfHash struct {
+	name string
+	new  func() hash.Hash
+}
+
+var singleStepKdfHashes = []singleStepKdfHash{
+	{"sha1", sha1.New},
+	{"sha256", sha256.New},
+	{"sha384", sha512.New384},
+	{"sha512", sha512.New},
+}
+
+// singleStepKdf returns length bytes of output. If counterFirst is true,
+// the counter is hashed before the shared secret (ConcatKDF), otherwise
+// after it (X9.63).
+func singleStepKdf(newHash func() hash.Hash, counterFirst bool, z, otherInfo []byte, length int) []byte {
+	var out []byte
+	for counter := uint32(1); len(out) < length; counter++ {
+		h := newHash()
+		c := binary.BigEndian.AppendUint32(nil, counter)
+		if counterFirst {
+			h.Write(c)
+			h.Write(z)
+		} else {
+			h.Write(z)
+			h.Write(c)
+		}
+		h.Write(otherInfo)
+		out = h.Sum(out)
+	}
+	return out[:length]
+}
+
+// jweOtherInfo returns the OtherInfo of JWE ECDH-ES (RFC 7518 section
+// 4.6.2) with a key length in bits.
+func jweOtherInfo(algorithm, partyUInfo, partyVInfo string, keyBits int) []byte {
+	lengthPrefixed := func(s string) []byte {
+		return concat(binary.BigEndian.AppendUint32(nil, uint32(len(s))), []byte(s))
+	}
+	return concat(
+		lengthPrefixed(algorithm),
+		lengthPrefixed(partyUInfo),
+		lengthPrefixed(partyVInfo),
+		binary.BigEndian.AppendUint32(nil, uint32(keyBits)),
+	)
+}
+
+func init() {
+	register(newGenerator("x963kdf-concatkdf", singleStepKdfSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func singleStepKdfSuites() ([]*Suite, error) {
+	type input struct {
+		name         string
+		z, otherInfo []byte
+		length       int
+	}
+	var inputs []input
+	// Shared secrets of X25519, P-256, P-384 and P-521.
+	for _, n := range []int{32, 48, 66} {
+		for _, info := range [][]byte{nil, sequence(0xa0, 16)} {
+			for _, length := range []int{1, 16, 32, 33, 64, 100} {
+				inputs = append(inputs, input{
+					fmt.Sprintf("%d-byte secret, %d-byte info, %d-byte output", n, len(info), length),
+					sequence(0x01, n), info, length,
+				})
+			}
+		}
+	}
+	// RFC 7518 appendix C.
+	rfc7518 := input{
+		"RFC 7518 appendix C",
+		[]byte{158, 86, 217, 29, 129, 113, 53, 211, 114, 131, 66, 131, 191, 132, 38, 156, 251, 49, 110, 163, 218, 128, 106, 72, 246, 218, 167, 121, 140, 254, 144, 196},
+		jweOtherInfo("A128GCM", "Alice", "Bob", 128),
+		16,
+	}
+
+	var suites []*Suite
+	for _, kdf := range []struct {
+		name         string
+		skip         string
+		counterFirst bool
+	}{
+		{"x963kdf", x963KdfSkip, false},
+		{"concatkdf", concatKdfSkip, true},
+	} {
+		for _, h := range singleStepKdfHashes {
+			s := &Suite{Name: kdf.name + "-" + h.name, Skip: kdf.skip}
+			all := inputs
+			if kdf.counterFirst && h.name == "sha256" {
+				all = append(all[:len(all):len(all)], rfc7518)
+			}
+			for _, in := range all {
+				s.Vectors = append(s.Vectors, Vector{
+					Name: in.name,
+					Fields: []Field{
+						{"sharedSecret", in.z},
+						{"otherInfo", in.otherInfo},
+						{"expected", singleStepKdf(h.new, kdf.counterFirst, in.z, in.otherInfo, in.length)},
+					},
+				})
+			}
+			suites = append(suites, s)
+		}
+	}
+	return suites, nil
+}
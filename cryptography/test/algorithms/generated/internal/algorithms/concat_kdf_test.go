@@ -0,0 +1,22 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSingleStepKdf(t *testing.T) {
+	suites, err := singleStepKdfSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "VqqN6vgjbSBcIijNcacQGg" in RFC 7518 appendix C.
+	checkHex(t, findVector(t, suites, "concatkdf-sha256", "RFC 7518 appendix C"), "expected", "56aa8deaf8236d205c2228cd71a7101a")
+
+	// NIST CAVS ansx963_2001.rsp, SHA-256 with empty SharedInfo.
+	got := singleStepKdf(sha256.New, false, mustHex("96c05619d56c328ab95fe84b18264b08725b85e33fd34f08"), nil, 16)
+	if want := "443024c3dae66b95e6f5670601558f71"; hex.EncodeToString(got) != want {
+		t.Errorf("x963kdf = %x, want %s", got, want)
+	}
+}
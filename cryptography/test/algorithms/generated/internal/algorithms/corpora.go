@@ -0,0 +1,144 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CorporaPath is the manifest of external test vector corpora.
+const CorporaPath = "corpora.json"
+
+// Corpus is an external file that is downloaded by "go run ./cmd/vectorgen fetch".
+type Corpus struct {
+	// Name is the path of the file in the cache directory.
+	Name string `json:"name"`
+
+	// URL is where the file is downloaded from. It names a release or a
+	// commit, never a branch, so the file does not change after it is pinned.
+	URL string `json:"url"`
+
+	// SHA256 is the pinned hex-encoded SHA-256 hash of the file. Files that
+	// are not pinned are never used.
+	SHA256 string `json:"sha256"`
+}
+
+// DefaultCacheDir returns the default directory of the fetched corpora.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ".cache"
+	}
+	return filepath.Join(dir, "dart-cryptography-vectors")
+}
+
+// ReadCorpora reads the manifest at the path, which is CorporaPath in the
+// working directory of cmd/vectorgen.
+func ReadCorpora(path string) ([]Corpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var corpora []Corpus
+	if err := json.Unmarshal(data, &corpora); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return corpora, nil
+}
+
+// FetchCorpus downloads the file unless the cache already has a file with the
+// pinned hash. It returns the hash of the downloaded file.
+func FetchCorpus(cacheDir string, c Corpus) (string, error) {
+	path := filepath.Join(cacheDir, filepath.FromSlash(c.Name))
+	if c.SHA256 != "" {
+		if _, err := readVerified(path, c.SHA256); err == nil {
+			return c.SHA256, nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	client := &http.Client{Timeout: 5 * time.Minute}
+	slog.Info("downloading", "corpus", c.Name, "url", c.URL)
+	resp, err := client.Get(c.URL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", c.URL, resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := os.Remove(tmp.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("removing temporary file", "path", tmp.Name(), "err", err)
+		}
+	}()
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	if c.SHA256 != "" && sum != c.SHA256 {
+		return "", fmt.Errorf("SHA-256 mismatch: got %s, want %s", sum, c.SHA256)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+// readVerified reads a file and checks that it has the expected hash.
+func readVerified(path, want string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("%s: SHA-256 mismatch: got %s, want %s", path, got, want)
+	}
+	return data, nil
+}
+
+// openCorpus returns the contents of a cached corpus file after verifying its
+// pinned hash.
+func openCorpus(cacheDir, name string) ([]byte, error) {
+	corpora, err := ReadCorpora(CorporaPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range corpora {
+		if c.Name != name {
+			continue
+		}
+		if c.SHA256 == "" {
+			return nil, fmt.Errorf("%s: not pinned", name)
+		}
+		data, err := readVerified(filepath.Join(cacheDir, filepath.FromSlash(name)), c.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("%w (run \"go run ./cmd/vectorgen fetch\")", err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%s: not in %s", name, CorporaPath)
+}
@@ -0,0 +1,33 @@
+package algorithms
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCorpora(t *testing.T) {
+	corpora, err := ReadCorpora(filepath.Join("..", "..", CorporaPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := map[string]bool{}
+	for _, c := range wycheproofConverters {
+		used[c.corpus] = true
+	}
+	for _, a := range cavpArchives {
+		used[a.corpus] = true
+	}
+	for _, c := range corpora {
+		if !used[c.Name] {
+			t.Errorf("%s: not read by a converter", c.Name)
+		}
+		delete(used, c.Name)
+		if strings.Contains(c.URL, "/main/") || strings.Contains(c.URL, "/master/") {
+			t.Errorf("%s: URL %s is on a branch", c.Name, c.URL)
+		}
+	}
+	for name := range used {
+		t.Errorf("%s: not in %s", name, CorporaPath)
+	}
+}
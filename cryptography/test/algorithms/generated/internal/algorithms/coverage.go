@@ -0,0 +1,150 @@
+package algorithms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// AlgorithmsPath is the list of algorithms and parameters of
+// package:cryptography that "go run ./cmd/vectorgen coverage" checks.
+const AlgorithmsPath = "algorithms.json"
+
+// Algorithm is an entry of AlgorithmsPath.
+type Algorithm struct {
+	// Name is the Dart class or factory, such as "AesGcm" or
+	// "Chacha20.poly1305Aead".
+	Name string `json:"algorithm"`
+
+	// Parameters maps a parameter name to its possible values. The first
+	// value is the default.
+	Parameters map[string][]string `json:"parameters"`
+}
+
+// ReadAlgorithms reads the list of algorithms at the path, which is
+// AlgorithmsPath in the working directory of cmd/vectorgen.
+func ReadAlgorithms(path string) ([]Algorithm, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var algorithms []Algorithm
+	if err := json.Unmarshal(data, &algorithms); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return algorithms, nil
+}
+
+// parameterNames returns the parameter names in sorted order.
+func (a Algorithm) parameterNames() []string {
+	names := make([]string, 0, len(a.Parameters))
+	for name := range a.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// combinations returns every combination of parameter values in the
+// canonical form used by canonicalCoverage.
+func (a Algorithm) combinations() []string {
+	combinations := []string{a.Name}
+	for _, name := range a.parameterNames() {
+		var next []string
+		for _, prefix := range combinations {
+			for _, value := range a.Parameters[name] {
+				next = append(next, prefix+" "+name+"="+value)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// canonicalCoverage parses an entry of Suite.Covers, such as
+// "AesGcm nonceLength=16", and returns it with every parameter of the
+// algorithm in sorted order. Missing parameters have the default value.
+func canonicalCoverage(algorithms []Algorithm, covers string) (string, error) {
+	words := strings.Fields(covers)
+	if len(words) == 0 {
+		return "", errors.New("empty coverage entry")
+	}
+	var a *Algorithm
+	for i := range algorithms {
+		if algorithms[i].Name == words[0] {
+			a = &algorithms[i]
+		}
+	}
+	if a == nil {
+		return "", fmt.Errorf("%q: algorithm is not in %s", covers, AlgorithmsPath)
+	}
+	values := map[string]string{}
+	for _, word := range words[1:] {
+		name, value, ok := strings.Cut(word, "=")
+		if !ok {
+			return "", fmt.Errorf("%q: %q is not name=value", covers, word)
+		}
+		valid := false
+		for _, v := range a.Parameters[name] {
+			valid = valid || v == value
+		}
+		if !valid {
+			return "", fmt.Errorf("%q: %s=%s is not in %s", covers, name, value, AlgorithmsPath)
+		}
+		values[name] = value
+	}
+	result := a.Name
+	for _, name := range a.parameterNames() {
+		value, ok := values[name]
+		if !ok {
+			value = a.Parameters[name][0]
+		}
+		result += " " + name + "=" + value
+	}
+	return result, nil
+}
+
+// WriteCoverage writes the coverage report and returns the number of
+// untested combinations. Vectors of skipped suites are not counted.
+func WriteCoverage(w io.Writer, algorithms []Algorithm, suites []*Suite) (int, error) {
+	vectors := map[string]int{}
+	for _, s := range suites {
+		for _, covers := range s.Covers {
+			c, err := canonicalCoverage(algorithms, covers)
+			if err != nil {
+				return 0, fmt.Errorf("%s: %w", s.Name, err)
+			}
+			if s.Skip == "" {
+				vectors[c] += len(s.Vectors)
+			}
+		}
+	}
+	sorted := append([]Algorithm(nil), algorithms...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	untested := 0
+	for _, a := range sorted {
+		combinations := a.combinations()
+		var missing []string
+		for _, c := range combinations {
+			if vectors[c] == 0 {
+				missing = append(missing, c)
+			}
+		}
+		untested += len(missing)
+		if _, err := fmt.Fprintf(w, "%s: %d/%d combinations tested\n", a.Name, len(combinations)-len(missing), len(combinations)); err != nil {
+			return 0, err
+		}
+		for _, c := range missing {
+			if _, err := fmt.Fprintf(w, "  untested: %s\n", c); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return untested, nil
+}
@@ -0,0 +1,27 @@
+package algorithms
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectSuites(t *testing.T) {
+	suites, err := CollectSuites(registry, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	algorithms, err := ReadAlgorithms(filepath.Join("..", "..", AlgorithmsPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range suites {
+		if len(s.Vectors) == 0 {
+			t.Errorf("%s: no vectors", s.Name)
+		}
+		for _, covers := range s.Covers {
+			if _, err := canonicalCoverage(algorithms, covers); err != nil {
+				t.Errorf("%s: %v", s.Name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package algorithms
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// CPace (draft-irtf-cfrg-cpace) with the CPACE-X25519-ELL2_NU-SHA512
+// ciphersuite.
+
+const (
+	cpaceDSI      = "CPace255"
+	cpaceSInBytes = 128 // SHA-512 input block size
+)
+
+func leb128(n int) []byte {
+	var out []byte
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func cpacePrependLen(data []byte) []byte {
+	return concat(leb128(len(data)), data)
+}
+
+func cpaceLVCat(args ...[]byte) []byte {
+	var out []byte
+	for _, arg := range args {
+		out = append(out, cpacePrependLen(arg)...)
+	}
+	return out
+}
+
+func cpaceGeneratorString(prs, ci, sid []byte) []byte {
+	zpad := cpaceSInBytes - 1 - len(cpacePrependLen(prs)) - len(cpacePrependLen([]byte(cpaceDSI)))
+	if zpad < 0 {
+		zpad = 0
+	}
+	return cpaceLVCat([]byte(cpaceDSI), prs, make([]byte, zpad), ci, sid)
+}
+
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// elligator2Curve25519 maps a field element to the u-coordinate of a
+// Curve25519 point (RFC 9380 section 6.7.1, without the v-coordinate).
+func elligator2Curve25519(u *big.Int) *big.Int {
+	p := curve25519P
+	j := big.NewInt(486662)
+	// x1 = -J / (1 + 2 u^2)
+	d := new(big.Int).Mul(u, u)
+	d.Lsh(d, 1)
+	d.Add(d, big.NewInt(1))
+	d.Mod(d, p)
+	x1 := new(big.Int).Neg(j)
+	if d.Sign() != 0 {
+		x1.Mul(x1, new(big.Int).ModInverse(d, p))
+	}
+	x1.Mod(x1, p)
+	// gx1 = x1^3 + J x1^2 + x1
+	gx1 := new(big.Int).Mul(x1, x1)
+	gx1.Mul(gx1, x1)
+	t := new(big.Int).Mul(x1, x1)
+	t.Mul(t, j)
+	gx1.Add(gx1, t)
+	gx1.Add(gx1, x1)
+	gx1.Mod(gx1, p)
+	if big.Jacobi(gx1, p) >= 0 {
+		return x1
+	}
+	x2 := new(big.Int).Neg(x1)
+	x2.Sub(x2, j)
+	return x2.Mod(x2, p)
+}
+
+// littleEndianBytes encodes a field element as 32 little-endian bytes.
+func littleEndianBytes(x *big.Int) []byte {
+	out := make([]byte, 32)
+	b := x.Bytes()
+	for i := range b {
+		out[i] = b[len(b)-1-i]
+	}
+	return out
+}
+
+func littleEndianInt(b []byte) *big.Int {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[i] = b[len(b)-1-i]
+	}
+	return new(big.Int).SetBytes(reversed)
+}
+
+func cpaceCalculateGenerator(prs, ci, sid []byte) []byte {
+	h := sha512.Sum512(cpaceGeneratorString(prs, ci, sid))
+	u := h[:32]
+	u[31] &= 0x7f
+	x := littleEndianInt(u)
+	x.Mod(x, curve25519P)
+	return littleEndianBytes(elligator2Curve25519(x))
+}
+
+type cpaceInput struct {
+	name     string
+	prs      []byte
+	ci       []byte
+	sid      []byte
+	ya, yb   []byte
+	adA, adB []byte
+}
+
+func cpaceVector(in cpaceInput) (Vector, error) {
+	g := cpaceCalculateGenerator(in.prs, in.ci, in.sid)
+	publicA, err := curve25519.X25519(in.ya, g)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing Ya: %w", err)
+	}
+	publicB, err := curve25519.X25519(in.yb, g)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing Yb: %w", err)
+	}
+	k, err := curve25519.X25519(in.ya, publicB)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing K: %w", err)
+	}
+	transcript := concat(cpaceLVCat(publicA, in.adA), cpaceLVCat(publicB, in.adB))
+	isk := sha512.Sum512(concat(cpaceLVCat([]byte(cpaceDSI+"_ISK"), in.sid, k), transcript))
+	return Vector{
+		Name: in.name,
+		Fields: []Field{
+			{"prs", in.prs},
+			{"ci", in.ci},
+			{"sid", in.sid},
+			{"generatorString", cpaceGeneratorString(in.prs, in.ci, in.sid)},
+			{"generator", g},
+			{"ya", in.ya},
+			{"adA", in.adA},
+			{"publicA", publicA},
+			{"yb", in.yb},
+			{"adB", in.adB},
+			{"publicB", publicB},
+			{"k", k},
+			{"isk", isk[:]},
+		},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("cpace", cpaceSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func cpaceSuites() ([]*Suite, error) {
+	// Inputs of the X25519 test vector in the CPace draft (appendix B.1).
+	draft := cpaceInput{
+		name: "draft-irtf-cfrg-cpace inputs",
+		prs:  []byte("Password"),
+		ci:   []byte("\x0bA_initiator\x0bB_responder"),
+		sid:  mustHex("7e4b4791d6a8ef019b936c79fb7f2c57"),
+		ya:   mustHex("21b4f4bd9e64ed355c3eb676a28ebedaf6d8f17bdc365995b319097153044080"),
+		yb:   mustHex("848b0779ff415f0af4ea14df9dd1d3c29ac41d836c7808896c4eba19c51ac40a"),
+		adA:  []byte("ADa"),
+		adB:  []byte("ADb"),
+	}
+	withoutAD := draft
+	withoutAD.name = "without associated data"
+	withoutAD.adA = nil
+	withoutAD.adB = nil
+	longPassword := draft
+	longPassword.name = "password longer than the hash block"
+	longPassword.prs = make([]byte, 200)
+	for i := range longPassword.prs {
+		longPassword.prs[i] = byte(i)
+	}
+	s := &Suite{
+		Name: "cpace: X25519-ELL2_NU-SHA512",
+		Skip: "CPace is not implemented in package:cryptography",
+	}
+	for _, in := range []cpaceInput{draft, withoutAD, longPassword} {
+		v, err := cpaceVector(in)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", in.name, err)
+		}
+		s.Vectors = append(s.Vectors, v)
+	}
+	return []*Suite{s}, nil
+}
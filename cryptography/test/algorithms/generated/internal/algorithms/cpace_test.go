@@ -0,0 +1,71 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestCPace(t *testing.T) {
+	// RFC 9380 appendix J.7.2, curve25519_XMD:SHA-512_ELL2_NU_ with an empty
+	// message. The field elements are big-endian.
+	u, _ := new(big.Int).SetString("608d892b641f0328523802a6603427c26e55e6f27e71a91a478148d45b5093cd", 16)
+	if got, want := fmt.Sprintf("%064x", elligator2Curve25519(u)), "51125222da5e763d97f3c10fcc92ea6860b9ccbbd2eb1285728f566721c1e65b"; got != want {
+		t.Errorf("elligator2Curve25519 = %s, want %s", got, want)
+	}
+
+	suites, err := cpaceSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const name = "cpace: X25519-ELL2_NU-SHA512"
+	v := findVector(t, suites, name, "draft-irtf-cfrg-cpace inputs")
+	sid := fieldValue(t, v, "sid").([]byte)
+	ci := fieldValue(t, v, "ci").([]byte)
+	// lv_cat(DSI, PRS, ZPAD, CI, sid) with ZPAD filling the SHA-512 block.
+	generatorString := concat(
+		[]byte("\x08CPace255\x08Password"),
+		[]byte{109}, make([]byte, 109),
+		[]byte{byte(len(ci))}, ci,
+		[]byte{byte(len(sid))}, sid,
+	)
+	checkHex(t, v, "generatorString", hex.EncodeToString(generatorString))
+	h := sha512.Sum512(generatorString)
+	h[31] &= 0x7f
+	u = littleEndianInt(h[:32])
+	checkHex(t, v, "generator", hex.EncodeToString(littleEndianBytes(elligator2Curve25519(u.Mod(u, curve25519P)))))
+
+	// B computes the same K from the public value of A.
+	publicA := fieldValue(t, v, "publicA").([]byte)
+	publicB := fieldValue(t, v, "publicB").([]byte)
+	k, err := curve25519.X25519(fieldValue(t, v, "yb").([]byte), publicA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "k", hex.EncodeToString(k))
+	isk := sha512.Sum512(concat(
+		[]byte("\x0cCPace255_ISK"),
+		[]byte{16}, sid,
+		[]byte{32}, k,
+		[]byte{32}, publicA,
+		[]byte("\x03ADa"),
+		[]byte{32}, publicB,
+		[]byte("\x03ADb"),
+	))
+	checkHex(t, v, "isk", hex.EncodeToString(isk[:]))
+
+	// A password of 200 bytes has a two-byte LEB128 length and no padding.
+	v = findVector(t, suites, name, "password longer than the hash block")
+	gs := fieldValue(t, v, "generatorString").([]byte)
+	if want := []byte("\x08CPace255\xc8\x01\x00\x01"); !bytes.HasPrefix(gs, want) {
+		t.Errorf("generatorString starts with %x, want %x", gs[:len(want)], want)
+	}
+	if got, want := len(gs), 9+2+200+1+1+len(ci)+1+len(sid); got != want {
+		t.Errorf("generatorString has %d bytes, want %d", got, want)
+	}
+}
@@ -0,0 +1,256 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Cycles feed the output of an algorithm back as its input, so one short
+// vector checks a long chain of calls. The state starts empty and every cycle
+// computes the output of the state, or with "differentLengths" of the state
+// followed by the first i bytes of data (byte i is i % 256), which checks every
+// input length up to the number of cycles. The next state is the last 64
+// bytes of the output. Ciphers encrypt with a fixed key and nonce and their
+// output is the cipher text followed by the MAC.
+//
+// The -cycles flag sets the number of cycles. With the default, the BLAKE2s
+// vectors are the same as the cycle tests in blake2s_test.dart.
+
+// DefaultCycles is the default value of the -cycles flag.
+const DefaultCycles = 10000
+
+// Cycles is the number of cycles of the cycle suites.
+var Cycles = DefaultCycles
+
+// cycleStateLength is the maximum length of the state.
+const cycleStateLength = 64
+
+// cyclesDart is the beginning of every cycle body. The algorithm-specific
+// part computes output from message.
+const cyclesDart = `
+final data = Uint8List(cycles);
+for (var i = 0; i < data.length; i++) {
+  data[i] = i %% 256;
+}
+List<int> actual = <int>[];
+for (var i = 0; i < cycles; i++) {
+  final message = differentLengths ? [...actual, ...data.sublist(0, i)] : actual;
+%s
+  actual = output.length > %d ? output.sublist(output.length - %d) : output;
+}
+expect(
+  hexFromBytes(actual),
+  hexFromBytes(expected),
+);
+`
+
+// cyclesBody returns a body that computes output with the Dart code.
+func cyclesBody(code string) string {
+	return fmt.Sprintf(cyclesDart, Indent(2, code), cycleStateLength, cycleStateLength)
+}
+
+// runCycles returns the state after n cycles of f.
+func runCycles(n int, differentLengths bool, f func(message []byte) ([]byte, error)) ([]byte, error) {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i % 256)
+	}
+	var state []byte
+	for i := 0; i < n; i++ {
+		message := state
+		if differentLengths {
+			message = concat(state, data[:i])
+		}
+		output, err := f(message)
+		if err != nil {
+			return nil, err
+		}
+		if len(output) > cycleStateLength {
+			output = output[len(output)-cycleStateLength:]
+		}
+		state = output
+	}
+	return state, nil
+}
+
+// cycleAlgorithm is an algorithm of the cycle suites.
+type cycleAlgorithm struct {
+	name, covers string
+
+	// dart computes output from message.
+	dart string
+
+	// fields are the fields that the Dart code uses, such as the key.
+	fields []Field
+
+	// bytesPerCost and callCost estimate the cost of a cycle.
+	bytesPerCost, callCost int
+
+	f func(message []byte) ([]byte, error)
+}
+
+// hashCycleAlgorithm returns the hash as a cycleAlgorithm.
+func hashCycleAlgorithm(h sinkHash) cycleAlgorithm {
+	return cycleAlgorithm{
+		name:         h.name,
+		covers:       h.dart,
+		dart:         fmt.Sprintf("final output = (await %s().hash(message)).bytes;", h.dart),
+		bytesPerCost: h.new().BlockSize(),
+		callCost:     1,
+		f: func(message []byte) ([]byte, error) {
+			d := h.new()
+			d.Write(message)
+			return d.Sum(nil), nil
+		},
+	}
+}
+
+// hmacCycleAlgorithm returns the HMAC of the hash as a cycleAlgorithm.
+func hmacCycleAlgorithm(h sinkHash, key []byte) cycleAlgorithm {
+	return cycleAlgorithm{
+		name:   "hmac-" + h.name,
+		covers: "Hmac hashAlgorithm=" + h.dart,
+		dart: fmt.Sprintf(`final mac = await Hmac(%s()).calculateMac(
+  message,
+  secretKey: SecretKey(secretKey),
+);
+final output = mac.bytes;`, h.dart),
+		fields:       []Field{{"secretKey", key}},
+		bytesPerCost: h.new().BlockSize(),
+		// The padded key blocks and the outer hash.
+		callCost: 4,
+		f: func(message []byte) ([]byte, error) {
+			mac := hmac.New(h.new, key)
+			mac.Write(message)
+			return mac.Sum(nil), nil
+		},
+	}
+}
+
+// cipherCycleAlgorithm returns an AEAD as a cycleAlgorithm.
+func cipherCycleAlgorithm(name, dart, covers string, key, nonce []byte, seal func(clearText []byte) ([]byte, error)) cycleAlgorithm {
+	return cycleAlgorithm{
+		name:   name,
+		covers: covers,
+		dart: fmt.Sprintf(`final secretBox = await %s.encrypt(
+  message,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+final output = secretBox.concatenation(nonce: false);`, dart),
+		fields:       []Field{{"secretKey", key}, {"nonce", nonce}},
+		bytesPerCost: 16,
+		callCost:     4,
+		f:            seal,
+	}
+}
+
+func init() {
+	register(cycleGenerator{})
+}
+
+// cycleGenerator returns the cycle suites. They depend on the -cycles flag.
+type cycleGenerator struct{}
+
+func (cycleGenerator) Name() string { return "cycles" }
+
+func (cycleGenerator) Params() Params {
+	return Params{
+		Flags: []string{fmt.Sprintf("cycles=%d", Cycles)},
+		Files: []string{"cycles.go", "aes_cbc.go", "bytes.go", "dart.go", "sinks.go", "suite.go"},
+	}
+}
+
+func (cycleGenerator) Generate() ([]*Suite, error) { return cycleSuites() }
+
+func cycleSuites() ([]*Suite, error) {
+	var algorithms []cycleAlgorithm
+	for _, h := range sinkHashes {
+		algorithms = append(algorithms, hashCycleAlgorithm(h))
+	}
+	hmacKey := sequence(0x40, 32)
+	for _, h := range sinkHashes {
+		// HMAC-BLAKE2 has the wrong block lengths (see blake2HmacSkip).
+		if h.name != "blake2b" && h.name != "blake2s" {
+			algorithms = append(algorithms, hmacCycleAlgorithm(h, hmacKey))
+		}
+	}
+
+	key := sequence(0, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	chacha, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	xchacha, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	aeadSeal := func(aead cipher.AEAD, nonce []byte) func([]byte) ([]byte, error) {
+		return func(clearText []byte) ([]byte, error) {
+			return aead.Seal(nil, nonce, clearText, nil), nil
+		}
+	}
+	cbcNonce := sequence(0x80, aes.BlockSize)
+	algorithms = append(algorithms,
+		cipherCycleAlgorithm("aes-gcm", "AesGcm.with256bits()", "AesGcm secretKeyLength=32 nonceLength=12",
+			key, sequence(0x80, 12), aeadSeal(gcm, sequence(0x80, 12))),
+		cipherCycleAlgorithm("chacha20-poly1305", "Chacha20.poly1305Aead()", "Chacha20.poly1305Aead",
+			key, sequence(0x80, 12), aeadSeal(chacha, sequence(0x80, 12))),
+		cipherCycleAlgorithm("xchacha20-poly1305", "Xchacha20.poly1305Aead()", "Xchacha20.poly1305Aead",
+			key, sequence(0x80, 24), aeadSeal(xchacha, sequence(0x80, 24))),
+		cipherCycleAlgorithm("aes-cbc", "AesCbc.with256bits(macAlgorithm: Hmac.sha256())", "AesCbc macAlgorithm=Hmac.sha256",
+			key, cbcNonce, func(clearText []byte) ([]byte, error) {
+				cipherText, err := aesCbcEncrypt(key, cbcNonce, clearText)
+				if err != nil {
+					return nil, err
+				}
+				return concat(cipherText, aesCbcMacs[0].mac(key, cipherText)), nil
+			}),
+	)
+
+	var suites []*Suite
+	for _, a := range algorithms {
+		s := &Suite{
+			Name:   a.name + ": cycles",
+			Body:   cyclesBody(a.dart),
+			Covers: []string{a.covers},
+		}
+		for _, differentLengths := range []bool{false, true} {
+			expected, err := runCycles(Cycles, differentLengths, a.f)
+			if err != nil {
+				return nil, err
+			}
+			name := fmt.Sprintf("%d cycles", Cycles)
+			// The messages are at most cycleStateLength bytes, or with
+			// different lengths about cycles/2 bytes longer on average.
+			bytes := Cycles * cycleStateLength
+			if differentLengths {
+				name += ", different lengths"
+				bytes += Cycles * (Cycles - 1) / 2
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: name,
+				Fields: append(append([]Field(nil), a.fields...),
+					Field{"cycles", Cycles},
+					Field{"differentLengths", differentLengths},
+					Field{"expected", expected},
+				),
+				Cost: bytes/a.bytesPerCost + Cycles*a.callCost,
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,29 @@
+package algorithms
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCycles(t *testing.T) {
+	suites, err := cycleSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The values of blake2s_test.dart.
+	v := findVector(t, suites, "blake2s: cycles", "10000 cycles")
+	checkHex(t, v, "expected", "64f338fcf15a4dd6273e8b8a54d27f1502ba3ac67b67c9dc15ca1f916fa6df76")
+	v = findVector(t, suites, "blake2s: cycles", "10000 cycles, different lengths")
+	checkHex(t, v, "expected", "49e37b4a7e9e2ed81d5b72f222537e58fd0a28e6b6a935818fd802fd3e1f4a36")
+
+	// The state is the last 64 bytes of the output.
+	got, err := runCycles(2, false, func(message []byte) ([]byte, error) {
+		return concat(message, sequence(byte(len(message)), 40)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := concat(sequence(0, 40)[16:], sequence(40, 40)); !bytes.Equal(got, want) {
+		t.Errorf("got %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+package algorithms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// describeBytes returns a short description of the bytes for test names.
+func describeBytes(b []byte) string {
+	return describeLength(len(b))
+}
+
+// describeLength returns a phrase such as "1 byte" or "16 bytes".
+func describeLength(n int) string {
+	return describeCount(n, "byte")
+}
+
+// describeCount returns a phrase such as "1 caveat" or "2 caveats".
+func describeCount(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// Indent indents every non-empty line of the Dart code. Bodies and the
+// templates of package emit use it to nest Dart code.
+func Indent(n int, code string) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(strings.TrimSpace(code), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,26 @@
+package algorithms
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	for _, tc := range []struct {
+		got, want string
+	}{
+		{describeBytes(nil), "0 bytes"},
+		{describeBytes([]byte{1}), "1 byte"},
+		{describeBytes(make([]byte, 64)), "64 bytes"},
+		{describeLength(0), "0 bytes"},
+		{describeCount(0, "caveat"), "0 caveats"},
+		{describeCount(1, "caveat"), "1 caveat"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+}
+
+func TestIndent(t *testing.T) {
+	if got, want := Indent(2, "\na\n\nb\n"), "  a\n\n  b"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package algorithms
+
+import (
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+)
+
+// ECDH over the NIST curves. Public keys are uncompressed points
+// (0x04 || x || y) and the shared secret is the x-coordinate. Only
+// BrowserCryptography implements Ecdh, so the suites run on Chrome.
+
+const ecdhBody = `
+final algorithm = Ecdh.%[1]s(length: sharedSecret.length);
+final n = (publicKey.length - 1) ~/ 2;
+final keyPair = EcKeyPairData(
+  d: privateKey,
+  x: publicKey.sublist(1, 1 + n),
+  y: publicKey.sublist(1 + n),
+  type: KeyPairType.%[1]s,
+);
+final remotePublicKey = EcPublicKey(
+  x: peerPublicKey.sublist(1, 1 + n),
+  y: peerPublicKey.sublist(1 + n),
+  type: KeyPairType.%[1]s,
+);
+final sharedSecretKey = await algorithm.sharedSecretKey(
+  keyPair: keyPair,
+  remotePublicKey: remotePublicKey,
+);
+expect(
+  hexFromBytes(await sharedSecretKey.extractBytes()),
+  hexFromBytes(sharedSecret),
+);
+`
+
+// nistCurve is a NIST curve of the ECDH and ECDSA suites.
+type nistCurve struct {
+	// name is the name in suite names, such as "p256".
+	name string
+
+	ecdh     ecdh.Curve
+	elliptic elliptic.Curve
+}
+
+var nistCurves = []nistCurve{
+	{"p256", ecdh.P256(), elliptic.P256()},
+	{"p384", ecdh.P384(), elliptic.P384()},
+	{"p521", ecdh.P521(), elliptic.P521()},
+}
+
+// scalarSize returns the length of an encoded scalar or coordinate.
+func (c nistCurve) scalarSize() int {
+	return (c.elliptic.Params().BitSize + 7) / 8
+}
+
+// sequenceScalar returns sequence(start, scalarSize()) reduced below the
+// order of the curve. Only P-521 needs it: its scalars have 521 bits.
+func (c nistCurve) sequenceScalar(start byte) []byte {
+	d := new(big.Int).SetBytes(sequence(start, c.scalarSize()))
+	d.Mod(d, c.elliptic.Params().N)
+	return d.FillBytes(make([]byte, c.scalarSize()))
+}
+
+func init() {
+	register(newGenerator("ecdh", ecdhSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func ecdhSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, c := range nistCurves {
+		s := &Suite{
+			Name:   "ecdh-" + c.name,
+			Body:   fmt.Sprintf(ecdhBody, c.name),
+			TestOn: "chrome",
+			Covers: []string{"Ecdh curve=" + c.name},
+		}
+		n := c.elliptic.Params().N
+		one := big.NewInt(1).FillBytes(make([]byte, c.scalarSize()))
+		nMinusOne := new(big.Int).Sub(n, big.NewInt(1)).FillBytes(make([]byte, c.scalarSize()))
+		peer, err := c.ecdh.NewPrivateKey(c.sequenceScalar(0x80))
+		if err != nil {
+			return nil, err
+		}
+		privateKeys := map[string][]byte{
+			"private key 1":   one,
+			"private key n-1": nMinusOne,
+		}
+		for _, start := range []byte{0x00, 0x40, 0xc0} {
+			d := c.sequenceScalar(start)
+			privateKeys[fmt.Sprintf("private key %02x..%02x", d[0], d[len(d)-1])] = d
+		}
+		for name, d := range privateKeys {
+			privateKey, err := c.ecdh.NewPrivateKey(d)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", s.Name, name, err)
+			}
+			sharedSecret, err := privateKey.ECDH(peer.PublicKey())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", s.Name, name, err)
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"privateKey", d},
+					{"publicKey", privateKey.PublicKey().Bytes()},
+					{"peerPublicKey", peer.PublicKey().Bytes()},
+					{"sharedSecret", sharedSecret},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,28 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEcdh(t *testing.T) {
+	suites, err := ecdhSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The public key of 1 is the base point and the shared secret is the x
+	// coordinate of the peer public key.
+	for _, c := range nistCurves {
+		v := findVector(t, suites, "ecdh-"+c.name, "private key 1")
+		params := c.elliptic.Params()
+		size := c.scalarSize()
+		g := append([]byte{4}, params.Gx.FillBytes(make([]byte, size))...)
+		g = append(g, params.Gy.FillBytes(make([]byte, size))...)
+		checkHex(t, v, "publicKey", hex.EncodeToString(g))
+		for _, f := range v.Fields {
+			if f.Name == "peerPublicKey" {
+				checkHex(t, v, "sharedSecret", hex.EncodeToString(f.Value.([]byte)[1:1+size]))
+			}
+		}
+	}
+}
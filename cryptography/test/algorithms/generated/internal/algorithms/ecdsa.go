@@ -0,0 +1,268 @@
+package algorithms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// ECDSA over the NIST curves with deterministic nonces (RFC 6979), so the
+// signatures are reproducible. Signatures are r || s with fixed-length
+// big-endian integers (IEEE P1363), the format of WebCrypto and Dart Ecdsa.
+// Every signature is also given as an ASN.1 DER sequence of r and s, the
+// format of OpenSSL and X.509.
+
+// Dart Ecdsa uses WebCrypto, which signs with random nonces.
+const ecdsaDeterministicSkip = "Ecdsa in package:cryptography does not sign with RFC 6979 nonces"
+
+const ecdsaSignBody = `
+final algorithm = Ecdsa.%[1]s(%[2]s());
+final n = (publicKey.length - 1) ~/ 2;
+final keyPair = EcKeyPairData(
+  d: privateKey,
+  x: publicKey.sublist(1, 1 + n),
+  y: publicKey.sublist(1 + n),
+  type: KeyPairType.%[1]s,
+);
+final signature = await algorithm.sign(message, keyPair: keyPair);
+expect(
+  hexFromBytes(signature.bytes),
+  hexFromBytes(expected),
+);
+`
+
+// WebCrypto returns false for invalid signatures, but other implementations
+// may throw ArgumentError for malformed ones, such as r = 0.
+const ecdsaVerifyBody = `
+final algorithm = Ecdsa.%[1]s(%[2]s());
+final n = (publicKey.length - 1) ~/ 2;
+final publicKeyObject = EcPublicKey(
+  x: publicKey.sublist(1, 1 + n),
+  y: publicKey.sublist(1 + n),
+  type: KeyPairType.%[1]s,
+);
+var isValid = false;
+try {
+  isValid = await algorithm.verify(
+    message,
+    signature: Signature(signature, publicKey: publicKeyObject),
+  );
+} on ArgumentError {
+  isValid = false;
+}
+expect(isValid, valid);
+`
+
+// ecdsaHash is a hash function of the ECDSA suites.
+type ecdsaHash struct {
+	// dart is the Dart class, which is also the name in algorithms.json.
+	dart string
+	hash crypto.Hash
+}
+
+var ecdsaHashes = []ecdsaHash{
+	{"Sha256", crypto.SHA256},
+	{"Sha384", crypto.SHA384},
+	{"Sha512", crypto.SHA512},
+}
+
+// rfc6979Keys are the private keys of RFC 6979 appendix A.2.
+var rfc6979Keys = map[string]string{
+	"p256": "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+	"p384": "6b9d3dad2e1b8c1c05b19875b6659f4de23c3b667bf297ba9aa47740787137d896d5724e4c70a825f872c9ea60d2edf5",
+	"p521": "00fad06daa62ba3b25d2fb40133da757205de67f5bb0018fee8c86e1b68c7e75caa896eb32f1f47c70855836a6d16fcc1466f6d8fbec67db89ec0c08b0e996b83538",
+}
+
+// ecdsaSign returns the RFC 6979 signature of the message as r || s.
+func ecdsaSign(c nistCurve, h crypto.Hash, privateKey, message []byte) ([]byte, error) {
+	priv, err := ecdsa.ParseRawPrivateKey(c.elliptic, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	digest := h.New()
+	digest.Write(message)
+	der, err := priv.Sign(nil, digest.Sum(nil), h)
+	if err != nil {
+		return nil, err
+	}
+	var rs struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(der, &rs); err != nil {
+		return nil, err
+	}
+	size := c.scalarSize()
+	return append(rs.R.FillBytes(make([]byte, size)), rs.S.FillBytes(make([]byte, size))...), nil
+}
+
+// ecdsaDer converts an r || s signature to DER.
+func ecdsaDer(signature []byte) ([]byte, error) {
+	size := len(signature) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		new(big.Int).SetBytes(signature[:size]),
+		new(big.Int).SetBytes(signature[size:]),
+	})
+}
+
+// ecdsaPublicKey returns the uncompressed public key of a private key.
+func ecdsaPublicKey(c nistCurve, privateKey []byte) ([]byte, error) {
+	priv, err := ecdsa.ParseRawPrivateKey(c.elliptic, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return priv.PublicKey.Bytes()
+}
+
+func init() {
+	register(newGenerator("ecdsa", ecdsaSuites, spec.Seed).
+		uses("bytes.go", "dart.go", "ecdh.go", "prng.go", "suite.go"))
+}
+
+func ecdsaSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, c := range nistCurves {
+		keys := map[string][]byte{
+			"RFC 6979 key": mustHex(rfc6979Keys[c.name]),
+			"sequence key": c.sequenceScalar(0x40),
+		}
+		for _, h := range ecdsaHashes {
+			s := &Suite{
+				Name:   fmt.Sprintf("ecdsa-%s-%s: sign", c.name, strings.ToLower(h.dart)),
+				Body:   fmt.Sprintf(ecdsaSignBody, c.name, h.dart),
+				Skip:   ecdsaDeterministicSkip,
+				TestOn: "chrome",
+				Covers: []string{fmt.Sprintf("Ecdsa curve=%s hashAlgorithm=%s", c.name, h.dart)},
+			}
+			for keyName, privateKey := range keys {
+				publicKey, err := ecdsaPublicKey(c, privateKey)
+				if err != nil {
+					return nil, err
+				}
+				messages := map[string][]byte{
+					`"sample"`: []byte("sample"),
+					`"test"`:   []byte("test"),
+				}
+				for _, n := range []int{0, 1, 64, 1000} {
+					message := randomBytes(s.Name+": message", n)
+					messages[describeBytes(message)] = message
+				}
+				for messageName, message := range messages {
+					expected, err := ecdsaSign(c, h.hash, privateKey, message)
+					if err != nil {
+						return nil, err
+					}
+					expectedDer, err := ecdsaDer(expected)
+					if err != nil {
+						return nil, err
+					}
+					s.Vectors = append(s.Vectors, Vector{
+						Name: keyName + ", " + messageName,
+						Fields: []Field{
+							{"privateKey", privateKey},
+							{"publicKey", publicKey},
+							{"message", message},
+							{"expected", expected},
+							{"expectedDer", expectedDer},
+						},
+					})
+				}
+			}
+			suites = append(suites, s)
+
+			verify, err := ecdsaVerifySuite(c, h)
+			if err != nil {
+				return nil, err
+			}
+			suites = append(suites, verify)
+		}
+	}
+	return suites, nil
+}
+
+// ecdsaVerifySuite returns valid and invalid signatures for verify().
+func ecdsaVerifySuite(c nistCurve, h ecdsaHash) (*Suite, error) {
+	s := &Suite{
+		Name:   fmt.Sprintf("ecdsa-%s-%s: verify", c.name, strings.ToLower(h.dart)),
+		Body:   fmt.Sprintf(ecdsaVerifyBody, c.name, h.dart),
+		TestOn: "chrome",
+		Covers: []string{fmt.Sprintf("Ecdsa curve=%s hashAlgorithm=%s", c.name, h.dart)},
+	}
+	privateKey := c.sequenceScalar(0x40)
+	publicKey, err := ecdsaPublicKey(c, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	message := []byte("sample")
+	signature, err := ecdsaSign(c, h.hash, privateKey, message)
+	if err != nil {
+		return nil, err
+	}
+	size := c.scalarSize()
+	n := c.elliptic.Params().N
+	r := new(big.Int).SetBytes(signature[:size])
+	sValue := new(big.Int).SetBytes(signature[size:])
+	// withRS returns nil if r or s does not fit in the fixed-length encoding.
+	withRS := func(r, s *big.Int) []byte {
+		if r.BitLen() > 8*size || s.BitLen() > 8*size {
+			return nil
+		}
+		return append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	}
+	otherMessage := []byte("samplf")
+	otherPublicKey, err := ecdsaPublicKey(c, c.sequenceScalar(0x80))
+	if err != nil {
+		return nil, err
+	}
+	for _, tc := range []struct {
+		name      string
+		publicKey []byte
+		message   []byte
+		signature []byte
+		valid     bool
+	}{
+		{"valid", publicKey, message, signature, true},
+		// ECDSA accepts both s and n - s.
+		{"valid with n - s", publicKey, message, withRS(r, new(big.Int).Sub(n, sValue)), true},
+		{"r = 0", publicKey, message, withRS(big.NewInt(0), sValue), false},
+		{"s = 0", publicKey, message, withRS(r, big.NewInt(0)), false},
+		{"r = n", publicKey, message, withRS(n, sValue), false},
+		{"s = n", publicKey, message, withRS(r, n), false},
+		{"s = n + 1", publicKey, message, withRS(r, new(big.Int).Add(n, big.NewInt(1))), false},
+		{"s + n", publicKey, message, withRS(r, new(big.Int).Add(sValue, n)), false},
+		{"wrong message", publicKey, otherMessage, signature, false},
+		{"wrong public key", otherPublicKey, message, signature, false},
+	} {
+		if tc.signature == nil {
+			continue
+		}
+		pub, err := ecdsa.ParseUncompressedPublicKey(c.elliptic, tc.publicKey)
+		if err != nil {
+			return nil, err
+		}
+		digest := h.hash.New()
+		digest.Write(tc.message)
+		r := new(big.Int).SetBytes(tc.signature[:size])
+		sv := new(big.Int).SetBytes(tc.signature[size:])
+		if ecdsa.Verify(pub, digest.Sum(nil), r, sv) != tc.valid {
+			return nil, fmt.Errorf("%s: %s: Go does not agree that valid = %v", s.Name, tc.name, tc.valid)
+		}
+		signatureDer, err := ecdsaDer(tc.signature)
+		if err != nil {
+			return nil, err
+		}
+		s.Vectors = append(s.Vectors, Vector{
+			Name: tc.name,
+			Fields: []Field{
+				{"publicKey", tc.publicKey},
+				{"message", tc.message},
+				{"signature", tc.signature},
+				{"signatureDer", signatureDer},
+				{"valid", tc.valid},
+			},
+		})
+	}
+	return s, nil
+}
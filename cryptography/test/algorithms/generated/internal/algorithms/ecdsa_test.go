@@ -0,0 +1,32 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEcdsa(t *testing.T) {
+	suites, err := ecdsaSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 6979 appendix A.2.5: P-256 with SHA-256.
+	v := findVector(t, suites, "ecdsa-p256-sha256: sign", `RFC 6979 key, "sample"`)
+	checkHex(t, v, "expected", "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8")
+	checkHex(t, v, "publicKey", "0460fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb67903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299")
+	// RFC 6979 appendix A.2.7: P-521 with SHA-512.
+	v = findVector(t, suites, "ecdsa-p521-sha512: sign", `RFC 6979 key, "test"`)
+	checkHex(t, v, "expected", "013e99020abf5cee7525d16b69b229652ab6bdf2affcaef38773b4b7d08725f10cdb93482fdcc54edcee91eca4166b2a7c6265ef0ce2bd7051b7cef945babd47ee6d01fbd0013c674aa79cb39849527916ce301c66ea7ce8b80682786ad60f98f7e78a19ca69eff5c57400e3b3a0ad66ce0978214d13baf4e9ac60752f7b155e2de4dce3")
+}
+
+func TestEcdsaDer(t *testing.T) {
+	// A leading zero byte is added to a positive integer with the high bit
+	// set, and removed from an integer that starts with zero bytes.
+	der, err := ecdsaDer(mustHex("80000000" + "00000001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "300a02050080000000020101"; hex.EncodeToString(der) != want {
+		t.Errorf("got %x, want %s", der, want)
+	}
+}
@@ -0,0 +1,172 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// ECIES with X25519 or P-256 ECDH, HKDF-SHA256 and AES-256-GCM, for
+// specifying a hybrid encryption helper:
+//
+//  1. The sender computes the ECDH shared secret of a fresh ephemeral key
+//     pair and the recipient public key.
+//  2. HKDF-SHA256 derives 44 bytes from the shared secret, with the ephemeral
+//     public key followed by the recipient public key as the salt and the
+//     name of the scheme as the info. The first 32 bytes are the AES key and
+//     the last 12 bytes are the nonce. Every message has a new ephemeral key,
+//     so the nonce is never reused with the same key.
+//  3. The output is the ephemeral public key, the AES-GCM cipher text and the
+//     16-byte tag, without AAD.
+//
+// The Dart tests decrypt as the recipient. Only BrowserCryptography
+// implements Ecdh, so the P-256 suite runs on Chrome.
+
+const eciesBody = `
+final n = ephemeralPublicKey.length;
+expect(hexFromBytes(sealed.sublist(0, n)), hexFromBytes(ephemeralPublicKey));
+%s
+expect(
+  hexFromBytes(await sharedSecretKey.extractBytes()),
+  hexFromBytes(sharedSecret),
+);
+final hkdf = Hkdf(hmac: Hmac.sha256(), outputLength: 44);
+final derivedKey = await hkdf.deriveKey(
+  secretKey: sharedSecretKey,
+  nonce: [...ephemeralPublicKey, ...recipientPublicKey],
+  info: utf8.encode(info),
+);
+final derivedBytes = await derivedKey.extractBytes();
+expect(hexFromBytes(derivedBytes.sublist(0, 32)), hexFromBytes(key));
+expect(hexFromBytes(derivedBytes.sublist(32)), hexFromBytes(nonce));
+final decrypted = await AesGcm.with256bits().decrypt(
+  SecretBox(
+    sealed.sublist(n, sealed.length - 16),
+    nonce: nonce,
+    mac: Mac(sealed.sublist(sealed.length - 16)),
+  ),
+  secretKey: SecretKey(key),
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+const eciesX25519Dart = `final algorithm = X25519();
+final keyPair = await algorithm.newKeyPairFromSeed(recipientPrivateKey);
+final sharedSecretKey = await algorithm.sharedSecretKey(
+  keyPair: keyPair,
+  remotePublicKey: SimplePublicKey(
+    ephemeralPublicKey,
+    type: KeyPairType.x25519,
+  ),
+);`
+
+const eciesP256Dart = `final algorithm = Ecdh.p256(length: sharedSecret.length);
+final m = (recipientPublicKey.length - 1) ~/ 2;
+final keyPair = EcKeyPairData(
+  d: recipientPrivateKey,
+  x: recipientPublicKey.sublist(1, 1 + m),
+  y: recipientPublicKey.sublist(1 + m),
+  type: KeyPairType.p256,
+);
+final sharedSecretKey = await algorithm.sharedSecretKey(
+  keyPair: keyPair,
+  remotePublicKey: EcPublicKey(
+    x: ephemeralPublicKey.sublist(1, 1 + m),
+    y: ephemeralPublicKey.sublist(1 + m),
+    type: KeyPairType.p256,
+  ),
+);`
+
+// eciesSeal encrypts the clear text to the recipient with the ephemeral key
+// and returns the fields of the vector.
+func eciesSeal(info string, recipient, ephemeral *ecdh.PrivateKey, clearText []byte) ([]Field, error) {
+	sharedSecret, err := ephemeral.ECDH(recipient.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	ephemeralPublicKey := ephemeral.PublicKey().Bytes()
+	recipientPublicKey := recipient.PublicKey().Bytes()
+	derived := make([]byte, 44)
+	r := hkdf.New(sha256.New, sharedSecret, concat(ephemeralPublicKey, recipientPublicKey), []byte(info))
+	if _, err := io.ReadFull(r, derived); err != nil {
+		return nil, err
+	}
+	key, nonce := derived[:32], derived[32:]
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return []Field{
+		{"info", info},
+		{"recipientPrivateKey", recipient.Bytes()},
+		{"recipientPublicKey", recipientPublicKey},
+		{"ephemeralPrivateKey", ephemeral.Bytes()},
+		{"ephemeralPublicKey", ephemeralPublicKey},
+		{"sharedSecret", sharedSecret},
+		{"key", key},
+		{"nonce", nonce},
+		{"clearText", clearText},
+		{"sealed", concat(ephemeralPublicKey, gcm.Seal(nil, nonce, clearText, nil))},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("ecies", eciesSuites).
+		uses("bytes.go", "dart.go", "ecdh.go", "interop.go", "suite.go"))
+}
+
+func eciesSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, c := range []struct {
+		name, info, dart, testOn string
+		covers                   []string
+		curve                    ecdh.Curve
+		privateKey               func(start byte) []byte
+	}{
+		{"x25519", "ECIES-X25519-HKDF-SHA256-AES-256-GCM", eciesX25519Dart, "", []string{"X25519"}, ecdh.X25519(), func(start byte) []byte {
+			return sequence(start, 32)
+		}},
+		{"p256", "ECIES-P256-HKDF-SHA256-AES-256-GCM", eciesP256Dart, "chrome", []string{"Ecdh curve=p256"}, ecdh.P256(), nistCurves[0].sequenceScalar},
+	} {
+		s := &Suite{
+			Name:   "ecies: " + c.name + ", hkdf-sha256, aes-256-gcm",
+			Body:   fmt.Sprintf(eciesBody, c.dart),
+			TestOn: c.testOn,
+			Covers: append(c.covers, "Hkdf hmac=Hmac.sha256", "AesGcm secretKeyLength=32 nonceLength=12"),
+		}
+		recipient, err := c.curve.NewPrivateKey(c.privateKey(0x40))
+		if err != nil {
+			return nil, err
+		}
+		for i, start := range []byte{0x80, 0xc0} {
+			ephemeral, err := c.curve.NewPrivateKey(c.privateKey(start))
+			if err != nil {
+				return nil, err
+			}
+			for _, clearText := range interopMessages {
+				fields, err := eciesSeal(c.info, recipient, ephemeral, clearText)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", s.Name, err)
+				}
+				s.Vectors = append(s.Vectors, Vector{
+					Name:   fmt.Sprintf("ephemeral key %d, %s", i+1, describeBytes(clearText)),
+					Fields: fields,
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,66 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestEcies(t *testing.T) {
+	suites, err := eciesSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Decrypt as the recipient.
+	for _, c := range []struct {
+		suite string
+		curve ecdh.Curve
+	}{
+		{"ecies: x25519, hkdf-sha256, aes-256-gcm", ecdh.X25519()},
+		{"ecies: p256, hkdf-sha256, aes-256-gcm", ecdh.P256()},
+	} {
+		v := findVector(t, suites, c.suite, "ephemeral key 2, 13 bytes")
+		values := map[string][]byte{}
+		for _, f := range v.Fields {
+			if b, ok := f.Value.([]byte); ok {
+				values[f.Name] = b
+			}
+		}
+		recipient, err := c.curve.NewPrivateKey(values["recipientPrivateKey"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sealed := values["sealed"]
+		n := len(values["ephemeralPublicKey"])
+		ephemeralPublicKey, err := c.curve.NewPublicKey(sealed[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sharedSecret, err := recipient.ECDH(ephemeralPublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		derived, err := hkdf.Key(sha256.New, sharedSecret, concat(sealed[:n], recipient.PublicKey().Bytes()), v.Fields[0].Value.(string), 44)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, err := aes.NewCipher(derived[:32])
+		if err != nil {
+			t.Fatal(err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clearText, err := gcm.Open(nil, derived[32:], sealed[n:], nil)
+		if err != nil {
+			t.Fatalf("%s: %v", c.suite, err)
+		}
+		if string(clearText) != "Hello, World!" {
+			t.Errorf("%s: clear text is %q", c.suite, clearText)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// Ed25519 (RFC 8032). The private key is the 32-byte seed of Dart
+// newKeyPairFromSeed.
+
+const ed25519SignBody = `
+final algorithm = Ed25519();
+final keyPair = await algorithm.newKeyPairFromSeed(seed);
+final signature = await algorithm.sign(
+  message,
+  keyPair: keyPair,
+);
+expect(
+  hexFromBytes((signature.publicKey as SimplePublicKey).bytes),
+  hexFromBytes(publicKey),
+);
+expect(
+  hexFromBytes(signature.bytes),
+  hexFromBytes(expected),
+);
+expect(
+  await algorithm.verify(message, signature: signature),
+  isTrue,
+);
+`
+
+const ed25519PublicKeyBody = `
+final keyPair = await Ed25519().newKeyPairFromSeed(seed);
+final actual = await keyPair.extractPublicKey() as SimplePublicKey;
+expect(
+  hexFromBytes(actual.bytes),
+  hexFromBytes(publicKey),
+);
+`
+
+// Dart verify() throws ArgumentError for signatures that are not 64 bytes,
+// which also counts as rejection.
+const ed25519RejectBody = `
+var isValid = true;
+try {
+  isValid = await Ed25519().verify(
+    message,
+    signature: Signature(
+      signature,
+      publicKey: SimplePublicKey(publicKey, type: KeyPairType.ed25519),
+    ),
+  );
+} on ArgumentError {
+  isValid = false;
+}
+expect(isValid, isFalse);
+`
+
+// ed25519Order is the order L of the Ed25519 base point.
+var ed25519Order, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+// eddsaAddOrder returns an EdDSA signature R || S with S replaced by S+L,
+// which has the same value mod L but is not canonical. R and S have the same
+// length and are little-endian.
+func eddsaAddOrder(signature []byte, order *big.Int) []byte {
+	n := len(signature) / 2
+	s := make([]byte, n)
+	for i, b := range signature[n:] {
+		s[n-1-i] = b
+	}
+	sum := new(big.Int).Add(new(big.Int).SetBytes(s), order).FillBytes(make([]byte, n))
+	result := append([]byte(nil), signature[:n]...)
+	for i := range sum {
+		result = append(result, sum[n-1-i])
+	}
+	return result
+}
+
+// ed25519SignWithOptions signs with Ed25519ph if prehash is set and with
+// Ed25519ctx otherwise. Ed25519ph hashes the message with SHA-512 first.
+func ed25519SignWithOptions(privateKey ed25519.PrivateKey, message []byte, context string, prehash bool) ([]byte, error) {
+	options := &ed25519.Options{Context: context}
+	if prehash {
+		digest := sha512.Sum512(message)
+		message = digest[:]
+		options.Hash = crypto.SHA512
+	}
+	return privateKey.Sign(nil, message, options)
+}
+
+// ed25519Seeds returns seeds for the public key vectors: constant seeds and
+// byte sequences starting at different values.
+func ed25519Seeds() map[string][]byte {
+	seeds := map[string][]byte{
+		"all-zero seed": make([]byte, ed25519.SeedSize),
+		"all-0xff seed": bytes.Repeat([]byte{0xff}, ed25519.SeedSize),
+	}
+	for _, start := range []byte{0x00, 0x20, 0x40, 0x80, 0xc0, 0xe0} {
+		seed := sequence(start, ed25519.SeedSize)
+		seeds[fmt.Sprintf("seed %02x..%02x", seed[0], seed[len(seed)-1])] = seed
+	}
+	return seeds
+}
+
+func init() {
+	register(newGenerator("ed25519", ed25519Suites, spec.InputLengths, spec.Seed).
+		uses("bytes.go", "dart.go", "ed448.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func ed25519Suites() ([]*Suite, error) {
+	// The public key depends on the seed only through SHA-512 and scalar
+	// multiplication, so it is tested separately from signing.
+	publicKeys := &Suite{
+		Name:   "ed25519: public key from seed",
+		Body:   ed25519PublicKeyBody,
+		Covers: []string{"Ed25519"},
+	}
+	for name, seed := range ed25519Seeds() {
+		publicKeys.Vectors = append(publicKeys.Vectors, Vector{
+			Name: name,
+			Fields: []Field{
+				{"seed", seed},
+				{"publicKey", []byte(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))},
+			},
+		})
+	}
+
+	sign := &Suite{
+		Name:   "ed25519: sign",
+		Body:   ed25519SignBody,
+		Covers: []string{"Ed25519"},
+	}
+	seed := sequence(0x40, ed25519.SeedSize)
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	for _, n := range inputLengths(0, 1, 2, 3, 63, 64, 65, 1000) {
+		message := randomBytes(sign.Name+": message", n)
+		sign.Vectors = append(sign.Vectors, Vector{
+			Name: describeBytes(message),
+			Fields: []Field{
+				{"seed", seed},
+				{"publicKey", []byte(publicKey)},
+				{"message", message},
+				{"expected", ed25519.Sign(privateKey, message)},
+			},
+		})
+	}
+
+	// Every vector must be rejected. The message is signed with the key
+	// pair of the sign suite unless the name says otherwise.
+	reject := &Suite{
+		Name:   "ed25519: invalid signatures",
+		Body:   ed25519RejectBody,
+		Covers: []string{"Ed25519"},
+	}
+	message := sequence(0, 3)
+	signature := ed25519.Sign(privateKey, message)
+	otherPublicKey := ed25519.NewKeyFromSeed(sequence(0x80, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	flip := func(i int) []byte {
+		b := append([]byte(nil), signature...)
+		b[i] ^= 1
+		return b
+	}
+	for _, tc := range []struct {
+		name      string
+		publicKey []byte
+		message   []byte
+		signature []byte
+	}{
+		{"bit flipped in R", publicKey, message, flip(0)},
+		{"bit flipped in S", publicKey, message, flip(32)},
+		{"bit flipped in last byte", publicKey, message, flip(63)},
+		{"non-canonical S", publicKey, message, eddsaAddOrder(signature, ed25519Order)},
+		{"wrong public key", otherPublicKey, message, signature},
+		{"wrong message", publicKey, sequence(1, 3), signature},
+		{"truncated signature", publicKey, message, signature[:63]},
+		{"empty signature", publicKey, message, []byte{}},
+		{"signature with an extra byte", publicKey, message, append(append([]byte(nil), signature...), 0)},
+	} {
+		if len(tc.signature) == ed25519.SignatureSize && ed25519.Verify(tc.publicKey, tc.message, tc.signature) {
+			return nil, fmt.Errorf("ed25519: %s: signature is valid", tc.name)
+		}
+		reject.Vectors = append(reject.Vectors, Vector{
+			Name: tc.name,
+			Fields: []Field{
+				{"publicKey", tc.publicKey},
+				{"message", tc.message},
+				{"signature", tc.signature},
+			},
+		})
+	}
+
+	// Ed25519ph and Ed25519ctx (RFC 8032 section 5.1). Ed25519ctx requires
+	// a non-empty context.
+	const variantsSkip = "Ed25519ph and Ed25519ctx are not implemented in package:cryptography"
+	ph := &Suite{Name: "ed25519ph", Skip: variantsSkip}
+	ctx := &Suite{Name: "ed25519ctx", Skip: variantsSkip}
+	for _, context := range []string{"", "foo", string(sequence(0, 255))} {
+		for _, n := range []int{0, 3, 64, 1000} {
+			message := randomBytes(ph.Name+": message", n)
+			name := fmt.Sprintf("%d-byte context, %s", len(context), describeBytes(message))
+			for _, variant := range []struct {
+				suite   *Suite
+				prehash bool
+			}{
+				{ph, true},
+				{ctx, false},
+			} {
+				if context == "" && !variant.prehash {
+					continue
+				}
+				signature, err := ed25519SignWithOptions(privateKey, message, context, variant.prehash)
+				if err != nil {
+					return nil, err
+				}
+				variant.suite.Vectors = append(variant.suite.Vectors, Vector{
+					Name: name,
+					Fields: []Field{
+						{"seed", seed},
+						{"publicKey", []byte(publicKey)},
+						{"context", []byte(context)},
+						{"message", message},
+						{"expected", signature},
+					},
+				})
+			}
+		}
+	}
+	return []*Suite{publicKeys, sign, reject, ph, ctx}, nil
+}
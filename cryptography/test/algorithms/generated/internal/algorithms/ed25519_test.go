@@ -0,0 +1,61 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+func TestEddsaAddOrder(t *testing.T) {
+	// S+L must reduce to S, so only its encoding is invalid.
+	le := func(b []byte) *big.Int {
+		r := make([]byte, len(b))
+		for i := range b {
+			r[len(b)-1-i] = b[i]
+		}
+		return new(big.Int).SetBytes(r)
+	}
+	for _, tc := range []struct {
+		name      string
+		signature []byte
+		order     *big.Int
+	}{
+		{"ed25519", ed25519.Sign(ed25519.NewKeyFromSeed(sequence(0, ed25519.SeedSize)), nil), ed25519Order},
+		{"ed448", ed448.Sign(ed448.NewKeyFromSeed(sequence(0, ed448.SeedSize)), nil, ""), ed448Order},
+	} {
+		n := len(tc.signature) / 2
+		got := eddsaAddOrder(tc.signature, tc.order)
+		if !bytes.Equal(got[:n], tc.signature[:n]) {
+			t.Fatalf("%s: R changed", tc.name)
+		}
+		s, sl := le(tc.signature[n:]), le(got[n:])
+		if new(big.Int).Sub(sl, s).Cmp(tc.order) != 0 {
+			t.Errorf("%s: got S = %v, want %v + L", tc.name, sl, s)
+		}
+	}
+}
+
+func TestEd25519SignWithOptions(t *testing.T) {
+	// RFC 8032 section 7.3: Ed25519ph of "abc".
+	privateKey := ed25519.NewKeyFromSeed(mustHex("833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42"))
+	got, err := ed25519SignWithOptions(privateKey, []byte("abc"), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406"; hex.EncodeToString(got) != want {
+		t.Errorf("Ed25519ph: got %x, want %s", got, want)
+	}
+	// RFC 8032 section 7.2: Ed25519ctx with context "foo".
+	privateKey = ed25519.NewKeyFromSeed(mustHex("0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6"))
+	got, err = ed25519SignWithOptions(privateKey, mustHex("f726936d19c800494e3fdaff20b276a8"), "foo", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d"; hex.EncodeToString(got) != want {
+		t.Errorf("Ed25519ctx: got %x, want %s", got, want)
+	}
+}
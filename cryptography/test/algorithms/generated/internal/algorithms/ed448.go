@@ -0,0 +1,113 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// Ed448 (RFC 8032), with the same suites as Ed25519. The private key is the
+// 57-byte seed and every signature has a context, which is empty by default.
+// Ed448ph hashes the message with SHAKE256 first.
+
+const ed448Skip = "Ed448 is not implemented in package:cryptography"
+
+// ed448Order is the order L of the Ed448 base point.
+var ed448Order, _ = new(big.Int).SetString("181709681073901722637330951972001133588410340171829515070372549795146003961539585716195755291692375963310293709091662304773755859649779", 10)
+
+func init() {
+	register(newGenerator("ed448", ed448Suites, spec.InputLengths, spec.Seed).
+		uses("bytes.go", "dart.go", "ed25519.go", "prng.go", "spec.go", "suite.go"))
+}
+
+func ed448Suites() ([]*Suite, error) {
+	publicKeys := &Suite{Name: "ed448: public key from seed", Skip: ed448Skip}
+	for _, start := range []byte{0x00, 0x20, 0x40, 0x80, 0xc0, 0xe0} {
+		seed := sequence(start, ed448.SeedSize)
+		publicKeys.Vectors = append(publicKeys.Vectors, Vector{
+			Name: fmt.Sprintf("seed %02x..%02x", seed[0], seed[len(seed)-1]),
+			Fields: []Field{
+				{"seed", seed},
+				{"publicKey", []byte(ed448.NewKeyFromSeed(seed).Public().(ed448.PublicKey))},
+			},
+		})
+	}
+
+	seed := sequence(0x40, ed448.SeedSize)
+	privateKey := ed448.NewKeyFromSeed(seed)
+	publicKey := privateKey.Public().(ed448.PublicKey)
+	sign := &Suite{Name: "ed448: sign", Skip: ed448Skip}
+	ph := &Suite{Name: "ed448ph", Skip: ed448Skip}
+	for _, context := range []string{"", "foo", string(sequence(0, 255))} {
+		for _, n := range inputLengths(0, 1, 2, 3, 63, 64, 65, 1000) {
+			message := randomBytes(sign.Name+": message", n)
+			name := fmt.Sprintf("%d-byte context, %s", len(context), describeBytes(message))
+			sign.Vectors = append(sign.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"seed", seed},
+					{"publicKey", []byte(publicKey)},
+					{"context", []byte(context)},
+					{"message", message},
+					{"expected", ed448.Sign(privateKey, message, context)},
+				},
+			})
+			ph.Vectors = append(ph.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"seed", seed},
+					{"publicKey", []byte(publicKey)},
+					{"context", []byte(context)},
+					{"message", message},
+					{"expected", ed448.SignPh(privateKey, message, context)},
+				},
+			})
+		}
+	}
+
+	// Every vector must be rejected. The message is signed with the key
+	// pair of the sign suite unless the name says otherwise.
+	reject := &Suite{Name: "ed448: invalid signatures", Skip: ed448Skip}
+	message := sequence(0, 3)
+	signature := ed448.Sign(privateKey, message, "")
+	otherPublicKey := ed448.NewKeyFromSeed(sequence(0x80, ed448.SeedSize)).Public().(ed448.PublicKey)
+	flip := func(i int) []byte {
+		b := append([]byte(nil), signature...)
+		b[i] ^= 1
+		return b
+	}
+	for _, tc := range []struct {
+		name      string
+		publicKey []byte
+		message   []byte
+		context   string
+		signature []byte
+	}{
+		{"bit flipped in R", publicKey, message, "", flip(0)},
+		{"bit flipped in S", publicKey, message, "", flip(ed448.SignatureSize / 2)},
+		{"bit flipped in last byte", publicKey, message, "", flip(ed448.SignatureSize - 1)},
+		{"non-canonical S", publicKey, message, "", eddsaAddOrder(signature, ed448Order)},
+		{"wrong public key", otherPublicKey, message, "", signature},
+		{"wrong message", publicKey, sequence(1, 3), "", signature},
+		{"wrong context", publicKey, message, "foo", signature},
+		{"truncated signature", publicKey, message, "", signature[:ed448.SignatureSize-1]},
+		{"empty signature", publicKey, message, "", []byte{}},
+		{"signature with an extra byte", publicKey, message, "", append(append([]byte(nil), signature...), 0)},
+	} {
+		if ed448.Verify(tc.publicKey, tc.message, tc.signature, tc.context) {
+			return nil, fmt.Errorf("ed448: %s: signature is valid", tc.name)
+		}
+		reject.Vectors = append(reject.Vectors, Vector{
+			Name: tc.name,
+			Fields: []Field{
+				{"publicKey", tc.publicKey},
+				{"context", []byte(tc.context)},
+				{"message", tc.message},
+				{"signature", tc.signature},
+			},
+		})
+	}
+	return []*Suite{publicKeys, sign, ph, reject}, nil
+}
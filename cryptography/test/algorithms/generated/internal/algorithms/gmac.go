@@ -0,0 +1,112 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// GMAC, which is AES-GCM with an empty clear text, so the tag only
+// authenticates the AAD. Vectors have the hash key H and the GHASH output
+// too, so an implementation can find whether GHASH or the encryption of J0 is
+// wrong. The generator checks that E(K, J0) XOR GHASH is the tag of Go.
+
+const gmacBody = `
+final algorithm = AesGcm.with%dbits();
+final secretBox = await algorithm.encrypt(
+  <int>[],
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  aad: aad,
+);
+expect(secretBox.cipherText, isEmpty);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(mac),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(<int>[], nonce: nonce, mac: Mac(mac)),
+  secretKey: SecretKey(secretKey),
+  aad: aad,
+);
+expect(decrypted, isEmpty);
+`
+
+// gmac returns the hash key, the GHASH of the AAD and the tag.
+func gmac(key, nonce, aad []byte) (h, s, tag []byte, err error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	h = make([]byte, 16)
+	block.Encrypt(h, h)
+	lengths := make([]byte, 16)
+	binary.BigEndian.PutUint64(lengths, uint64(8*len(aad)))
+	s = ghash(h, concat(aad, make([]byte, (16-len(aad)%16)%16), lengths))
+
+	tag = make([]byte, 16)
+	block.Encrypt(tag, gcmCounterBlock(block, nonce))
+	for i := range tag {
+		tag[i] ^= s[i]
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if want := gcm.Seal(nil, nonce, nil, aad); !bytes.Equal(tag, want) {
+		return nil, nil, nil, fmt.Errorf("gmac: tag %x, crypto/cipher has %x", tag, want)
+	}
+	return h, s, tag, nil
+}
+
+func init() {
+	register(newGenerator("gmac", gmacSuites, spec.AesKeyLengths).
+		uses("aes_gcm.go", "bytes.go", "dart.go", "spec.go", "suite.go"))
+}
+
+func gmacSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, keyLength := range aesKeyLengths() {
+		s := &Suite{
+			Name:   fmt.Sprintf("gmac: %d-bit key", 8*keyLength),
+			Body:   fmt.Sprintf(gmacBody, 8*keyLength),
+			Covers: []string{fmt.Sprintf("AesGcm secretKeyLength=%d nonceLength=12", keyLength)},
+		}
+		add := func(name string, key, nonce, aad []byte) error {
+			h, ghash, tag, err := gmac(key, nonce, aad)
+			if err != nil {
+				return err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"aad", aad},
+					{"hashKey", h},
+					{"ghash", ghash},
+					{"mac", tag},
+				},
+			})
+			return nil
+		}
+		if keyLength == 16 {
+			// Test case 1 of the GCM specification, where GHASH is zero.
+			if err := add("GCM specification test case 1", make([]byte, 16), make([]byte, 12), nil); err != nil {
+				return nil, err
+			}
+		}
+		for _, n := range []int{1, 15, 16, 17, 20, 32, 64, 100} {
+			aad := sequence(0xa0, n)
+			if err := add(describeBytes(aad)+" of AAD", sequence(0, keyLength), sequence(0x80, 12), aad); err != nil {
+				return nil, err
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
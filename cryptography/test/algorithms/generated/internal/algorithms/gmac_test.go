@@ -0,0 +1,17 @@
+package algorithms
+
+import (
+	"testing"
+)
+
+func TestGmac(t *testing.T) {
+	suites, err := gmacSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "gmac: 128-bit key", "GCM specification test case 1")
+	checkHex(t, v, "hashKey", "66e94bd4ef8a2c3b884cfa59ca342b2e")
+	checkHex(t, v, "ghash", "00000000000000000000000000000000")
+	checkHex(t, v, "mac", "58e2fccefa7e3061367f1d57a4e7455a")
+	findVector(t, suites, "gmac: 256-bit key", "17 bytes of AAD")
+}
@@ -0,0 +1,166 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/sha3"
+)
+
+// Hash functions and HMAC. Input lengths are around the padding boundaries of
+// the block size: a message of blockSize-9 bytes is the longest that fits in
+// one padded MD5, SHA-1 or SHA-2 block. For SHA-3, the block size is the rate
+// and blockSize-1 bytes is the longest message that fits in one padded block.
+
+// hashAlgorithm is a hash function of the hash and HMAC suites.
+type hashAlgorithm struct {
+	// name is the prefix of the suite names.
+	name string
+
+	// dart is the Dart class, which is also the name in algorithms.json. It
+	// is empty for skipped hashes.
+	dart string
+
+	// skip is set when package:cryptography does not implement the hash.
+	skip string
+
+	new func() hash.Hash
+}
+
+var hashAlgorithms = []hashAlgorithm{
+	{name: "md5", skip: "MD5 is not implemented in package:cryptography", new: md5.New},
+	{name: "sha-1", dart: "Sha1", new: sha1.New},
+	{name: "sha-224", dart: "Sha224", new: sha256.New224},
+	{name: "sha-256", dart: "Sha256", new: sha256.New},
+	{name: "sha-384", dart: "Sha384", new: sha512.New384},
+	{name: "sha-512", dart: "Sha512", new: sha512.New},
+	{name: "sha-512/224", skip: "SHA-512/224 is not implemented in package:cryptography", new: sha512.New512_224},
+	{name: "sha-512/256", skip: "SHA-512/256 is not implemented in package:cryptography", new: sha512.New512_256},
+	{name: "sha3-224", skip: sha3Skip, new: sha3.New224},
+	{name: "sha3-256", skip: sha3Skip, new: sha3.New256},
+	{name: "sha3-384", skip: sha3Skip, new: sha3.New384},
+	{name: "sha3-512", skip: sha3Skip, new: sha3.New512},
+}
+
+const sha3Skip = "SHA-3 is not implemented in package:cryptography"
+
+const hmacEmptyKeySkip = "Hmac in package:cryptography rejects empty keys"
+
+const hashBody = `
+final hash = await %s().hash(data);
+expect(
+  hexFromBytes(hash.bytes),
+  hexFromBytes(expected),
+);
+`
+
+const hmacBody = `
+final mac = await Hmac(%s()).calculateMac(
+  data,
+  secretKey: SecretKey(secretKey),
+);
+expect(
+  hexFromBytes(mac.bytes),
+  hexFromBytes(expected),
+);
+`
+
+// blockBoundaryLengths returns input lengths around the padding boundaries.
+func blockBoundaryLengths(blockSize int) []int {
+	return []int{
+		0, 1,
+		blockSize - 9, blockSize - 8, blockSize - 7,
+		blockSize - 1, blockSize, blockSize + 1,
+		2*blockSize - 9, 2*blockSize - 8, 2 * blockSize,
+	}
+}
+
+func init() {
+	register(newGenerator("hash", hashSuites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func hashSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, a := range hashAlgorithms {
+		blockSize := a.new().BlockSize()
+		hashSuite := &Suite{Name: a.name, Skip: a.skip}
+		hmacSuite := &Suite{Name: "hmac-" + a.name, Skip: a.skip}
+		// Skipped suites have no body because the Dart class does not exist.
+		if a.skip == "" {
+			hashSuite.Body = fmt.Sprintf(hashBody, a.dart)
+			hashSuite.Covers = []string{a.dart}
+			hmacSuite.Body = fmt.Sprintf(hmacBody, a.dart)
+			hmacSuite.Covers = []string{"Hmac hashAlgorithm=" + a.dart}
+		}
+		for _, n := range blockBoundaryLengths(blockSize) {
+			data := randomBytes(hashSuite.Name+": data", n)
+			h := a.new()
+			h.Write(data)
+			hashSuite.Vectors = append(hashSuite.Vectors, Vector{
+				Name: describeBytes(data),
+				Fields: []Field{
+					{"data", data},
+					{"expected", h.Sum(nil)},
+				},
+			})
+		}
+		for _, keyLength := range hmacKeyLengths(blockSize) {
+			hmacSuite.Vectors = append(hmacSuite.Vectors, hmacVectors(a, keyLength)...)
+		}
+		suites = append(suites, hashSuite, hmacSuite, hmacEmptyKeySuite(a))
+	}
+	return suites, nil
+}
+
+// hmacKeyLengths returns key lengths shorter than, equal to and longer than
+// the block size. Longer keys are hashed first. 200 bytes is longer than the
+// block size of every hash.
+func hmacKeyLengths(blockSize int) []int {
+	return []int{1, 20, blockSize - 1, blockSize, blockSize + 1, 200}
+}
+
+// hmacEmptyKeySuite returns HMAC vectors with an empty key. The suite is
+// skipped because Hmac rejects empty keys, but it has a body so the tests
+// check the MACs once Hmac accepts them.
+func hmacEmptyKeySuite(a hashAlgorithm) *Suite {
+	s := &Suite{
+		Name:    "hmac-" + a.name + ": empty key",
+		Skip:    a.skip,
+		Vectors: hmacVectors(a, 0),
+	}
+	if a.skip == "" {
+		s.Body = fmt.Sprintf(hmacBody, a.dart)
+		s.Skip = hmacEmptyKeySkip
+		s.Covers = []string{"Hmac hashAlgorithm=" + a.dart}
+	}
+	return s
+}
+
+// hmacVectors returns HMAC vectors with a key of the length and data lengths
+// around the block size.
+func hmacVectors(a hashAlgorithm, keyLength int) []Vector {
+	blockSize := a.new().BlockSize()
+	var vectors []Vector
+	for _, n := range []int{0, 1, blockSize - 9, blockSize + 1} {
+		key := sequence(0, keyLength)
+		data := randomBytes("hmac-"+a.name+": data", n)
+		mac := hmac.New(a.new, key)
+		mac.Write(data)
+		vectors = append(vectors, Vector{
+			Name: fmt.Sprintf("%d-byte key, %s", keyLength, describeBytes(data)),
+			Fields: []Field{
+				{"secretKey", key},
+				{"data", data},
+				{"expected", mac.Sum(nil)},
+			},
+		})
+	}
+	return vectors
+}
@@ -0,0 +1,43 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	suites, err := hashSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 1321 and FIPS 180 hashes of the empty message.
+	for _, tc := range []struct {
+		suite, want string
+	}{
+		{"md5", "d41d8cd98f00b204e9800998ecf8427e"},
+		{"sha-1", "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+		{"sha-224", "d14a028c2a3a2bc9476102bb288234c415a2b01f828ea62ac5b3e42f"},
+		{"sha-256", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"sha-512/224", "6ed0dd02806fa89e25de060c19d3ac86cabb87d6a0ddd05c333b84f4"},
+		{"sha-512/256", "c672b8d1ef56ed28ab87c3622c5114069bdd3ad7b8f9737498d0c01ecef0967a"},
+		{"sha3-256", "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+	} {
+		checkHex(t, findVector(t, suites, tc.suite, "0 bytes"), "expected", tc.want)
+	}
+	// HMAC-SHA1 with an empty key and an empty message. The suite is skipped
+	// but still checks the MACs.
+	for _, s := range suites {
+		if s.Name == "hmac-sha-1: empty key" && s.Body == "" {
+			t.Errorf("%s: no body", s.Name)
+		}
+	}
+	v := findVector(t, suites, "hmac-sha-1: empty key", "0-byte key, 0 bytes")
+	checkHex(t, v, "expected", "fbdb1d1b18aa6c08324b7d64b71fb76370690e1d")
+	// A key longer than the block size is replaced by its hash.
+	key := sha256.Sum256(sequence(0, 200))
+	mac := hmac.New(sha256.New, key[:])
+	v = findVector(t, suites, "hmac-sha-256", "200-byte key, 0 bytes")
+	checkHex(t, v, "expected", hex.EncodeToString(mac.Sum(nil)))
+}
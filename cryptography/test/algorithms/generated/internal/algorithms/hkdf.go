@@ -0,0 +1,261 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF (RFC 5869). An empty salt is the same as HashLen zero bytes. The
+// output is at most 255 blocks because the block counter is one byte.
+
+const hkdfBody = `
+final hkdf = Hkdf(hmac: %s, outputLength: expected.length);
+final derivedKey = await hkdf.deriveKey(
+  secretKey: SecretKey(secretKey),
+  nonce: salt,
+  info: info,
+);
+expect(
+  hexFromBytes(await derivedKey.extractBytes()),
+  hexFromBytes(expected),
+);
+`
+
+// The tests of kdfStrings encode the inputs with utf8.encode.
+const hkdfStringBody = `
+final secretKeyBytes = utf8.encode(secretKey);
+final saltBytes = utf8.encode(salt);
+final infoBytes = utf8.encode(info);
+expect(hexFromBytes(secretKeyBytes), hexFromBytes(secretKeyUtf8));
+expect(hexFromBytes(saltBytes), hexFromBytes(saltUtf8));
+expect(hexFromBytes(infoBytes), hexFromBytes(infoUtf8));
+final hkdf = Hkdf(hmac: %s, outputLength: expected.length);
+final derivedKey = await hkdf.deriveKey(
+  secretKey: SecretKey(secretKeyBytes),
+  nonce: saltBytes,
+  info: infoBytes,
+);
+expect(
+  hexFromBytes(await derivedKey.extractBytes()),
+  hexFromBytes(expected),
+);
+`
+
+// Deriving more than 255 blocks must fail instead of wrapping the counter.
+const hkdfTooLongBody = `
+await expectLater(
+  () async {
+    final hkdf = Hkdf(hmac: %s, outputLength: outputLength);
+    await hkdf.deriveKey(
+      secretKey: SecretKey(secretKey),
+      nonce: salt,
+      info: info,
+    );
+  },
+  throwsArgumentError,
+);
+`
+
+const hkdfTooLongSkip = "Hkdf in package:cryptography does not limit outputLength to 255 blocks"
+
+// Hkdf uses the salt as the HMAC key and Hmac rejects empty keys.
+const hkdfEmptySaltSkip = "Hkdf in package:cryptography rejects empty salts"
+
+// kdfHmac is the HMAC of HKDF and PBKDF2 suites.
+type kdfHmac struct {
+	// name is the suffix of the suite names.
+	name string
+
+	// hmac is a Dart expression for the Hmac.
+	hmac string
+
+	// covers is the HMAC parameter value in algorithms.json. It is empty for
+	// hashes that are not listed.
+	covers string
+
+	new func() hash.Hash
+}
+
+var hkdfHashes = []kdfHmac{
+	{"sha256", "Hmac.sha256()", "Hmac.sha256", sha256.New},
+	{"sha384", "Hmac(Sha384())", "", sha512.New384},
+	{"sha512", "Hmac.sha512()", "Hmac.sha512", sha512.New},
+}
+
+// hkdfDerive returns the HKDF output for the inputs.
+func hkdfDerive(h kdfHmac, secret, salt, info []byte, length int) ([]byte, error) {
+	expected := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(h.new, secret, salt, info), expected); err != nil {
+		return nil, err
+	}
+	return expected, nil
+}
+
+// hkdfVector returns a vector for the inputs.
+func hkdfVector(h kdfHmac, secret, salt, info []byte, length int) (Vector, error) {
+	expected, err := hkdfDerive(h, secret, salt, info, length)
+	if err != nil {
+		return Vector{}, err
+	}
+	return Vector{
+		Name: fmt.Sprintf("%d-byte salt, %d-byte info, %d-byte output", len(salt), len(info), length),
+		Fields: []Field{
+			{"secretKey", secret},
+			{"salt", salt},
+			{"info", info},
+			{"expected", expected},
+		},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("hkdf", hkdfSuites).
+		uses("bytes.go", "pbkdf2.go", "suite.go"))
+}
+
+func hkdfSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, h := range hkdfHashes {
+		s := &Suite{
+			Name: "hkdf-" + h.name,
+			Body: fmt.Sprintf(hkdfBody, h.hmac),
+		}
+		emptySalt := &Suite{
+			Name: "hkdf-" + h.name + ": empty salt",
+			Body: s.Body,
+			Skip: hkdfEmptySaltSkip,
+		}
+		if h.covers != "" {
+			s.Covers = []string{"Hkdf hmac=" + h.covers}
+			emptySalt.Covers = s.Covers
+		}
+		hashLength := h.new().Size()
+		secret := sequence(0, 22)
+		type input struct {
+			salt, info []byte
+			length     int
+		}
+		var inputs []input
+		// Output lengths up to half of the maximum with a fixed salt and
+		// info. The grid below has the hash length.
+		for _, length := range []int{1, hashLength - 1, hashLength + 1, 255 * hashLength / 4, 255 * hashLength / 2} {
+			inputs = append(inputs, input{sequence(0x40, 13), sequence(0x80, 10), length})
+		}
+		// Salts shorter than, equal to and longer than the hash length.
+		for _, saltLength := range []int{0, 13, hashLength, 80} {
+			for _, infoLength := range []int{0, 10, 80} {
+				inputs = append(inputs, input{sequence(0x40, saltLength), sequence(0x80, infoLength), hashLength})
+			}
+		}
+		for _, in := range inputs {
+			v, err := hkdfVector(h, secret, in.salt, in.info, in.length)
+			if err != nil {
+				return nil, err
+			}
+			if len(in.salt) == 0 {
+				emptySalt.Vectors = append(emptySalt.Vectors, v)
+			} else {
+				s.Vectors = append(s.Vectors, v)
+			}
+		}
+
+		// The last blocks before the limit. The Dart loop computes one
+		// block more than it needs when the length is a multiple of the
+		// hash length, which is block 256 at the limit.
+		maximum := &Suite{
+			Name:   "hkdf-" + h.name + ": maximum output length",
+			Body:   s.Body,
+			Covers: s.Covers,
+		}
+		for _, length := range []int{254 * hashLength, 254*hashLength + 1, 255*hashLength - 1, 255 * hashLength} {
+			v, err := hkdfVector(h, secret, sequence(0x40, 13), sequence(0x80, 10), length)
+			if err != nil {
+				return nil, err
+			}
+			maximum.Vectors = append(maximum.Vectors, v)
+		}
+
+		tooLong := &Suite{
+			Name:   "hkdf-" + h.name + ": output length too long",
+			Body:   fmt.Sprintf(hkdfTooLongBody, h.hmac),
+			Skip:   hkdfTooLongSkip,
+			Covers: s.Covers,
+		}
+		length := 255*hashLength + 1
+		if _, err := hkdfVector(h, secret, sequence(0x40, 13), sequence(0x80, 10), length); err == nil {
+			return nil, fmt.Errorf("hkdf-%s: %d-byte output did not fail", h.name, length)
+		}
+		tooLong.Vectors = append(tooLong.Vectors, Vector{
+			Name: fmt.Sprintf("%d-byte output", length),
+			Fields: []Field{
+				{"secretKey", secret},
+				{"salt", sequence(0x40, 13)},
+				{"info", sequence(0x80, 10)},
+				{"outputLength", length},
+			},
+		})
+
+		text := &Suite{
+			Name:   "hkdf-" + h.name + ": utf-8 inputs",
+			Body:   fmt.Sprintf(hkdfStringBody, h.hmac),
+			Covers: s.Covers,
+		}
+		binary := &Suite{
+			Name:   "hkdf-" + h.name + ": binary inputs",
+			Body:   s.Body,
+			Covers: s.Covers,
+		}
+		for _, input := range kdfStrings {
+			for _, tc := range []struct {
+				name               string
+				secret, salt, info string
+			}{
+				{fmt.Sprintf("secret key %+q", input), input, "salt", "info"},
+				{fmt.Sprintf("salt %+q", input), "secret", input, "info"},
+				{fmt.Sprintf("info %+q", input), "secret", "salt", input},
+			} {
+				expected, err := hkdfDerive(h, []byte(tc.secret), []byte(tc.salt), []byte(tc.info), hashLength)
+				if err != nil {
+					return nil, err
+				}
+				v := Vector{
+					Name: tc.name,
+					Fields: []Field{
+						{"secretKey", tc.secret},
+						{"salt", tc.salt},
+						{"info", tc.info},
+						{"secretKeyUtf8", []byte(tc.secret)},
+						{"saltUtf8", []byte(tc.salt)},
+						{"infoUtf8", []byte(tc.info)},
+						{"expected", expected},
+					},
+				}
+				text.Vectors = append(text.Vectors, v)
+			}
+		}
+		for _, input := range kdfBinaryInputs {
+			for _, tc := range []struct {
+				name               string
+				secret, salt, info []byte
+			}{
+				{fmt.Sprintf("secret key %x", input), input, sequence(0x40, 13), nil},
+				{fmt.Sprintf("salt %x", input), secret, input, nil},
+				{fmt.Sprintf("info %x", input), secret, sequence(0x40, 13), input},
+			} {
+				v, err := hkdfVector(h, tc.secret, tc.salt, tc.info, hashLength)
+				if err != nil {
+					return nil, err
+				}
+				v.Name = tc.name
+				binary.Vectors = append(binary.Vectors, v)
+			}
+		}
+		suites = append(suites, s, emptySalt, maximum, tooLong, text, binary)
+	}
+	return suites, nil
+}
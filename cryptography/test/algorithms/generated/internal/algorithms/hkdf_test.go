@@ -0,0 +1,36 @@
+package algorithms
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHkdf(t *testing.T) {
+	// RFC 5869 test case 1.
+	v, err := hkdfVector(hkdfHashes[0], bytes.Repeat([]byte{0x0b}, 22), sequence(0, 13), sequence(0xf0, 10), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "expected", "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+
+	suites, err := hkdfSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	findVector(t, suites, "hkdf-sha512", "13-byte salt, 10-byte info, 8160-byte output")
+	findVector(t, suites, "hkdf-sha384: empty salt", "0-byte salt, 80-byte info, 48-byte output")
+}
+
+func TestHkdfMaximumOutputLength(t *testing.T) {
+	suites, err := hkdfSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "hkdf-sha256: maximum output length", "13-byte salt, 10-byte info, 8160-byte output")
+	short := findVector(t, suites, "hkdf-sha256", "13-byte salt, 10-byte info, 4080-byte output")
+	// HKDF output is a prefix of longer output with the same inputs.
+	if !bytes.Equal(v.Fields[3].Value.([]byte)[:4080], short.Fields[3].Value.([]byte)) {
+		t.Error("8160-byte output does not start with the 4080-byte output")
+	}
+	findVector(t, suites, "hkdf-sha512: output length too long", "16321-byte output")
+}
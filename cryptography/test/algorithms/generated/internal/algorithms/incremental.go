@@ -0,0 +1,210 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"sort"
+)
+
+// CachePath stores the suites of every generator together with a hash of
+// its inputs, so unchanged generators are not run again. It is written to
+// the output directory and is not committed.
+const CachePath = "generated_cache.json"
+
+// embedded are the Go files of this package. The hash of a generator has
+// its files (see Params). The other packages of the module do not change
+// suites: the options of spec files are in Params, and emit and
+// cmd/vectorgen only write the suites.
+//
+//go:embed *.go
+var embedded embed.FS
+
+// sources are the files that generatorHash reads. Tests replace them.
+var sources fs.FS = embedded
+
+// Cache is the content of CachePath.
+type Cache struct {
+	Generators map[string]cachedGenerator `json:"generators"`
+}
+
+type cachedGenerator struct {
+	// Hash is the hash of the generator inputs (see generatorHash).
+	Hash   string        `json:"hash"`
+	Suites []cachedSuite `json:"suites"`
+}
+
+type cachedSuite struct {
+	Name    string         `json:"name"`
+	Body    string         `json:"body,omitempty"`
+	Skip    string         `json:"skip,omitempty"`
+	TestOn  string         `json:"testOn,omitempty"`
+	Covers  []string       `json:"covers,omitempty"`
+	Vectors []cachedVector `json:"vectors"`
+}
+
+type cachedVector struct {
+	Name   string        `json:"name"`
+	Cost   int           `json:"cost,omitempty"`
+	Fields []cachedField `json:"fields"`
+}
+
+// cachedField keeps the Go type of a field value, which the JSON output of
+// "-emit data" does not.
+type cachedField struct {
+	Name    string    `json:"name"`
+	Bytes   *string   `json:"bytes,omitempty"`
+	String  *string   `json:"string,omitempty"`
+	Strings *[]string `json:"strings,omitempty"`
+	Int     *int      `json:"int,omitempty"`
+	Ints    *[]int    `json:"ints,omitempty"`
+	Bool    *bool     `json:"bool,omitempty"`
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{Generators: map[string]cachedGenerator{}}
+}
+
+// ReadCache reads CachePath in the directory. A missing file is an empty
+// cache.
+func ReadCache(dir string) (*Cache, error) {
+	path := filepath.Join(dir, CachePath)
+	cache := NewCache()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if cache.Generators == nil {
+		cache.Generators = map[string]cachedGenerator{}
+	}
+	return cache, nil
+}
+
+// Write writes the cache as the content of CachePath.
+func (c *Cache) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(c)
+}
+
+// generatorHash hashes what the output of a generator depends on: its
+// parameters, its Go files and the versions of the modules it is built
+// with.
+func generatorHash(g Generator) (string, error) {
+	p := g.Params()
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%q\n", g.Name(), p.Flags)
+	files := slices.Clone(p.Files)
+	sort.Strings(files)
+	for _, name := range slices.Compact(files) {
+		data, err := fs.ReadFile(sources, name)
+		if err != nil {
+			return "", fmt.Errorf("generator %s: %w", g.Name(), err)
+		}
+		fmt.Fprintf(h, "%s\n%d\n", name, len(data))
+		h.Write(data)
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		deps := make([]string, 0, len(info.Deps))
+		for _, dep := range info.Deps {
+			deps = append(deps, dep.Path+"@"+dep.Version)
+		}
+		sort.Strings(deps)
+		fmt.Fprintf(h, "%s\n%q\n", info.GoVersion, deps)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func suitesToCache(suites []*Suite) ([]cachedSuite, error) {
+	result := make([]cachedSuite, 0, len(suites))
+	for _, s := range suites {
+		cs := cachedSuite{Name: s.Name, Body: s.Body, Skip: s.Skip, TestOn: s.TestOn, Covers: s.Covers}
+		for _, v := range s.Vectors {
+			cv := cachedVector{Name: v.Name, Cost: v.Cost}
+			for _, f := range v.Fields {
+				cf := cachedField{Name: f.Name}
+				switch value := f.Value.(type) {
+				case []byte:
+					h := hex.EncodeToString(value)
+					cf.Bytes = &h
+				case string:
+					cf.String = &value
+				case []string:
+					if value == nil {
+						value = []string{}
+					}
+					cf.Strings = &value
+				case int:
+					cf.Int = &value
+				case []int:
+					if value == nil {
+						value = []int{}
+					}
+					cf.Ints = &value
+				case bool:
+					cf.Bool = &value
+				default:
+					return nil, fmt.Errorf("%s: %s: %s: unsupported field type %T", s.Name, v.Name, f.Name, f.Value)
+				}
+				cv.Fields = append(cv.Fields, cf)
+			}
+			cs.Vectors = append(cs.Vectors, cv)
+		}
+		result = append(result, cs)
+	}
+	return result, nil
+}
+
+func suitesFromCache(cached []cachedSuite) ([]*Suite, error) {
+	result := make([]*Suite, 0, len(cached))
+	for _, cs := range cached {
+		s := &Suite{Name: cs.Name, Body: cs.Body, Skip: cs.Skip, TestOn: cs.TestOn, Covers: cs.Covers}
+		for _, cv := range cs.Vectors {
+			v := Vector{Name: cv.Name, Cost: cv.Cost}
+			for _, cf := range cv.Fields {
+				f := Field{Name: cf.Name}
+				switch {
+				case cf.Bytes != nil:
+					b, err := hex.DecodeString(*cf.Bytes)
+					if err != nil {
+						return nil, fmt.Errorf("%s: %s: %s: %w", cs.Name, cv.Name, cf.Name, err)
+					}
+					f.Value = b
+				case cf.String != nil:
+					f.Value = *cf.String
+				case cf.Strings != nil:
+					f.Value = *cf.Strings
+				case cf.Int != nil:
+					f.Value = *cf.Int
+				case cf.Ints != nil:
+					f.Value = *cf.Ints
+				case cf.Bool != nil:
+					f.Value = *cf.Bool
+				default:
+					return nil, fmt.Errorf("%s: %s: %s: field has no value", cs.Name, cv.Name, cf.Name)
+				}
+				v.Fields = append(v.Fields, f)
+			}
+			s.Vectors = append(s.Vectors, v)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
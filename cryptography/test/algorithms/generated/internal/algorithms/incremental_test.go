@@ -0,0 +1,29 @@
+package algorithms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	suites, err := CollectSuites(registry, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached, err := suitesToCache(suites)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := suitesFromCache(cached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The field values keep their Go types.
+	again, err := suitesToCache(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, cached) {
+		t.Error("suites from the cache differ")
+	}
+}
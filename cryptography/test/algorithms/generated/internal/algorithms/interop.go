@@ -0,0 +1,171 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
+// What backends produce with the defaults of Node.js crypto and PyCryptodome,
+// so that apps exchanging data with them have conformance tests:
+//
+//   - Node.js createCipheriv("aes-256-gcm") with the usual 12-byte IV and the
+//     16-byte tag of getAuthTag, createCipheriv("aes-256-cbc") with automatic
+//     PKCS7 padding, createCipheriv("chacha20-poly1305") with a 12-byte nonce
+//     and authTagLength 16, and pbkdf2Sync with the settings of its
+//     documentation.
+//   - PyCryptodome AES.new(key, AES.MODE_GCM), which generates a 16-byte nonce,
+//     AES.MODE_CBC with Padding.pad, and PBKDF2 with its defaults: HMAC-SHA1,
+//     1000 iterations and a 16-byte key. ChaCha20_Poly1305 defaults to a
+//     12-byte nonce like Node.js.
+
+// interopMessages are the clear texts of the cipher suites.
+var interopMessages = [][]byte{
+	nil,
+	[]byte("Hello, World!"),
+	[]byte("0123456789abcdef"),
+	[]byte(`{"id":42,"name":"Jürgen","roles":["admin","user"],"active":true}`),
+}
+
+// interopGcmSuite returns a suite of AES-GCM with the nonce length.
+func interopGcmSuite(name string, keyLength, nonceLength int) (*Suite, error) {
+	s := &Suite{
+		Name:   name,
+		Body:   fmt.Sprintf(aesGcmBody, 8*keyLength),
+		Covers: []string{fmt.Sprintf("AesGcm secretKeyLength=%d nonceLength=%d", keyLength, nonceLength)},
+	}
+	key := sequence(0, keyLength)
+	nonce := sequence(0x80, nonceLength)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, nonceLength)
+	if err != nil {
+		return nil, err
+	}
+	for _, clearText := range interopMessages {
+		for _, aad := range [][]byte{nil, []byte("header")} {
+			sealed := gcm.Seal(nil, nonce, clearText, aad)
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("%s, %s of AAD", describeBytes(clearText), describeBytes(aad)),
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"aad", aad},
+					{"clearText", clearText},
+					{"cipherText", sealed[:len(clearText)]},
+					{"mac", sealed[len(clearText):]},
+				},
+			})
+		}
+	}
+	addConcatenation(s)
+	return s, nil
+}
+
+// interopCbcSuite returns a suite of AES-CBC with PKCS7 padding and no MAC.
+func interopCbcSuite(name string, keyLength int) (*Suite, error) {
+	noMac := aesCbcMacs[1]
+	s := &Suite{
+		Name:   name,
+		Body:   fmt.Sprintf(aesCbcBody, 8*keyLength, noMac.dart),
+		Covers: []string{fmt.Sprintf("AesCbc secretKeyLength=%d macAlgorithm=%s", keyLength, noMac.covers)},
+	}
+	for _, clearText := range interopMessages {
+		v, err := aesCbcVector(noMac, sequence(0, keyLength), sequence(0x80, aes.BlockSize), clearText)
+		if err != nil {
+			return nil, err
+		}
+		s.Vectors = append(s.Vectors, v)
+	}
+	return s, nil
+}
+
+func init() {
+	register(newGenerator("interop", interopSuites).
+		uses("aes_cbc.go", "aes_gcm.go", "bytes.go", "chacha20_poly1305.go", "dart.go", "hkdf.go", "pbkdf2.go", "suite.go"))
+}
+
+func interopSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, c := range []struct {
+		name                   string
+		keyLength, nonceLength int
+	}{
+		{"node: aes-256-gcm", 32, 12},
+		{"pycryptodome: aes-gcm, 128-bit key", 16, 16},
+		{"pycryptodome: aes-gcm, 256-bit key", 32, 16},
+	} {
+		s, err := interopGcmSuite(c.name, c.keyLength, c.nonceLength)
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, s)
+	}
+
+	for _, c := range []struct {
+		name      string
+		keyLength int
+	}{
+		{"node: aes-256-cbc", 32},
+		{"pycryptodome: aes-cbc, 128-bit key", 16},
+	} {
+		s, err := interopCbcSuite(c.name, c.keyLength)
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, s)
+	}
+
+	chacha := &Suite{
+		Name:   "node: chacha20-poly1305",
+		Body:   chacha20Poly1305Body,
+		Covers: []string{"Chacha20.poly1305Aead"},
+	}
+	key := sequence(0, 32)
+	nonce := sequence(0x80, 12)
+	for _, clearText := range interopMessages {
+		for _, aad := range [][]byte{nil, []byte("header")} {
+			cipherText, mac, err := chacha20Poly1305Seal(key, nonce, clearText, aad)
+			if err != nil {
+				return nil, err
+			}
+			chacha.Vectors = append(chacha.Vectors, Vector{
+				Name: fmt.Sprintf("%s, %s of AAD", describeBytes(clearText), describeBytes(aad)),
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"aad", aad},
+					{"clearText", clearText},
+					{"cipherText", cipherText},
+					{"mac", mac},
+				},
+			})
+		}
+	}
+	addConcatenation(chacha)
+	suites = append(suites, chacha)
+
+	sha1Hmac, sha256Hmac, sha512Hmac := pbkdf2Hmacs[0], pbkdf2Hmacs[1], pbkdf2Hmacs[2]
+	for _, c := range []struct {
+		name               string
+		h                  kdfHmac
+		password, salt     string
+		iterations, length int
+	}{
+		// The example of crypto.pbkdf2Sync in the Node.js documentation.
+		{"node: pbkdf2-sha512", sha512Hmac, "secret", "salt", 100000, 64},
+		{"node: pbkdf2-sha256", sha256Hmac, "secret", "salt", 100000, 32},
+		{"pycryptodome: pbkdf2 defaults", sha1Hmac, "password", "salt", 1000, 16},
+	} {
+		suites = append(suites, &Suite{
+			Name:   c.name,
+			Body:   fmt.Sprintf(pbkdf2Body, c.h.hmac),
+			Covers: []string{"Pbkdf2 macAlgorithm=" + c.h.covers},
+			Vectors: []Vector{pbkdf2Vector(c.h, fmt.Sprintf("%s, %d-byte key", describeCount(c.iterations, "iteration"), c.length),
+				[]byte(c.password), []byte(c.salt), c.iterations, c.length)},
+		})
+	}
+	return suites, nil
+}
@@ -0,0 +1,33 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestInterop(t *testing.T) {
+	suites, err := interopSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Node.js documentation prints '3745e48...08d59ae' for
+	// pbkdf2Sync('secret', 'salt', 100000, 64, 'sha512').
+	v := findVector(t, suites, "node: pbkdf2-sha512", "100000 iterations, 64-byte key")
+	for _, f := range v.Fields {
+		if f.Name != "expected" {
+			continue
+		}
+		got := hex.EncodeToString(f.Value.([]byte))
+		if !strings.HasPrefix(got, "3745e48") || !strings.HasSuffix(got, "08d59ae") {
+			t.Errorf("pbkdf2Sync example is %s", got)
+		}
+	}
+	// PyCryptodome generates 16-byte GCM nonces.
+	v = findVector(t, suites, "pycryptodome: aes-gcm, 128-bit key", "0 bytes, 0 bytes of AAD")
+	for _, f := range v.Fields {
+		if f.Name == "nonce" && len(f.Value.([]byte)) != 16 {
+			t.Errorf("nonce has %d bytes, want 16", len(f.Value.([]byte)))
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JWE (RFC 7516) tokens in compact serialization with fixed keys and IVs:
+// direct encryption with A256GCM and A128CBC-HS256 (RFC 7518 section 5.2),
+// and ECDH-ES+A256KW with A256GCM, where the content encryption key is
+// wrapped with AES key wrap (RFC 3394) under a key derived with ConcatKDF
+// from an ECDH P-256 shared secret.
+
+const jweEcdhEsSkip = "AES key wrap and ConcatKDF are not implemented in package:cryptography"
+
+const jweDart = `
+final parts = token.split('.');
+expect(parts, hasLength(5));
+List<int> decode(String value) => base64Url.decode(base64Url.normalize(value));
+expect(utf8.decode(decode(parts[0])), header);
+final aad = ascii.encode(parts[0]);
+final encryptedKey = decode(parts[1]);
+final nonce = decode(parts[2]);
+final cipherText = decode(parts[3]);
+final tag = decode(parts[4]);
+expect(encryptedKey, isEmpty);
+`
+
+const jweA256GcmBody = jweDart + `final algorithm = AesGcm.with256bits();
+final secretBox = await algorithm.encrypt(
+  utf8.encode(clearText),
+  secretKey: SecretKey(cek),
+  nonce: nonce,
+  aad: aad,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(tag),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac(tag)),
+  secretKey: SecretKey(cek),
+  aad: aad,
+);
+expect(utf8.decode(decrypted), clearText);
+`
+
+// The MAC is the first half of HMAC-SHA-256 of the AAD, the IV, the cipher
+// text and the AAD length in bits as a 64-bit big-endian integer.
+const jweA128CbcHs256Body = jweDart + `final al = Uint8List(8)..buffer.asByteData().setUint32(4, 8 * aad.length);
+final mac = await Hmac.sha256().calculateMac(
+  [...aad, ...nonce, ...cipherText, ...al],
+  secretKey: SecretKey(cek.sublist(0, 16)),
+);
+expect(
+  hexFromBytes(mac.bytes.sublist(0, 16)),
+  hexFromBytes(tag),
+);
+final algorithm = AesCbc.with128bits(macAlgorithm: MacAlgorithm.empty);
+final secretBox = await algorithm.encrypt(
+  utf8.encode(clearText),
+  secretKey: SecretKey(cek.sublist(16)),
+  nonce: nonce,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(cek.sublist(16)),
+);
+expect(utf8.decode(decrypted), clearText);
+`
+
+// jweClearTexts are the clear texts of every suite.
+var jweClearTexts = []struct {
+	name, clearText string
+}{
+	{"empty", ""},
+	{"short", "Hello, World!"},
+	{"RFC 7516 appendix A.1", "The true sign of intelligence is not knowledge but imagination."},
+	{"JSON claims", `{"sub":"1234567890","name":"Jürgen","admin":true}`},
+}
+
+// aesKeyWrap wraps the key data with the key encryption key (RFC 3394
+// section 2.2.1) and the default initial value.
+func aesKeyWrap(kek, keyData []byte) ([]byte, error) {
+	if len(keyData)%8 != 0 || len(keyData) < 16 {
+		return nil, errors.New("aes key wrap: key data must be 8n bytes with n >= 2")
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := len(keyData) / 8
+	a := []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+	r := append([]byte(nil), keyData...)
+	b := make([]byte, aes.BlockSize)
+	for j := 0; j < 6; j++ {
+		for i := 0; i < n; i++ {
+			copy(b, a)
+			copy(b[8:], r[8*i:8*i+8])
+			block.Encrypt(b, b)
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(a, binary.BigEndian.Uint64(b[:8])^t)
+			copy(r[8*i:], b[8:])
+		}
+	}
+	return concat(a, r), nil
+}
+
+// jweA128CbcHs256 encrypts like AES_128_CBC_HMAC_SHA_256 (RFC 7518 section
+// 5.2.2.1) and returns the cipher text and the tag.
+func jweA128CbcHs256(cek, iv, clearText, aad []byte) (cipherText, tag []byte, err error) {
+	cipherText, err = aesCbcEncrypt(cek[16:], iv, clearText)
+	if err != nil {
+		return nil, nil, err
+	}
+	mac := hmac.New(sha256.New, cek[:16])
+	mac.Write(aad)
+	mac.Write(iv)
+	mac.Write(cipherText)
+	mac.Write(binary.BigEndian.AppendUint64(nil, uint64(8*len(aad))))
+	return cipherText, mac.Sum(nil)[:16], nil
+}
+
+// jweToken returns the compact serialization of the parts.
+func jweToken(header string, encryptedKey, iv, cipherText, tag []byte) string {
+	b64 := base64.RawURLEncoding.EncodeToString
+	return b64([]byte(header)) + "." + b64(encryptedKey) + "." + b64(iv) + "." + b64(cipherText) + "." + b64(tag)
+}
+
+// jweA256Gcm encrypts with A256GCM and returns the cipher text and the tag.
+func jweA256Gcm(cek, iv, clearText, aad []byte) (cipherText, tag []byte, err error) {
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed := gcm.Seal(nil, iv, clearText, aad)
+	return sealed[:len(clearText)], sealed[len(clearText):], nil
+}
+
+func init() {
+	register(newGenerator("jwe", jweSuites).
+		uses("aes_cbc.go", "bytes.go", "concat_kdf.go", "ecdh.go", "jwk.go", "suite.go"))
+}
+
+func jweSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, enc := range []struct {
+		name, body string
+		covers     []string
+		ivLength   int
+		encrypt    func(cek, iv, clearText, aad []byte) ([]byte, []byte, error)
+	}{
+		{"A256GCM", jweA256GcmBody, []string{"AesGcm secretKeyLength=32 nonceLength=12"}, 12, jweA256Gcm},
+		{"A128CBC-HS256", jweA128CbcHs256Body, []string{"AesCbc secretKeyLength=16 macAlgorithm=empty", "Hmac hashAlgorithm=Sha256"}, aes.BlockSize, jweA128CbcHs256},
+	} {
+		s := &Suite{
+			Name:   "jwe: dir, " + strings.ToLower(enc.name),
+			Body:   enc.body,
+			Covers: enc.covers,
+		}
+		header := fmt.Sprintf(`{"alg":"dir","enc":"%s"}`, enc.name)
+		cek := sequence(0, 32)
+		iv := sequence(0x80, enc.ivLength)
+		aad := []byte(base64.RawURLEncoding.EncodeToString([]byte(header)))
+		for _, c := range jweClearTexts {
+			cipherText, tag, err := enc.encrypt(cek, iv, []byte(c.clearText), aad)
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: c.name,
+				Fields: []Field{
+					{"header", header},
+					{"cek", cek},
+					{"clearText", c.clearText},
+					{"token", jweToken(header, nil, iv, cipherText, tag)},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+
+	// The recipient and ephemeral keys are the sequence keys of the ECDH
+	// suites.
+	p256 := nistCurves[0]
+	recipient, err := ecdh.P256().NewPrivateKey(p256.sequenceScalar(0x40))
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := ecdh.P256().NewPrivateKey(p256.sequenceScalar(0x80))
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := ephemeral.ECDH(recipient.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	point := ephemeral.PublicKey().Bytes()
+	epk, err := json.Marshal(jwk{Kty: "EC", Crv: "P-256", X: b64url(point[1:33]), Y: b64url(point[33:])})
+	if err != nil {
+		return nil, err
+	}
+	const alg = "ECDH-ES+A256KW"
+	header := fmt.Sprintf(`{"alg":"%s","enc":"A256GCM","epk":%s}`, alg, epk)
+	kek := singleStepKdf(sha256.New, true, sharedSecret, jweOtherInfo(alg, "", "", 256), 32)
+	cek := sequence(0, 32)
+	encryptedKey, err := aesKeyWrap(kek, cek)
+	if err != nil {
+		return nil, err
+	}
+	iv := sequence(0x80, 12)
+	aad := []byte(base64.RawURLEncoding.EncodeToString([]byte(header)))
+	ecdhEs := &Suite{Name: "jwe: ecdh-es+a256kw, a256gcm", Skip: jweEcdhEsSkip}
+	for _, c := range jweClearTexts {
+		cipherText, tag, err := jweA256Gcm(cek, iv, []byte(c.clearText), aad)
+		if err != nil {
+			return nil, err
+		}
+		ecdhEs.Vectors = append(ecdhEs.Vectors, Vector{
+			Name: c.name,
+			Fields: []Field{
+				{"header", header},
+				{"recipientPrivateKey", recipient.Bytes()},
+				{"ephemeralPrivateKey", ephemeral.Bytes()},
+				{"sharedSecret", sharedSecret},
+				{"kek", kek},
+				{"cek", cek},
+				{"encryptedKey", encryptedKey},
+				{"clearText", c.clearText},
+				{"token", jweToken(header, encryptedKey, iv, cipherText, tag)},
+			},
+		})
+	}
+	return append(suites, ecdhEs), nil
+}
@@ -0,0 +1,37 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestJwe(t *testing.T) {
+	// RFC 3394 section 4.6.
+	wrapped, err := aesKeyWrap(sequence(0, 32), mustHex("00112233445566778899aabbccddeeff000102030405060708090a0b0c0d0e0f"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "28c9f404c4b810f4cbccb35cfb87f8263f5786e2d80ed326cbc7f0e71a99f43bfb988b9b7a02dd21"; hex.EncodeToString(wrapped) != want {
+		t.Errorf("wrapped key is %x, want %s", wrapped, want)
+	}
+
+	// RFC 7518 appendix B.1.
+	_, tag, err := jweA128CbcHs256(
+		sequence(0, 32),
+		mustHex("1af38c2dc2b96ffdd86694092341bc04"),
+		[]byte("A cipher system must not be required to be secret, and it must be able to fall into the hands of the enemy without inconvenience"),
+		[]byte("The second principle of Auguste Kerckhoffs"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "652c3fa36b0a7c5b3219fab3a30bc1c4"; hex.EncodeToString(tag) != want {
+		t.Errorf("tag is %x, want %s", tag, want)
+	}
+
+	suites, err := jweSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	findVector(t, suites, "jwe: ecdh-es+a256kw, a256gcm", "short")
+}
@@ -0,0 +1,274 @@
+package algorithms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// JSON Web Keys (RFC 7517, RFC 7518 and RFC 8037) of every key type, with
+// their RFC 7638 thumbprints as "kid", for testing JWK import and export in
+// package:jwk. The Dart tests import the JWK with Jwk.fromJson, compare the
+// decoded members with the raw values, export the key pair and its public
+// key again, and compute the thumbprint from the required members in
+// lexicographic order. The tests are written to package:jwk by
+// "go run ./cmd/vectorgen jwk" because package:cryptography cannot depend on it.
+
+// jwk is a key in JSON Web Key format. Empty members are omitted, so the
+// same type encodes every key type and public keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	K   string `json:"k,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	Dp  string `json:"dp,omitempty"`
+	Dq  string `json:"dq,omitempty"`
+	Qi  string `json:"qi,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// b64url encodes bytes like JWK members: base64url without padding.
+func b64url(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// public returns the key without the private members.
+func (j jwk) public() jwk {
+	j.D, j.P, j.Q, j.Dp, j.Dq, j.Qi = "", "", "", "", "", ""
+	return j
+}
+
+// thumbprintInput returns the required members of the key type in
+// lexicographic order without whitespace (RFC 7638 section 3.2).
+func (j jwk) thumbprintInput() (string, error) {
+	members := map[string]string{"kty": j.Kty}
+	switch j.Kty {
+	case "oct":
+		members["k"] = j.K
+	case "RSA":
+		members["e"] = j.E
+		members["n"] = j.N
+	case "EC":
+		members["crv"] = j.Crv
+		members["x"] = j.X
+		members["y"] = j.Y
+	case "OKP":
+		members["crv"] = j.Crv
+		members["x"] = j.X
+	default:
+		return "", fmt.Errorf("jwk: unsupported kty %q", j.Kty)
+	}
+	// encoding/json sorts map keys.
+	b, err := json.Marshal(members)
+	return string(b), err
+}
+
+// thumbprint returns the base64url SHA-256 thumbprint of the key.
+func (j jwk) thumbprint() (string, error) {
+	input, err := j.thumbprintInput()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(input))
+	return b64url(sum[:]), nil
+}
+
+// jwkVector returns a vector of the key with the thumbprint as "kid". The
+// fields are the raw values of the members that the Dart test decodes.
+func jwkVector(name string, key jwk, members []Field) (Vector, error) {
+	input, err := key.thumbprintInput()
+	if err != nil {
+		return Vector{}, err
+	}
+	key.Kid, err = key.thumbprint()
+	if err != nil {
+		return Vector{}, err
+	}
+	private, err := json.Marshal(key)
+	if err != nil {
+		return Vector{}, err
+	}
+	fields := append([]Field{
+		{"kty", key.Kty},
+		{"jwk", string(private)},
+	}, members...)
+	if key.Kty != "oct" {
+		public, err := json.Marshal(key.public())
+		if err != nil {
+			return Vector{}, err
+		}
+		fields = append(fields, Field{"publicJwk", string(public)})
+	}
+	return Vector{
+		Name: name,
+		Fields: append(fields,
+			Field{"thumbprintInput", input},
+			Field{"thumbprint", key.Kid},
+		),
+	}, nil
+}
+
+// Jwk in package:jwk spells the key types "OCP" and "OCK", and encodes
+// members with padding.
+const (
+	jwkOctSkip     = `Jwk in package:jwk does not support kty "oct"`
+	jwkOkpSkip     = `Jwk in package:jwk does not support kty "OKP"`
+	jwkPaddingSkip = "Jwk in package:jwk encodes members with base64url padding"
+)
+
+const jwkDart = `
+final members = jsonDecode(jwk) as Map<String, dynamic>;
+final key = Jwk.fromJson(members);
+expect(key.kty, kty);
+expect(key.kid, thumbprint);
+%s
+final required = jsonDecode(thumbprintInput) as Map<String, dynamic>;
+expect(required.keys.toList(), required.keys.toList()..sort());
+for (final entry in required.entries) {
+  expect(members[entry.key], entry.value, reason: entry.key);
+}
+expect(jsonEncode(required), thumbprintInput);
+final hash = await Sha256().hash(utf8.encode(thumbprintInput));
+expect(base64Url.encode(hash.bytes).replaceAll('=', ''), thumbprint);
+`
+
+// jwkKeyPairDart exports the imported key pair and public key, which must
+// give the JWKs without "kid".
+const jwkKeyPairDart = `final keyPair = await key.toKeyPair().extract();
+expect(
+  Jwk.fromKeyPair(keyPair).toJson(),
+  Map.of(members)..remove('kid'),
+);
+final publicMembers = jsonDecode(publicJwk) as Map<String, dynamic>;
+publicMembers.remove('kid');
+expect(Jwk.fromPublicKey(key.toPublicKey()!).toJson(), publicMembers);
+expect(
+  Jwk.fromPublicKey(await keyPair.extractPublicKey()).toJson(),
+  publicMembers,
+);`
+
+// jwkBody returns a body that imports the JWK with package:jwk and checks
+// the members, which are fields of the vectors with the same names. Key
+// pairs are exported again.
+func jwkBody(members []string, keyPair bool) string {
+	var b strings.Builder
+	for _, m := range members {
+		switch m {
+		case "crv":
+			b.WriteString("expect(key.crv, crv);\n")
+		case "k":
+			b.WriteString("expect(hexFromBytes(await key.toSecretKey().extractBytes()), hexFromBytes(k));\n")
+		default:
+			fmt.Fprintf(&b, "expect(hexFromBytes(key.%s!), hexFromBytes(%s));\n", m, m)
+		}
+	}
+	b.WriteString("expect(key.toJson(), members);\n")
+	if keyPair {
+		b.WriteString(jwkKeyPairDart)
+	}
+	return fmt.Sprintf(jwkDart, strings.TrimSuffix(b.String(), "\n"))
+}
+
+// JwkSuites returns the JWK fixtures of package:jwk in canonical order.
+func JwkSuites() ([]*Suite, error) {
+	oct := &Suite{
+		Name: "jwk: oct",
+		Body: jwkBody([]string{"k"}, false),
+		Skip: jwkOctSkip,
+	}
+	for _, length := range []int{16, 32, 64} {
+		k := sequence(0, length)
+		v, err := jwkVector(fmt.Sprintf("%d-bit key", 8*length), jwk{Kty: "oct", K: b64url(k)}, []Field{{"k", k}})
+		if err != nil {
+			return nil, err
+		}
+		oct.Vectors = append(oct.Vectors, v)
+	}
+	suites := []*Suite{oct}
+
+	rsaSuite := &Suite{
+		Name: "jwk: rsa",
+		Body: jwkBody([]string{"n", "e", "d", "p", "q", "dp", "dq", "qi"}, true),
+		Skip: jwkPaddingSkip,
+	}
+	for _, bits := range rsaKeySizes {
+		key, err := rsaKey(bits)
+		if err != nil {
+			return nil, err
+		}
+		v, err := jwkVector(fmt.Sprintf("%d-bit key", bits), rsaJwk(key), rsaKeyFields(key))
+		if err != nil {
+			return nil, err
+		}
+		rsaSuite.Vectors = append(rsaSuite.Vectors, v)
+	}
+	suites = append(suites, rsaSuite)
+
+	for _, c := range nistCurves {
+		crv := "P-" + strings.TrimPrefix(c.name, "p")
+		d := c.sequenceScalar(0x40)
+		privateKey, err := ecdsa.ParseRawPrivateKey(c.elliptic, d)
+		if err != nil {
+			return nil, err
+		}
+		point, err := privateKey.PublicKey.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		// RFC 7518 section 6.2.1.2: the coordinates have the full length.
+		x, y := point[1:1+c.scalarSize()], point[1+c.scalarSize():]
+		v, err := jwkVector("sequence key", jwk{Kty: "EC", Crv: crv, X: b64url(x), Y: b64url(y), D: b64url(d)},
+			[]Field{{"crv", crv}, {"x", x}, {"y", y}, {"d", d}})
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, &Suite{
+			Name:    "jwk: ec " + strings.ToLower(crv),
+			Body:    jwkBody([]string{"crv", "x", "y", "d"}, true),
+			Skip:    jwkPaddingSkip,
+			Vectors: []Vector{v},
+		})
+	}
+
+	seed := sequence(0, ed25519.SeedSize)
+	scalar := sequence(0x40, curve25519.ScalarSize)
+	x25519Public, err := curve25519.X25519(scalar, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range []struct {
+		crv  string
+		d, x []byte
+	}{
+		{"Ed25519", seed, ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)},
+		{"X25519", scalar, x25519Public},
+	} {
+		v, err := jwkVector("sequence key", jwk{Kty: "OKP", Crv: k.crv, X: b64url(k.x), D: b64url(k.d)},
+			[]Field{{"crv", k.crv}, {"x", k.x}, {"d", k.d}})
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, &Suite{
+			Name:    "jwk: okp " + strings.ToLower(k.crv),
+			Body:    jwkBody([]string{"crv", "x", "d"}, true),
+			Skip:    jwkOkpSkip,
+			Vectors: []Vector{v},
+		})
+	}
+	if err := canonicalize(suites); err != nil {
+		return nil, err
+	}
+	return suites, nil
+}
@@ -0,0 +1,45 @@
+package algorithms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJwk(t *testing.T) {
+	// RFC 8037 appendix A.3.
+	key := jwk{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	got, err := key.thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; got != want {
+		t.Errorf("thumbprint is %s, want %s", got, want)
+	}
+
+	suites, err := JwkSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Dart tests import and export the keys with package:jwk.
+	for _, s := range suites {
+		if !strings.Contains(s.Body, "Jwk.fromJson(") {
+			t.Errorf("%s: does not import the JWK", s.Name)
+		}
+		if s.Name != "jwk: oct" && !strings.Contains(s.Body, "Jwk.fromKeyPair(") {
+			t.Errorf("%s: does not export the key pair", s.Name)
+		}
+	}
+	v := findVector(t, suites, "jwk: ec p-521", "sequence key")
+	for _, f := range v.Fields {
+		switch f.Name {
+		case "x", "y", "d":
+			if n := len(f.Value.([]byte)); n != 66 {
+				t.Errorf("%s has %d bytes, want 66", f.Name, n)
+			}
+		case "publicJwk":
+			if strings.Contains(f.Value.(string), `"d"`) {
+				t.Errorf("public JWK %s has a private member", f.Value)
+			}
+		}
+	}
+}
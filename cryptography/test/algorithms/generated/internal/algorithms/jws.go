@@ -0,0 +1,222 @@
+package algorithms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// JWS (RFC 7515) tokens in compact serialization with the algorithms of RFC
+// 7518 and RFC 8037. The header and the payload are fixed strings, because
+// the signature covers their exact bytes. HS256 and EdDSA signatures are
+// deterministic, so the Dart tests compute them. ES256 and PS256 signatures
+// are randomized, so the Dart tests verify the ones computed here with a
+// fixed nonce and salt, on Chrome like the other ECDSA and RSA suites.
+
+const jwsDart = `
+final parts = token.split('.');
+expect(parts, hasLength(3));
+List<int> decode(String value) => base64Url.decode(base64Url.normalize(value));
+String encode(List<int> bytes) => base64Url.encode(bytes).replaceAll('=', '');
+expect(utf8.decode(decode(parts[0])), header);
+expect(utf8.decode(decode(parts[1])), payload);
+final signingInput = ascii.encode('${parts[0]}.${parts[1]}');
+final signature = decode(parts[2]);
+`
+
+const jwsHs256Body = jwsDart + `final mac = await Hmac.sha256().calculateMac(
+  signingInput,
+  secretKey: SecretKey(secretKey),
+);
+expect(encode(mac.bytes), parts[2]);
+`
+
+const jwsEdDsaBody = jwsDart + `final algorithm = Ed25519();
+final keyPair = await algorithm.newKeyPairFromSeed(seed);
+final actual = await algorithm.sign(signingInput, keyPair: keyPair);
+expect(encode(actual.bytes), parts[2]);
+expect(
+  await algorithm.verify(
+    signingInput,
+    signature: Signature(signature, publicKey: await keyPair.extractPublicKey()),
+  ),
+  isTrue,
+);
+`
+
+const jwsEs256Body = jwsDart + `final algorithm = Ecdsa.p256(Sha256());
+final n = (publicKey.length - 1) ~/ 2;
+final publicKeyObject = EcPublicKey(
+  x: publicKey.sublist(1, 1 + n),
+  y: publicKey.sublist(1 + n),
+  type: KeyPairType.p256,
+);
+expect(
+  await algorithm.verify(
+    signingInput,
+    signature: Signature(signature, publicKey: publicKeyObject),
+  ),
+  isTrue,
+);
+`
+
+const jwsPs256Body = jwsDart + `final algorithm = RsaPss(Sha256(), nonceLengthInBytes: 32);
+expect(
+  await algorithm.verify(
+    signingInput,
+    signature: Signature(signature, publicKey: RsaPublicKey(e: e, n: n)),
+  ),
+  isTrue,
+);
+`
+
+// jwsPayloads are the payloads of every suite.
+var jwsPayloads = []struct {
+	name, payload string
+}{
+	{"jwt.io example", `{"sub":"1234567890","name":"John Doe","iat":1516239022}`},
+	{"empty claims", `{}`},
+	{"RFC 7515 payload", "{\"iss\":\"joe\",\r\n \"exp\":1300819380,\r\n \"http://example.com/is_root\":true}"},
+	{"non-ASCII claims", `{"name":"Jürgen 👋"}`},
+}
+
+// jwsToken returns the compact serialization of the header and the payload
+// signed with sign.
+func jwsToken(header, payload string, sign func(signingInput []byte) ([]byte, error)) (string, error) {
+	b64 := base64.RawURLEncoding.EncodeToString
+	signingInput := b64([]byte(header)) + "." + b64([]byte(payload))
+	signature, err := sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + b64(signature), nil
+}
+
+// mgf1 returns the mask of the length for the seed (RFC 8017 appendix B.2.1).
+func mgf1(h crypto.Hash, seed []byte, length int) []byte {
+	var mask []byte
+	for counter := uint32(0); len(mask) < length; counter++ {
+		d := h.New()
+		d.Write(seed)
+		d.Write(binary.BigEndian.AppendUint32(nil, counter))
+		mask = d.Sum(mask)
+	}
+	return mask[:length]
+}
+
+// rsaPssSign returns the RSASSA-PSS signature of the message with the salt
+// (RFC 8017 section 8.1.1). crypto/rsa always uses a random salt.
+func rsaPssSign(key *rsa.PrivateKey, h crypto.Hash, message, salt []byte) ([]byte, error) {
+	digest := h.New()
+	digest.Write(message)
+	mHash := digest.Sum(nil)
+
+	emBits := key.N.BitLen() - 1
+	emLength := (emBits + 7) / 8
+	if emLength < len(mHash)+len(salt)+2 {
+		return nil, errors.New("rsa-pss: modulus too short")
+	}
+	d := h.New()
+	d.Write(make([]byte, 8))
+	d.Write(mHash)
+	d.Write(salt)
+	hashed := d.Sum(nil)
+	db := concat(make([]byte, emLength-len(salt)-len(hashed)-2), []byte{1}, salt)
+	subtle.XORBytes(db, db, mgf1(h, hashed, len(db)))
+	db[0] &= 0xff >> (8*emLength - emBits)
+	em := concat(db, hashed, []byte{0xbc})
+
+	s := new(big.Int).Exp(new(big.Int).SetBytes(em), key.D, key.N)
+	signature := s.FillBytes(make([]byte, key.Size()))
+	if err := rsa.VerifyPSS(&key.PublicKey, h, mHash, signature, &rsa.PSSOptions{SaltLength: len(salt)}); err != nil {
+		return nil, fmt.Errorf("rsa-pss: %w", err)
+	}
+	return signature, nil
+}
+
+func init() {
+	register(newGenerator("jws", jwsSuites).
+		uses("bytes.go", "ecdh.go", "ecdsa.go", "rsa.go", "suite.go"))
+}
+
+func jwsSuites() ([]*Suite, error) {
+	secretKey := []byte("your-256-bit-secret")
+	seed := sequence(0, ed25519.SeedSize)
+	ed25519Key := ed25519.NewKeyFromSeed(seed)
+	p256 := nistCurves[0]
+	ecKey := p256.sequenceScalar(0x40)
+	ecPrivateKey, err := ecdsa.ParseRawPrivateKey(p256.elliptic, ecKey)
+	if err != nil {
+		return nil, err
+	}
+	ecPublicKey, err := ecPrivateKey.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	rsaPrivateKey, err := rsaKey(2048)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithms := []struct {
+		alg, body, testOn, covers string
+		fields                    []Field
+		sign                      func(signingInput []byte) ([]byte, error)
+	}{
+		{"HS256", jwsHs256Body, "", "Hmac hashAlgorithm=Sha256", []Field{{"secretKey", secretKey}}, func(signingInput []byte) ([]byte, error) {
+			mac := hmac.New(sha256.New, secretKey)
+			mac.Write(signingInput)
+			return mac.Sum(nil), nil
+		}},
+		{"EdDSA", jwsEdDsaBody, "", "Ed25519", []Field{{"seed", seed}}, func(signingInput []byte) ([]byte, error) {
+			return ed25519.Sign(ed25519Key, signingInput), nil
+		}},
+		{"ES256", jwsEs256Body, "chrome", "Ecdsa curve=p256 hashAlgorithm=Sha256", []Field{{"publicKey", ecPublicKey}}, func(signingInput []byte) ([]byte, error) {
+			return ecdsaSign(p256, crypto.SHA256, ecKey, signingInput)
+		}},
+		{"PS256", jwsPs256Body, "chrome", "RsaPss hashAlgorithm=Sha256", []Field{
+			{"n", rsaPrivateKey.N.Bytes()},
+			{"e", big.NewInt(int64(rsaPrivateKey.E)).Bytes()},
+		}, func(signingInput []byte) ([]byte, error) {
+			// RFC 7518 section 3.5: the salt is as long as the hash.
+			return rsaPssSign(rsaPrivateKey, crypto.SHA256, signingInput, sequence(0x80, sha256.Size))
+		}},
+	}
+
+	var suites []*Suite
+	for _, a := range algorithms {
+		s := &Suite{
+			Name:   "jws: " + strings.ToLower(a.alg),
+			Body:   a.body,
+			TestOn: a.testOn,
+			Covers: []string{a.covers},
+		}
+		header := fmt.Sprintf(`{"alg":"%s","typ":"JWT"}`, a.alg)
+		for _, p := range jwsPayloads {
+			token, err := jwsToken(header, p.payload, a.sign)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", s.Name, err)
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: p.name,
+				Fields: append(append([]Field(nil), a.fields...),
+					Field{"header", header},
+					Field{"payload", p.payload},
+					Field{"token", token},
+				),
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,182 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+)
+
+// AES-GCM cipher texts that decrypt under two different keys, like the
+// "invisible salamanders" of Dodis, Grubbs, Ristenpart and Woodage. AES-GCM
+// does not commit to the key, so both decryptions succeed and return
+// different clear texts. The tests document this behavior; a committing
+// AEAD wrapper must reject the secret box under at least one of the keys.
+//
+// The first cipher text block is chosen so that the tags under both keys are
+// equal. The tag under key k is E(k, J0) XOR GHASH(H_k, ...), which is linear
+// in the block, so the block is a solution of a linear equation in
+// GF(2^128). The rest of the cipher text is the encryption of a message under
+// the first key.
+
+const keyCommitmentBody = `
+final algorithm = AesGcm.with%dbits();
+final secretBox = SecretBox(cipherText, nonce: nonce, mac: Mac(mac));
+final decrypted1 = await algorithm.decrypt(
+  secretBox,
+  secretKey: SecretKey(secretKey1),
+  aad: aad,
+);
+expect(
+  hexFromBytes(decrypted1),
+  hexFromBytes(clearText1),
+);
+final decrypted2 = await algorithm.decrypt(
+  secretBox,
+  secretKey: SecretKey(secretKey2),
+  aad: aad,
+);
+expect(
+  hexFromBytes(decrypted2),
+  hexFromBytes(clearText2),
+);
+`
+
+// gcmInverse returns the multiplicative inverse of x in GF(2^128), which is
+// x^(2^128-2).
+func gcmInverse(x []byte) []byte {
+	// y = x^(2^127-1) and the inverse is y^2.
+	y := x
+	for i := 1; i < 127; i++ {
+		y = gcmMul(gcmMul(y, y), x)
+	}
+	return gcmMul(y, y)
+}
+
+// gcmKeyState is the hash key H and the tag mask E(K, J0) of a key.
+type gcmKeyState struct {
+	gcm     cipher.AEAD
+	h, mask []byte
+}
+
+func newGcmKeyState(key, nonce []byte) (gcmKeyState, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return gcmKeyState{}, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return gcmKeyState{}, err
+	}
+	h := make([]byte, 16)
+	block.Encrypt(h, h)
+	mask := make([]byte, 16)
+	block.Encrypt(mask, gcmCounterBlock(block, nonce))
+	return gcmKeyState{gcm, h, mask}, nil
+}
+
+// tag returns the tag of the cipher text.
+func (s gcmKeyState) tag(aad, cipherText []byte) []byte {
+	lengths := make([]byte, 16)
+	binary.BigEndian.PutUint64(lengths, uint64(8*len(aad)))
+	binary.BigEndian.PutUint64(lengths[8:], uint64(8*len(cipherText)))
+	tag := ghash(s.h, concat(
+		aad, make([]byte, (16-len(aad)%16)%16),
+		cipherText, make([]byte, (16-len(cipherText)%16)%16),
+		lengths,
+	))
+	for i := range tag {
+		tag[i] ^= s.mask[i]
+	}
+	return tag
+}
+
+// keyCommitmentVector returns a secret box that decrypts under both keys.
+func keyCommitmentVector(key1, key2, nonce, aad, message []byte) ([]Field, error) {
+	s1, err := newGcmKeyState(key1, nonce)
+	if err != nil {
+		return nil, err
+	}
+	s2, err := newGcmKeyState(key2, nonce)
+	if err != nil {
+		return nil, err
+	}
+	// The message starts at the second block of the key stream.
+	cipherText := s1.gcm.Seal(nil, nonce, concat(make([]byte, 16), message), aad)
+	cipherText = cipherText[:len(cipherText)-16]
+	copy(cipherText, make([]byte, 16))
+
+	// With a zero first block the tags differ by d. Replacing the block with
+	// c adds c*H_k^e to the tag under key k, where e is the number of blocks
+	// from the first cipher text block to the lengths block. The tags are
+	// equal when c = d / (H_1^e + H_2^e).
+	d := s1.tag(aad, cipherText)
+	for i, b := range s2.tag(aad, cipherText) {
+		d[i] ^= b
+	}
+	e := (len(cipherText)+15)/16 + 1
+	p1, p2 := s1.h, s2.h
+	for i := 1; i < e; i++ {
+		p1, p2 = gcmMul(p1, s1.h), gcmMul(p2, s2.h)
+	}
+	sum := make([]byte, 16)
+	for i := range sum {
+		sum[i] = p1[i] ^ p2[i]
+	}
+	copy(cipherText, gcmMul(d, gcmInverse(sum)))
+
+	mac := s1.tag(aad, cipherText)
+	clearText1, err := s1.gcm.Open(nil, nonce, concat(cipherText, mac), aad)
+	if err != nil {
+		return nil, fmt.Errorf("key 1: %w", err)
+	}
+	clearText2, err := s2.gcm.Open(nil, nonce, concat(cipherText, mac), aad)
+	if err != nil {
+		return nil, fmt.Errorf("key 2: %w", err)
+	}
+	return []Field{
+		{"secretKey1", key1},
+		{"secretKey2", key2},
+		{"nonce", nonce},
+		{"aad", aad},
+		{"cipherText", cipherText},
+		{"mac", mac},
+		{"clearText1", clearText1},
+		{"clearText2", clearText2},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("key-commitment", keyCommitmentSuites).
+		uses("aes_gcm.go", "bytes.go", "dart.go", "suite.go"))
+}
+
+func keyCommitmentSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, keyLength := range []int{16, 32} {
+		s := &Suite{
+			Name:   fmt.Sprintf("aes-gcm: %d-bit keys, cipher text valid under two keys", 8*keyLength),
+			Body:   fmt.Sprintf(keyCommitmentBody, 8*keyLength),
+			Covers: []string{fmt.Sprintf("AesGcm secretKeyLength=%d nonceLength=12", keyLength)},
+		}
+		nonce := sequence(0x80, 12)
+		for _, c := range []struct {
+			aad, message []byte
+		}{
+			{nil, nil},
+			{nil, []byte("Hello, world!")},
+			{sequence(0xa0, 13), []byte("The same cipher text decrypts under two keys.")},
+		} {
+			fields, err := keyCommitmentVector(sequence(0x00, keyLength), sequence(0x40, keyLength), nonce, c.aad, c.message)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", s.Name, err)
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name:   fmt.Sprintf("%d bytes of cipher text, %s of AAD", 16+len(c.message), describeBytes(c.aad)),
+				Fields: fields,
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
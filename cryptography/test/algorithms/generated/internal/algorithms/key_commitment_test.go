@@ -0,0 +1,48 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+)
+
+func TestKeyCommitment(t *testing.T) {
+	x := sequence(1, 16)
+	if got := gcmMul(x, gcmInverse(x)); !bytes.Equal(got, concat([]byte{0x80}, make([]byte, 15))) {
+		t.Errorf("x * x^-1 = %x, want the identity", got)
+	}
+	suites, err := keyCommitmentSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := canonicalize(suites); err != nil {
+		t.Fatal(err)
+	}
+	// Both keys open the secret box with crypto/cipher.
+	v := findVector(t, suites, "aes-gcm: 256-bit keys, cipher text valid under two keys", "61 bytes of cipher text, 13 bytes of AAD")
+	values := map[string][]byte{}
+	for _, f := range v.Fields {
+		values[f.Name] = f.Value.([]byte)
+	}
+	for _, n := range []string{"1", "2"} {
+		block, err := aes.NewCipher(values["secretKey"+n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := gcm.Open(nil, values["nonce"], concat(values["cipherText"], values["mac"]), values["aad"])
+		if err != nil {
+			t.Fatalf("key %s: %v", n, err)
+		}
+		if !bytes.Equal(got, values["clearText"+n]) {
+			t.Errorf("key %s: got %x, want %x", n, got, values["clearText"+n])
+		}
+	}
+	if got := values["clearText1"][16:]; string(got) != "The same cipher text decrypts under two keys." {
+		t.Errorf("clear text 1 ends with %q", got)
+	}
+}
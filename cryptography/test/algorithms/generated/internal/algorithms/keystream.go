@@ -0,0 +1,114 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20"
+)
+
+// Encryption in two calls, where the second call continues the key stream at
+// keyStreamIndex. The split is inside a block, so the second call has to
+// start with the rest of a partially used block. The concatenated output is
+// compared with the contiguous output of Go.
+
+const keyStreamBody = `
+final algorithm = %s;
+final first = await algorithm.encrypt(
+  clearText.sublist(0, split),
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+final second = await algorithm.encrypt(
+  clearText.sublist(split),
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  keyStreamIndex: split,
+);
+expect(
+  hexFromBytes([...first.cipherText, ...second.cipherText]),
+  hexFromBytes(cipherText),
+);
+final decryptedFirst = await algorithm.decrypt(
+  SecretBox(cipherText.sublist(0, split), nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(secretKey),
+);
+final decryptedSecond = await algorithm.decrypt(
+  SecretBox(cipherText.sublist(split), nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(secretKey),
+  keyStreamIndex: split,
+);
+expect(
+  hexFromBytes([...decryptedFirst, ...decryptedSecond]),
+  hexFromBytes(clearText),
+);
+`
+
+// keyStreamSplits returns the lengths of the first call for a block size.
+func keyStreamSplits(blockSize int) []int {
+	b := blockSize
+	return []int{1, b / 2, b - 1, b + 1, 2*b - 1, 2*b + b/2}
+}
+
+func init() {
+	register(newGenerator("key-stream-continuation", keyStreamSuites).
+		uses("bytes.go", "chacha20.go", "dart.go", "suite.go"))
+}
+
+func keyStreamSuites() ([]*Suite, error) {
+	key := sequence(0, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	var suites []*Suite
+	for _, c := range []struct {
+		name, dart, covers string
+		nonce              []byte
+		blockSize          int
+		xor                func(nonce, clearText []byte) ([]byte, error)
+	}{
+		{
+			"aes-ctr", "AesCtr.with256bits(macAlgorithm: MacAlgorithm.empty)", "AesCtr secretKeyLength=32 counterBits=64 macAlgorithm=empty",
+			concat(sequence(0x80, 8), make([]byte, 8)), aes.BlockSize,
+			func(nonce, clearText []byte) ([]byte, error) {
+				cipherText := make([]byte, len(clearText))
+				cipher.NewCTR(block, nonce).XORKeyStream(cipherText, clearText)
+				return cipherText, nil
+			},
+		},
+		{
+			"chacha20", "Chacha20(macAlgorithm: MacAlgorithm.empty)", "Chacha20 macAlgorithm=empty",
+			sequence(0x80, chacha20.NonceSize), 64,
+			func(nonce, clearText []byte) ([]byte, error) {
+				return chacha20XOR(key, nonce, clearText, 0)
+			},
+		},
+	} {
+		s := &Suite{
+			Name:   c.name + ": key stream continuation",
+			Body:   fmt.Sprintf(keyStreamBody, c.dart),
+			Covers: []string{c.covers},
+		}
+		clearText := sequence(0x01, 3*c.blockSize+5)
+		cipherText, err := c.xor(c.nonce, clearText)
+		if err != nil {
+			return nil, err
+		}
+		for _, split := range keyStreamSplits(c.blockSize) {
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("split after %s", describeCount(split, "byte")),
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", c.nonce},
+					{"split", split},
+					{"clearText", clearText},
+					{"cipherText", cipherText},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
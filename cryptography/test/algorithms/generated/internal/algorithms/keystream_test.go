@@ -0,0 +1,25 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestKeyStream(t *testing.T) {
+	suites, err := keyStreamSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two calls give the same output as one.
+	v := findVector(t, suites, "chacha20: key stream continuation", "split after 65 bytes")
+	first, err := chacha20XOR(sequence(0, 32), sequence(0x80, 12), sequence(0x01, 65), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := chacha20XOR(sequence(0, 32), sequence(0x80, 12), sequence(0x01, 3*64+5)[65:], 65)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "cipherText", hex.EncodeToString(concat(first, second)))
+	findVector(t, suites, "aes-ctr: key stream continuation", "split after 15 bytes")
+}
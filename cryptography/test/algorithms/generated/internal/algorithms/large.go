@@ -0,0 +1,180 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Multi-megabyte messages. The Dart test builds the message from its length
+// (byte i is i % 251, which does not repeat at any block size) and only the
+// digest of the output is in the vector, so the generated file stays small.
+// Cipher texts are compared by their SHA-256 hash.
+
+// largeMessageDart is the beginning of every test body.
+const largeMessageDart = `
+final data = Uint8List(length);
+for (var i = 0; i < data.length; i++) {
+  data[i] = i % 251;
+}`
+
+const largeHashBody = `
+final hash = await %s().hash(data);
+expect(
+  hexFromBytes(hash.bytes),
+  hexFromBytes(expected),
+);
+`
+
+const largeHmacBody = `
+final mac = await Hmac.sha256().calculateMac(
+  data,
+  secretKey: SecretKey(secretKey),
+);
+expect(
+  hexFromBytes(mac.bytes),
+  hexFromBytes(expected),
+);
+`
+
+const largeCipherBody = `
+final algorithm = %s;
+final secretBox = await algorithm.encrypt(
+  data,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+final cipherTextHash = await Sha256().hash(secretBox.cipherText);
+expect(
+  hexFromBytes(cipherTextHash.bytes),
+  hexFromBytes(cipherTextSha256),
+);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(mac),
+);
+final decrypted = await algorithm.decrypt(
+  secretBox,
+  secretKey: SecretKey(secretKey),
+);
+expect(decrypted, data);
+`
+
+// largeMessageLengths are 5 MiB and an odd length just under 5 MB.
+var largeMessageLengths = []int{5 << 20, 4999999}
+
+// largeMessage returns the message of the length.
+func largeMessage(length int) []byte {
+	data := make([]byte, length)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func init() {
+	register(newGenerator("large-messages", largeSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func largeSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, h := range []struct {
+		name, dart string
+		new        func() hash.Hash
+	}{
+		{"sha-256", "Sha256", sha256.New},
+		{"sha-512", "Sha512", sha512.New},
+	} {
+		s := &Suite{
+			Name:   "large messages: " + h.name,
+			Body:   largeMessageDart + fmt.Sprintf(largeHashBody, h.dart),
+			Covers: []string{h.dart},
+		}
+		for _, n := range largeMessageLengths {
+			d := h.new()
+			d.Write(largeMessage(n))
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("%d bytes", n),
+				Fields: []Field{
+					{"length", n},
+					{"expected", d.Sum(nil)},
+				},
+				Cost: n / d.BlockSize(),
+			})
+		}
+		suites = append(suites, s)
+	}
+
+	hmacSuite := &Suite{
+		Name:   "large messages: hmac-sha-256",
+		Body:   largeMessageDart + largeHmacBody,
+		Covers: []string{"Hmac hashAlgorithm=Sha256"},
+	}
+	key := sequence(0, 32)
+	for _, n := range largeMessageLengths {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(largeMessage(n))
+		hmacSuite.Vectors = append(hmacSuite.Vectors, Vector{
+			Name: fmt.Sprintf("%d bytes", n),
+			Fields: []Field{
+				{"secretKey", key},
+				{"length", n},
+				{"expected", mac.Sum(nil)},
+			},
+			Cost: n / sha256.BlockSize,
+		})
+	}
+	suites = append(suites, hmacSuite)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	chacha, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range []struct {
+		name, dart, covers string
+		aead               cipher.AEAD
+	}{
+		{"aes-gcm", "AesGcm.with256bits()", "AesGcm", gcm},
+		{"chacha20-poly1305", "Chacha20.poly1305Aead()", "Chacha20.poly1305Aead", chacha},
+	} {
+		s := &Suite{
+			Name:   "large messages: " + c.name,
+			Body:   largeMessageDart + fmt.Sprintf(largeCipherBody, c.dart),
+			Covers: []string{c.covers},
+		}
+		nonce := sequence(0x80, c.aead.NonceSize())
+		for _, n := range largeMessageLengths {
+			sealed := c.aead.Seal(nil, nonce, largeMessage(n), nil)
+			cipherTextSha256 := sha256.Sum256(sealed[:n])
+			s.Vectors = append(s.Vectors, Vector{
+				Name: fmt.Sprintf("%d bytes", n),
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"length", n},
+					{"cipherTextSha256", cipherTextSha256[:]},
+					{"mac", sealed[n:]},
+				},
+				// Encrypting, decrypting and hashing the cipher text.
+				Cost: 3 * n / 16,
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,20 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestLargeMessages(t *testing.T) {
+	suites, err := largeSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := largeMessage(5 << 20)
+	if data[251] != 0 || data[252] != 1 {
+		t.Errorf("pattern does not repeat every 251 bytes")
+	}
+	want := sha256.Sum256(data)
+	checkHex(t, findVector(t, suites, "large messages: sha-256", "5242880 bytes"), "expected", hex.EncodeToString(want[:]))
+}
@@ -0,0 +1,185 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// Macaroons (https://research.google/pubs/pub41892/) as implemented by
+// libmacaroons and gopkg.in/macaroon.v2. Signatures are chained HMAC-SHA256.
+
+// macaroonKeyGenerator is the HMAC key used to derive a macaroon key from a
+// root key.
+var macaroonKeyGenerator = []byte("macaroons-key-generator")
+
+func macaroonKey(rootKey []byte) []byte {
+	return macaroonHash(macaroonKeyGenerator, rootKey)
+}
+
+func macaroonHash(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func macaroonHash2(key, data0, data1 []byte) []byte {
+	h0 := macaroonHash(key, data0)
+	h1 := macaroonHash(key, data1)
+	return macaroonHash(key, append(h0, h1...))
+}
+
+// macaroonVerificationID encrypts the caveat key with the current signature.
+func macaroonVerificationID(signature, caveatKey []byte, nonce [24]byte) []byte {
+	var key, text [32]byte
+	copy(key[:], signature)
+	copy(text[:], macaroonKey(caveatKey))
+	return secretbox.Seal(nonce[:], text[:], &nonce, &key)
+}
+
+const macaroonPrelude = `
+final hmac = Hmac.sha256();
+Future<List<int>> keyedHash(List<int> key, List<int> data) async {
+  final mac = await hmac.calculateMac(data, secretKey: SecretKey(key));
+  return mac.bytes;
+}
+Future<List<int>> keyedHash2(
+    List<int> key, List<int> data0, List<int> data1) async {
+  final h0 = await keyedHash(key, data0);
+  final h1 = await keyedHash(key, data1);
+  return keyedHash(key, [...h0, ...h1]);
+}
+final macaroonKey = await keyedHash(
+  utf8.encode('macaroons-key-generator'),
+  rootKey,
+);
+var actual = await keyedHash(macaroonKey, utf8.encode(identifier));
+for (var caveat in caveats) {
+  actual = await keyedHash(actual, utf8.encode(caveat));
+}
+`
+
+func init() {
+	register(newGenerator("macaroons", macaroonSuites).
+		uses("dart.go", "suite.go"))
+}
+
+func macaroonSuites() ([]*Suite, error) {
+	firstParty := &Suite{
+		Name:   "macaroons: first-party caveats",
+		Covers: []string{"Hmac hashAlgorithm=Sha256"},
+		Body: macaroonPrelude + `
+expect(
+  hexFromBytes(actual),
+  hexFromBytes(signature),
+);
+`,
+	}
+	rootKey := []byte("this is our super secret key; only we should know it")
+	identifier := "we used our secret key"
+	allCaveats := []string{
+		"account = 3735928559",
+		"time < 2020-01-01T00:00",
+		"email = alice@example.org",
+	}
+	for n := 0; n <= len(allCaveats); n++ {
+		caveats := allCaveats[:n]
+		signature := macaroonKey(rootKey)
+		signature = macaroonHash(signature, []byte(identifier))
+		for _, caveat := range caveats {
+			signature = macaroonHash(signature, []byte(caveat))
+		}
+		firstParty.Vectors = append(firstParty.Vectors, Vector{
+			Name: describeCount(n, "caveat"),
+			Fields: []Field{
+				{"rootKey", rootKey},
+				{"identifier", identifier},
+				{"caveats", caveats},
+				{"signature", signature},
+			},
+		})
+	}
+
+	thirdParty := &Suite{
+		Name:   "macaroons: third-party caveats",
+		Covers: []string{"Hmac hashAlgorithm=Sha256"},
+		Body: macaroonPrelude + `
+actual = await keyedHash2(
+  actual,
+  verificationId,
+  utf8.encode(caveatId),
+);
+expect(
+  hexFromBytes(actual),
+  hexFromBytes(signature),
+);
+
+// Discharge macaroon
+final dischargeKey = await keyedHash(
+  utf8.encode('macaroons-key-generator'),
+  caveatRootKey,
+);
+final actualDischarge = await keyedHash(
+  dischargeKey,
+  utf8.encode(caveatId),
+);
+expect(
+  hexFromBytes(actualDischarge),
+  hexFromBytes(dischargeSignature),
+);
+
+// Discharge macaroon bound to the authorizing macaroon
+final actualBound = await keyedHash2(
+  Uint8List(32),
+  actual,
+  actualDischarge,
+);
+expect(
+  hexFromBytes(actualBound),
+  hexFromBytes(boundDischargeSignature),
+);
+`,
+	}
+	var nonce [24]byte
+	for i := range nonce {
+		nonce[i] = byte(i)
+	}
+	for _, caveats := range [][]string{
+		{},
+		{"account = 3735928559"},
+	} {
+		rootKey := []byte("this is a different super-secret key; never use the same secret twice")
+		identifier := "we used our other secret key"
+		caveatRootKey := []byte("4; guaranteed random by a fair toss of the dice")
+		caveatID := "this was how we remind auth of key/pred"
+
+		signature := macaroonKey(rootKey)
+		signature = macaroonHash(signature, []byte(identifier))
+		for _, caveat := range caveats {
+			signature = macaroonHash(signature, []byte(caveat))
+		}
+		verificationID := macaroonVerificationID(signature, caveatRootKey, nonce)
+		signature = macaroonHash2(signature, verificationID, []byte(caveatID))
+
+		dischargeSignature := macaroonKey(caveatRootKey)
+		dischargeSignature = macaroonHash(dischargeSignature, []byte(caveatID))
+		boundDischargeSignature := macaroonHash2(make([]byte, 32), signature, dischargeSignature)
+
+		thirdParty.Vectors = append(thirdParty.Vectors, Vector{
+			Name: describeCount(len(caveats), "first-party caveat"),
+			Fields: []Field{
+				{"rootKey", rootKey},
+				{"identifier", identifier},
+				{"caveats", caveats},
+				{"caveatRootKey", caveatRootKey},
+				{"caveatId", caveatID},
+				{"verificationId", verificationID},
+				{"signature", signature},
+				{"dischargeSignature", dischargeSignature},
+				{"boundDischargeSignature", boundDischargeSignature},
+			},
+		})
+	}
+	return []*Suite{firstParty, thirdParty}, nil
+}
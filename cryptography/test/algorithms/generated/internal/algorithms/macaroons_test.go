@@ -0,0 +1,24 @@
+package algorithms
+
+import (
+	"testing"
+)
+
+func TestMacaroons(t *testing.T) {
+	suites, err := macaroonSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Signatures from the libmacaroons README.
+	for _, tc := range []struct {
+		name, signature string
+	}{
+		{"0 caveats", "e3d9e02908526c4c0039ae15114115d97fdd68bf2ba379b342aaf0f617d0552f"},
+		{"1 caveat", "1efe4763f290dbce0c1d08477367e11f4eee456a64933cf662d79772dbb82128"},
+		{"2 caveats", "b5f06c8c8ef92f6c82c6ff282cd1f8bd1849301d09a2db634ba182536a611c49"},
+		{"3 caveats", "ddf553e46083e55b8d71ab822be3d8fcf21d6bf19c40d617bb9fb438934474b6"},
+	} {
+		v := findVector(t, suites, "macaroons: first-party caveats", tc.name)
+		checkHex(t, v, "signature", tc.signature)
+	}
+}
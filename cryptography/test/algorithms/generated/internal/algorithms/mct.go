@@ -0,0 +1,262 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// Monte Carlo tests of NIST SHAVS, SHA3VS and AESAVS. Each vector is a seed
+// and the output after 100 checkpoints of 1000 chained operations, so 100000
+// operations are checked with a few bytes of generated code. SHA-1 and SHA-2
+// hash the concatenation of the last three digests. SHA-3 hashes the last
+// digest.
+//
+// AES-CBC follows AESAVS: each clear text is the cipher text before the
+// previous one, and after every checkpoint the key is XORed with the last
+// cipher texts. AESAVS has no CTR test, so AES-CTR uses the same feedback with
+// the block at keyStreamIndex 16*j of the checkpoint's nonce, which checks
+// the counter increments as well.
+
+const (
+	mctCheckpoints = 100
+	mctIterations  = 1000
+)
+
+const shaMctBody = `
+final algorithm = %s();
+List<int> md = seed;
+for (var j = 0; j < checkpoints; j++) {
+  var a = md, b = md, c = md;
+  for (var i = 0; i < iterations; i++) {
+    final hash = await algorithm.hash([...a, ...b, ...c]);
+    a = b;
+    b = c;
+    c = hash.bytes;
+  }
+  md = c;
+}
+expect(
+  hexFromBytes(md),
+  hexFromBytes(expected),
+);
+`
+
+// shaMct returns the digest after the checkpoints of the SHAVS Monte Carlo
+// test for SHA-1 and SHA-2.
+func shaMct(newHash func() hash.Hash, seed []byte, checkpoints, iterations int) []byte {
+	md := seed
+	for j := 0; j < checkpoints; j++ {
+		a, b, c := md, md, md
+		for i := 0; i < iterations; i++ {
+			h := newHash()
+			h.Write(a)
+			h.Write(b)
+			h.Write(c)
+			a, b, c = b, c, h.Sum(nil)
+		}
+		md = c
+	}
+	return md
+}
+
+// sha3Mct returns the digest after the checkpoints of the SHA3VS Monte Carlo
+// test.
+func sha3Mct(newHash func() hash.Hash, seed []byte, checkpoints, iterations int) []byte {
+	md := seed
+	for j := 0; j < checkpoints; j++ {
+		for i := 0; i < iterations; i++ {
+			h := newHash()
+			h.Write(md)
+			md = h.Sum(nil)
+		}
+	}
+	return md
+}
+
+func init() {
+	register(newGenerator("hash-monte-carlo", hashMctSuites).
+		uses("bytes.go", "hash.go", "suite.go"))
+}
+
+func hashMctSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, a := range hashAlgorithms {
+		if a.name == "md5" {
+			continue
+		}
+		s := &Suite{Name: a.name + ": monte carlo", Skip: a.skip}
+		if a.skip == "" {
+			s.Body = fmt.Sprintf(shaMctBody, a.dart)
+			s.Covers = []string{a.dart}
+		}
+		mct := shaMct
+		if strings.HasPrefix(a.name, "sha3-") {
+			mct = sha3Mct
+		}
+		h := a.new()
+		seed := sequence(0, h.Size())
+		// Messages of three digests are one or two blocks with padding.
+		blocks := (3*h.Size() + 8 + h.BlockSize()) / h.BlockSize()
+		s.Vectors = append(s.Vectors, Vector{
+			Name: fmt.Sprintf("%d checkpoints of %d hashes", mctCheckpoints, mctIterations),
+			Fields: []Field{
+				{"seed", seed},
+				{"checkpoints", mctCheckpoints},
+				{"iterations", mctIterations},
+				{"expected", mct(a.new, seed, mctCheckpoints, mctIterations)},
+			},
+			Cost: mctCheckpoints * mctIterations * blocks,
+		})
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+const aesCbcMctBody = `
+final algorithm = AesCbc.with%dbits(macAlgorithm: MacAlgorithm.empty);
+List<int> key = secretKey;
+List<int> iv = nonce;
+List<int> pt = clearText;
+for (var i = 0; i < checkpoints; i++) {
+  var chain = iv;
+  for (var j = 0; j < iterations; j++) {
+    final secretBox = await algorithm.encrypt(
+      pt,
+      secretKey: SecretKey(key),
+      nonce: chain,
+    );
+    pt = chain;
+    // The second block is padding.
+    chain = secretBox.cipherText.sublist(0, 16);
+  }
+  final feedback = [...pt, ...chain];
+  key = [
+    for (var k = 0; k < key.length; k++)
+      key[k] ^ feedback[feedback.length - key.length + k],
+  ];
+  iv = chain;
+}
+expect(
+  hexFromBytes(iv),
+  hexFromBytes(expected),
+);
+`
+
+const aesCtrMctBody = `
+final algorithm = AesCtr.with%dbits(macAlgorithm: MacAlgorithm.empty);
+List<int> key = secretKey;
+List<int> iv = nonce;
+List<int> pt = clearText;
+for (var i = 0; i < checkpoints; i++) {
+  var chain = iv;
+  for (var j = 0; j < iterations; j++) {
+    final secretBox = await algorithm.encrypt(
+      pt,
+      secretKey: SecretKey(key),
+      nonce: iv,
+      keyStreamIndex: 16 * j,
+    );
+    pt = chain;
+    chain = secretBox.cipherText;
+  }
+  final feedback = [...pt, ...chain];
+  key = [
+    for (var k = 0; k < key.length; k++)
+      key[k] ^ feedback[feedback.length - key.length + k],
+  ];
+  iv = chain;
+}
+expect(
+  hexFromBytes(iv),
+  hexFromBytes(expected),
+);
+`
+
+// aesMct returns the last cipher text of the AES Monte Carlo test in CBC
+// mode or, if ctr is true, CTR mode.
+func aesMct(ctr bool, key, iv, clearText []byte, checkpoints, iterations int) ([]byte, error) {
+	key = append([]byte(nil), key...)
+	pt := clearText
+	for i := 0; i < checkpoints; i++ {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		chain := iv
+		for j := 0; j < iterations; j++ {
+			ct := make([]byte, aes.BlockSize)
+			if ctr {
+				// AesCtr increments 64 bits by default, so the low
+				// 64 bits of the nonce must not carry.
+				counter := append([]byte(nil), iv...)
+				low := binary.BigEndian.Uint64(counter[8:])
+				if low+uint64(j) < low {
+					return nil, errors.New("aes-ctr: counter carries past 64 bits")
+				}
+				binary.BigEndian.PutUint64(counter[8:], low+uint64(j))
+				block.Encrypt(ct, counter)
+				subtle.XORBytes(ct, ct, pt)
+			} else {
+				subtle.XORBytes(ct, pt, chain)
+				block.Encrypt(ct, ct)
+			}
+			pt, chain = chain, ct
+		}
+		feedback := concat(pt, chain)
+		subtle.XORBytes(key, key, feedback[len(feedback)-len(key):])
+		iv = chain
+	}
+	return iv, nil
+}
+
+func init() {
+	register(newGenerator("aes-monte-carlo", aesMctSuites, spec.AesKeyLengths).
+		uses("bytes.go", "spec.go", "suite.go"))
+}
+
+func aesMctSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, mode := range []struct {
+		name, body, covers string
+		ctr                bool
+	}{
+		{"aes-cbc", aesCbcMctBody, "AesCbc secretKeyLength=%d macAlgorithm=empty", false},
+		{"aes-ctr", aesCtrMctBody, "AesCtr secretKeyLength=%d counterBits=64 macAlgorithm=empty", true},
+	} {
+		for _, keyLength := range aesKeyLengths() {
+			key := sequence(0, keyLength)
+			iv := sequence(0x80, aes.BlockSize)
+			clearText := sequence(0x01, aes.BlockSize)
+			expected, err := aesMct(mode.ctr, key, iv, clearText, mctCheckpoints, mctIterations)
+			if err != nil {
+				return nil, err
+			}
+			suites = append(suites, &Suite{
+				Name:   fmt.Sprintf("%s: %d-bit key, monte carlo", mode.name, 8*keyLength),
+				Body:   fmt.Sprintf(mode.body, 8*keyLength),
+				Covers: []string{fmt.Sprintf(mode.covers, keyLength)},
+				Vectors: []Vector{{
+					Name: fmt.Sprintf("%d checkpoints of %d encryptions", mctCheckpoints, mctIterations),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", iv},
+						{"clearText", clearText},
+						{"checkpoints", mctCheckpoints},
+						{"iterations", mctIterations},
+						{"expected", expected},
+					},
+					// Expanding the key and two blocks.
+					Cost: 3 * mctCheckpoints * mctIterations,
+				}},
+			})
+		}
+	}
+	return suites, nil
+}
@@ -0,0 +1,77 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func TestHashMct(t *testing.T) {
+	// One iteration hashes three copies of the seed for SHA-2 and the seed
+	// for SHA-3.
+	seed := sequence(0, 32)
+	want := sha256.Sum256(concat(seed, seed, seed))
+	if got := shaMct(sha256.New, seed, 1, 1); !bytes.Equal(got, want[:]) {
+		t.Errorf("sha-256: got %x, want %x", got, want)
+	}
+	want = sha3.Sum256(seed)
+	if got := sha3Mct(sha3.New256, seed, 1, 1); !bytes.Equal(got, want[:]) {
+		t.Errorf("sha3-256: got %x, want %x", got, want)
+	}
+	// The checkpoint digest is the seed of the next checkpoint.
+	first := shaMct(sha256.New, seed, 1, 1000)
+	if got, want := shaMct(sha256.New, seed, 2, 1000), shaMct(sha256.New, first, 1, 1000); !bytes.Equal(got, want) {
+		t.Errorf("sha-256: got %x, want %x", got, want)
+	}
+
+	suites, err := hashMctSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "sha-256: monte carlo", "100 checkpoints of 1000 hashes")
+	checkHex(t, v, "expected", hex.EncodeToString(shaMct(sha256.New, seed, 100, 1000)))
+	if v.Cost != 200000 {
+		t.Errorf("cost %d, want 200000", v.Cost)
+	}
+}
+
+func TestAesMct(t *testing.T) {
+	key, iv, clearText := sequence(0, 16), sequence(0x80, 16), sequence(0x01, 16)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two CBC iterations are CBC encryption of the clear text and the IV.
+	cbc := concat(clearText, iv)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(cbc, cbc)
+	got, err := aesMct(false, key, iv, clearText, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, cbc[16:]) {
+		t.Errorf("cbc: got %x, want %x", got, cbc[16:])
+	}
+	// The second CTR iteration uses the second block of the key stream.
+	keyStream := make([]byte, 32)
+	cipher.NewCTR(block, iv).XORKeyStream(keyStream, keyStream)
+	want := make([]byte, 16)
+	subtle.XORBytes(want, keyStream[16:], iv)
+	if got, err = aesMct(true, key, iv, clearText, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ctr: got %x, want %x", got, want)
+	}
+
+	suites, err := aesMctSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	findVector(t, suites, "aes-cbc: 256-bit key, monte carlo", "100 checkpoints of 1000 encryptions")
+}
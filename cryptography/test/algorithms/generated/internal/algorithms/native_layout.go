@@ -0,0 +1,100 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// The layout of the Android and Apple APIs, where the tag is appended to the
+// cipher text, next to the split layout of SecretBox. The plugins split the
+// native output at length - 16, which users reported truncating clear texts
+// that are shorter than a tag or not a multiple of 16 bytes.
+
+const nativeLayoutBody = `
+final algorithm = %s;
+final secretBox = await algorithm.encrypt(
+  clearText,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+  aad: aad,
+);
+expect(
+  hexFromBytes(secretBox.concatenation(nonce: false)),
+  hexFromBytes(cipherTextAndMac),
+);
+final split = SecretBox.fromConcatenation(
+  cipherTextAndMac,
+  nonceLength: 0,
+  macLength: 16,
+);
+expect(hexFromBytes(split.cipherText), hexFromBytes(cipherText));
+expect(hexFromBytes(split.mac.bytes), hexFromBytes(mac));
+final decrypted = await algorithm.decrypt(
+  SecretBox(split.cipherText, nonce: nonce, mac: split.mac),
+  secretKey: SecretKey(secretKey),
+  aad: aad,
+);
+expect(
+  hexFromBytes(decrypted),
+  hexFromBytes(clearText),
+);
+`
+
+func init() {
+	register(newGenerator("native-layout", nativeLayoutSuites).
+		uses("bytes.go", "dart.go", "suite.go"))
+}
+
+func nativeLayoutSuites() ([]*Suite, error) {
+	key := sequence(0, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	chacha, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	var suites []*Suite
+	for _, c := range []struct {
+		name, dart, covers string
+		aead               cipher.AEAD
+	}{
+		{"aes-gcm", "AesGcm.with256bits()", "AesGcm secretKeyLength=32 nonceLength=12", gcm},
+		{"chacha20-poly1305", "Chacha20.poly1305Aead()", "Chacha20.poly1305Aead", chacha},
+	} {
+		s := &Suite{
+			Name:   c.name + ": native layout",
+			Body:   fmt.Sprintf(nativeLayoutBody, c.dart),
+			Covers: []string{c.covers},
+		}
+		nonce := sequence(0x80, c.aead.NonceSize())
+		for _, n := range []int{0, 1, 15, 16, 17, 31, 32, 33, 100} {
+			for _, aad := range [][]byte{nil, sequence(0xa0, 13)} {
+				clearText := sequence(0x01, n)
+				sealed := c.aead.Seal(nil, nonce, clearText, aad)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%s, %s of AAD", describeBytes(clearText), describeBytes(aad)),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", nonce},
+						{"aad", aad},
+						{"clearText", clearText},
+						{"cipherTextAndMac", sealed},
+						{"cipherText", sealed[:n]},
+						{"mac", sealed[n:]},
+					},
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,22 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestNativeLayout(t *testing.T) {
+	suites, err := nativeLayoutSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The tag follows the cipher text.
+	v := findVector(t, suites, "chacha20-poly1305: native layout", "15 bytes, 13 bytes of AAD")
+	cipherText, mac, err := chacha20Poly1305Seal(sequence(0, 32), sequence(0x80, 12), sequence(0x01, 15), sequence(0xa0, 13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, v, "cipherTextAndMac", hex.EncodeToString(concat(cipherText, mac)))
+	checkHex(t, v, "mac", hex.EncodeToString(mac))
+	findVector(t, suites, "aes-gcm: native layout", "0 bytes, 0 bytes of AAD")
+}
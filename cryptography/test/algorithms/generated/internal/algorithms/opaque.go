@@ -0,0 +1,385 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/group"
+	"github.com/cloudflare/circl/oprf"
+	"golang.org/x/crypto/hkdf"
+)
+
+// OPAQUE (RFC 9807) with the OPAQUE-3DH ristretto255-SHA512 configuration and
+// the identity key stretching function.
+//
+// All random values are fixed so the transcripts are reproducible.
+
+const (
+	opaqueNn    = 32 // nonce length
+	opaqueNseed = 32 // seed length
+	opaqueNh    = 64 // hash output length
+	opaqueNpk   = 32 // public key length
+)
+
+func opaqueExpand(prk, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha512.New, prk, info), out); err != nil {
+		return nil, fmt.Errorf("HKDF-Expand: %w", err)
+	}
+	return out, nil
+}
+
+func opaqueMAC(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func opaqueHash(data []byte) []byte {
+	h := sha512.Sum512(data)
+	return h[:]
+}
+
+func i2osp2(n int) []byte {
+	return binary.BigEndian.AppendUint16(nil, uint16(n))
+}
+
+// opaqueDeriveKeyPair derives a ristretto255 key pair with the OPRF
+// DeriveKeyPair function.
+func opaqueDeriveKeyPair(seed []byte, info string) (group.Scalar, []byte, error) {
+	privateKey, err := oprf.DeriveKey(oprf.SuiteRistretto255, oprf.BaseMode, seed, []byte(info))
+	if err != nil {
+		return nil, nil, fmt.Errorf("deriving key pair: %w", err)
+	}
+	privateKeyBytes, err := privateKey.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKey, err := privateKey.Public().MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	scalar, err := opaqueScalar(privateKeyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scalar, publicKey, nil
+}
+
+func opaqueDH(privateKey group.Scalar, publicKey []byte) ([]byte, error) {
+	element := group.Ristretto255.NewElement()
+	if err := element.UnmarshalBinary(publicKey); err != nil {
+		return nil, fmt.Errorf("decoding public key: %w", err)
+	}
+	return group.Ristretto255.NewElement().Mul(element, privateKey).MarshalBinaryCompress()
+}
+
+func opaqueScalar(b []byte) (group.Scalar, error) {
+	s := group.Ristretto255.NewScalar()
+	if err := s.UnmarshalBinary(b); err != nil {
+		return nil, fmt.Errorf("decoding scalar: %w", err)
+	}
+	return s, nil
+}
+
+// opaqueOPRF runs the OPRF protocol and returns the blinded element, the
+// evaluated element and the OPRF output.
+func opaqueOPRF(password, blind, oprfSeed, credentialIdentifier []byte) (blinded, evaluated, output []byte, err error) {
+	seed, err := opaqueExpand(oprfSeed, concat(credentialIdentifier, []byte("OprfKey")), 32)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	oprfKey, err := oprf.DeriveKey(oprf.SuiteRistretto255, oprf.BaseMode, seed, []byte("OPAQUE-DeriveKeyPair"))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("deriving OPRF key: %w", err)
+	}
+	blindScalar, err := opaqueScalar(blind)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	client := oprf.NewClient(oprf.SuiteRistretto255)
+	finData, evalReq, err := client.DeterministicBlind([][]byte{password}, []oprf.Blind{blindScalar})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("blinding: %w", err)
+	}
+	evaluation, err := oprf.NewServer(oprf.SuiteRistretto255, oprfKey).Evaluate(evalReq)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("evaluating: %w", err)
+	}
+	outputs, err := client.Finalize(finData, evaluation)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("finalizing: %w", err)
+	}
+	if blinded, err = evalReq.Elements[0].MarshalBinaryCompress(); err != nil {
+		return nil, nil, nil, err
+	}
+	if evaluated, err = evaluation.Elements[0].MarshalBinaryCompress(); err != nil {
+		return nil, nil, nil, err
+	}
+	return blinded, evaluated, outputs[0], nil
+}
+
+func opaqueCleartextCredentials(serverPublicKey, clientPublicKey, serverIdentity, clientIdentity []byte) []byte {
+	if len(serverIdentity) == 0 {
+		serverIdentity = serverPublicKey
+	}
+	if len(clientIdentity) == 0 {
+		clientIdentity = clientPublicKey
+	}
+	return concat(
+		serverPublicKey,
+		i2osp2(len(serverIdentity)), serverIdentity,
+		i2osp2(len(clientIdentity)), clientIdentity,
+	)
+}
+
+func opaqueExpandLabel(secret []byte, label string, context []byte, length int) ([]byte, error) {
+	fullLabel := "OPAQUE-" + label
+	info := concat(
+		i2osp2(length),
+		[]byte{byte(len(fullLabel))}, []byte(fullLabel),
+		[]byte{byte(len(context))}, context,
+	)
+	return opaqueExpand(secret, info, length)
+}
+
+func opaqueDeriveSecret(secret []byte, label string, transcriptHash []byte) ([]byte, error) {
+	return opaqueExpandLabel(secret, label, transcriptHash, opaqueNh)
+}
+
+type opaqueInput struct {
+	name                 string
+	context              []byte
+	clientIdentity       []byte
+	serverIdentity       []byte
+	password             []byte
+	credentialIdentifier []byte
+	oprfSeed             []byte
+	serverPrivateKey     []byte
+	envelopeNonce        []byte
+	maskingNonce         []byte
+	clientNonce          []byte
+	serverNonce          []byte
+	clientKeyshareSeed   []byte
+	serverKeyshareSeed   []byte
+	blindRegistration    []byte
+	blindLogin           []byte
+}
+
+// opaqueVector runs registration and login. The first error is kept in err
+// and subsequent steps are no-ops.
+func opaqueVector(in opaqueInput) (Vector, error) {
+	var err error
+	expand := func(prk, info []byte, length int) []byte {
+		if err != nil {
+			return nil
+		}
+		var out []byte
+		out, err = opaqueExpand(prk, info, length)
+		return out
+	}
+	deriveSecret := func(secret []byte, label string, transcriptHash []byte) []byte {
+		if err != nil {
+			return nil
+		}
+		var out []byte
+		out, err = opaqueDeriveSecret(secret, label, transcriptHash)
+		return out
+	}
+	deriveKeyPair := func(seed []byte) (group.Scalar, []byte) {
+		if err != nil {
+			return nil, nil
+		}
+		var scalar group.Scalar
+		var publicKey []byte
+		scalar, publicKey, err = opaqueDeriveKeyPair(seed, "OPAQUE-DeriveDiffieHellmanKeyPair")
+		return scalar, publicKey
+	}
+	dh := func(privateKey group.Scalar, publicKey []byte) []byte {
+		if err != nil {
+			return nil
+		}
+		var out []byte
+		out, err = opaqueDH(privateKey, publicKey)
+		return out
+	}
+	runOPRF := func(blind []byte) (blinded, evaluated, output []byte) {
+		if err != nil {
+			return nil, nil, nil
+		}
+		blinded, evaluated, output, err = opaqueOPRF(in.password, blind, in.oprfSeed, in.credentialIdentifier)
+		return blinded, evaluated, output
+	}
+
+	serverPrivateKey, err := opaqueScalar(in.serverPrivateKey)
+	if err != nil {
+		return Vector{}, err
+	}
+	serverPublicKey, err := group.Ristretto255.NewElement().MulGen(serverPrivateKey).MarshalBinaryCompress()
+	if err != nil {
+		return Vector{}, err
+	}
+
+	// Registration
+	registrationRequest, evaluated, oprfOutput := runOPRF(in.blindRegistration)
+	registrationResponse := concat(evaluated, serverPublicKey)
+	randomizedPassword := hkdf.Extract(sha512.New, concat(oprfOutput, oprfOutput), nil)
+	maskingKey := expand(randomizedPassword, []byte("MaskingKey"), opaqueNh)
+	authKey := expand(randomizedPassword, concat(in.envelopeNonce, []byte("AuthKey")), opaqueNh)
+	exportKey := expand(randomizedPassword, concat(in.envelopeNonce, []byte("ExportKey")), opaqueNh)
+	clientSeed := expand(randomizedPassword, concat(in.envelopeNonce, []byte("PrivateKey")), opaqueNseed)
+	clientPrivateKey, clientPublicKey := deriveKeyPair(clientSeed)
+	cleartextCredentials := opaqueCleartextCredentials(serverPublicKey, clientPublicKey, in.serverIdentity, in.clientIdentity)
+	authTag := opaqueMAC(authKey, concat(in.envelopeNonce, cleartextCredentials))
+	envelope := concat(in.envelopeNonce, authTag)
+	registrationUpload := concat(clientPublicKey, maskingKey, envelope)
+
+	// KE1
+	blindedLogin, evaluatedLogin, _ := runOPRF(in.blindLogin)
+	clientSecret, clientKeyshare := deriveKeyPair(in.clientKeyshareSeed)
+	ke1 := concat(blindedLogin, in.clientNonce, clientKeyshare)
+
+	// KE2
+	pad := expand(maskingKey, concat(in.maskingNonce, []byte("CredentialResponsePad")), opaqueNpk+opaqueNn+opaqueNh)
+	maskedResponse := concat(serverPublicKey, envelope)
+	if err == nil {
+		for i := range maskedResponse {
+			maskedResponse[i] ^= pad[i]
+		}
+	}
+	credentialResponse := concat(evaluatedLogin, in.maskingNonce, maskedResponse)
+	serverSecret, serverKeyshare := deriveKeyPair(in.serverKeyshareSeed)
+	serverIdentity := in.serverIdentity
+	if len(serverIdentity) == 0 {
+		serverIdentity = serverPublicKey
+	}
+	clientIdentity := in.clientIdentity
+	if len(clientIdentity) == 0 {
+		clientIdentity = clientPublicKey
+	}
+	preamble := concat(
+		[]byte("OPAQUEv1-"), i2osp2(len(in.context)), in.context,
+		i2osp2(len(clientIdentity)), clientIdentity,
+		ke1,
+		i2osp2(len(serverIdentity)), serverIdentity,
+		credentialResponse,
+		in.serverNonce,
+		serverKeyshare,
+	)
+	ikm := concat(
+		dh(serverSecret, clientKeyshare),
+		dh(serverPrivateKey, clientKeyshare),
+		dh(serverSecret, clientPublicKey),
+	)
+	clientIKM := concat(
+		dh(clientSecret, serverKeyshare),
+		dh(clientSecret, serverPublicKey),
+		dh(clientPrivateKey, serverKeyshare),
+	)
+	if err != nil {
+		return Vector{}, err
+	}
+	if !hmac.Equal(ikm, clientIKM) {
+		return Vector{}, errors.New("client and server disagree on the shared secret")
+	}
+	prk := hkdf.Extract(sha512.New, ikm, nil)
+	preambleHash := opaqueHash(preamble)
+	handshakeSecret := deriveSecret(prk, "HandshakeSecret", preambleHash)
+	sessionKey := deriveSecret(prk, "SessionKey", preambleHash)
+	serverMACKey := deriveSecret(handshakeSecret, "ServerMAC", nil)
+	clientMACKey := deriveSecret(handshakeSecret, "ClientMAC", nil)
+	if err != nil {
+		return Vector{}, err
+	}
+	serverMAC := opaqueMAC(serverMACKey, preambleHash)
+	ke2 := concat(credentialResponse, in.serverNonce, serverKeyshare, serverMAC)
+
+	// KE3
+	clientMAC := opaqueMAC(clientMACKey, opaqueHash(concat(preamble, serverMAC)))
+	ke3 := clientMAC
+
+	return Vector{
+		Name: in.name,
+		Fields: []Field{
+			{"context", in.context},
+			{"clientIdentity", in.clientIdentity},
+			{"serverIdentity", in.serverIdentity},
+			{"password", in.password},
+			{"credentialIdentifier", in.credentialIdentifier},
+			{"oprfSeed", in.oprfSeed},
+			{"serverPrivateKey", in.serverPrivateKey},
+			{"serverPublicKey", serverPublicKey},
+			{"envelopeNonce", in.envelopeNonce},
+			{"maskingNonce", in.maskingNonce},
+			{"clientNonce", in.clientNonce},
+			{"serverNonce", in.serverNonce},
+			{"clientKeyshareSeed", in.clientKeyshareSeed},
+			{"serverKeyshareSeed", in.serverKeyshareSeed},
+			{"blindRegistration", in.blindRegistration},
+			{"blindLogin", in.blindLogin},
+			{"oprfOutput", oprfOutput},
+			{"randomizedPassword", randomizedPassword},
+			{"envelope", envelope},
+			{"clientPublicKey", clientPublicKey},
+			{"maskingKey", maskingKey},
+			{"authKey", authKey},
+			{"handshakeSecret", handshakeSecret},
+			{"serverMacKey", serverMACKey},
+			{"clientMacKey", clientMACKey},
+			{"registrationRequest", registrationRequest},
+			{"registrationResponse", registrationResponse},
+			{"registrationUpload", registrationUpload},
+			{"ke1", ke1},
+			{"ke2", ke2},
+			{"ke3", ke3},
+			{"exportKey", exportKey},
+			{"sessionKey", sessionKey},
+		},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("opaque", opaqueSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func opaqueSuites() ([]*Suite, error) {
+	// Inputs of the "real test vectors" in RFC 9807 appendix C.1.
+	rfc := opaqueInput{
+		context:              []byte("OPAQUE-POC"),
+		password:             []byte("CorrectHorseBatteryStaple"),
+		credentialIdentifier: []byte("1234"),
+		oprfSeed:             mustHex("f433d0227b0b9dd54f7c4422b600e764e47fb503f1f9a0f0a47c6606b054a7fdc65347f1a08f277e22358bbabe26f823fca82c7848e9a75661f4ec5d5c1989ef"),
+		serverPrivateKey:     mustHex("47451a85372f8b3537e249d7b54188091fb18edde78094b43e2ba42b5eb89f0d"),
+		envelopeNonce:        mustHex("ac13171b2f17bc2c74997f0fce1e1f35bec6b91fe2e12dbd323d23ba7a38dfec"),
+		maskingNonce:         mustHex("38fe59af0df2c79f57b8780278f5ae47355fe1f817119041951c80f612fdfc6d"),
+		clientNonce:          mustHex("da7e07376d6d6f034cfa9bb537d11b8c6b4238c334333d1f0aebb380cae6a6cc"),
+		serverNonce:          mustHex("71cd9960ecef2fe0d0f7494986fa3d8b2bb01963537e60efb13981e138e3d4a1"),
+		clientKeyshareSeed:   mustHex("82850a697b42a505f5b68fcdafce8c31f0af2b581f063cf1091933541936304b"),
+		serverKeyshareSeed:   mustHex("05a4f54206eef1ba2f615bc0aa285cb22f26d1153b5b40a1e85ff80da12f982f"),
+		blindRegistration:    mustHex("76cfbfe758db884bebb33582331ba9f159720ca8784a2a070a265d9c2d6abe01"),
+		blindLogin:           mustHex("6ecc102d2e7a7cf49617aad7bbe188556792d4acd60a1a8a8d2b65d4b0790308"),
+	}
+	withoutIdentities := rfc
+	withoutIdentities.name = "without identities"
+	withIdentities := rfc
+	withIdentities.name = "with identities"
+	withIdentities.clientIdentity = []byte("alice")
+	withIdentities.serverIdentity = []byte("bob")
+	s := &Suite{
+		Name: "opaque-3dh: ristretto255-SHA512, identity KSF",
+		Skip: "OPAQUE is not implemented in package:cryptography",
+	}
+	for _, in := range []opaqueInput{withoutIdentities, withIdentities} {
+		v, err := opaqueVector(in)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", in.name, err)
+		}
+		s.Vectors = append(s.Vectors, v)
+	}
+	return []*Suite{s}, nil
+}
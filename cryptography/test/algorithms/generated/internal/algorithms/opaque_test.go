@@ -0,0 +1,18 @@
+package algorithms
+
+import (
+	"testing"
+)
+
+func TestOPAQUE(t *testing.T) {
+	suites, err := opaqueSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 9807 appendix C.1.1.
+	v := findVector(t, suites, "opaque-3dh: ristretto255-SHA512, identity KSF", "without identities")
+	checkHex(t, v, "registrationRequest", "5059ff249eb1551b7ce4991f3336205bde44a105a032e747d21bf382e75f7a71")
+	checkHex(t, v, "exportKey", "1ef15b4fa99e8a852412450ab78713aad30d21fa6966c9b8c9fb3262a970dc62950d4dd4ed62598229b1b72794fc0335199d9f7fcc6eaedde92cc04870e63f16")
+	checkHex(t, v, "sessionKey", "42afde6f5aca0cfa5c163763fbad55e73a41db6b41bc87b8e7b62214a8eedc6731fa3cb857d657ab9b3764b89a84e91ebcb4785166fbb02cedfcbdfda215b96f")
+	checkHex(t, v, "ke3", "4455df4f810ac31a6748835888564b536e6da5d9944dfea9e34defb9575fe5e2661ef61d2ae3929bcf57e53d464113d364365eb7d1a57b629707ca48da18e442")
+}
@@ -0,0 +1,91 @@
+package algorithms
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+)
+
+// CryptoJS.AES.encrypt(message, password) and "openssl enc -aes-256-cbc -md
+// md5": the key and IV are derived from the password and an 8-byte salt with
+// EVP_BytesToKey (MD5, one iteration), and the output is the Base64 of
+// "Salted__", the salt and the AES-256-CBC cipher text. package:cryptography
+// has no MD5, so the Dart test takes the derived key and IV from the vector
+// and checks the format and the encryption.
+
+const opensslSaltedBody = `
+final blob = base64Decode(cipherTextBase64);
+expect(utf8.decode(blob.sublist(0, 8)), 'Salted__');
+expect(hexFromBytes(blob.sublist(8, 16)), hexFromBytes(salt));
+final algorithm = AesCbc.with256bits(macAlgorithm: MacAlgorithm.empty);
+final secretBox = await algorithm.encrypt(
+  utf8.encode(clearText),
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+expect(
+  base64Encode([...utf8.encode('Salted__'), ...salt, ...secretBox.cipherText]),
+  cipherTextBase64,
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(blob.sublist(16), nonce: nonce, mac: Mac.empty),
+  secretKey: SecretKey(secretKey),
+);
+expect(utf8.decode(decrypted), clearText);
+`
+
+// evpBytesToKey derives a key and an IV from the password and the salt like
+// OpenSSL EVP_BytesToKey with MD5 and one iteration.
+func evpBytesToKey(password, salt []byte, keyLength, ivLength int) (key, iv []byte) {
+	var derived, block []byte
+	for len(derived) < keyLength+ivLength {
+		d := md5.New()
+		d.Write(block)
+		d.Write(password)
+		d.Write(salt)
+		block = d.Sum(nil)
+		derived = append(derived, block...)
+	}
+	return derived[:keyLength], derived[keyLength : keyLength+ivLength]
+}
+
+func init() {
+	register(newGenerator("openssl-salted", opensslSaltedSuites).
+		uses("aes_cbc.go", "bytes.go", "suite.go"))
+}
+
+func opensslSaltedSuites() ([]*Suite, error) {
+	s := &Suite{
+		Name:   "openssl salted: aes-256-cbc",
+		Body:   opensslSaltedBody,
+		Covers: []string{"AesCbc secretKeyLength=32 macAlgorithm=empty"},
+	}
+	for i, c := range []struct {
+		name, password, clearText string
+	}{
+		{"short message", "password", "Hello, World!"},
+		{"empty message", "password", ""},
+		{"one block", "password", "0123456789abcdef"},
+		{"empty password", "", "Hello, World!"},
+		{"non-ASCII password and message", "pässwörd", "Grüße aus Köln 👋"},
+		{"long password and message", "correct horse battery staple, but longer than one MD5 block of 64 bytes", "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua."},
+	} {
+		salt := sequence(byte(0x10*i), 8)
+		key, iv := evpBytesToKey([]byte(c.password), salt, 32, 16)
+		cipherText, err := aesCbcEncrypt(key, iv, []byte(c.clearText))
+		if err != nil {
+			return nil, err
+		}
+		s.Vectors = append(s.Vectors, Vector{
+			Name: c.name,
+			Fields: []Field{
+				{"password", c.password},
+				{"salt", salt},
+				{"secretKey", key},
+				{"nonce", iv},
+				{"clearText", c.clearText},
+				{"cipherTextBase64", base64.StdEncoding.EncodeToString(concat([]byte("Salted__"), salt, cipherText))},
+			},
+		})
+	}
+	return []*Suite{s}, nil
+}
@@ -0,0 +1,43 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"encoding/base64"
+	"testing"
+)
+
+func TestOpensslSalted(t *testing.T) {
+	password, salt := []byte("password"), sequence(0, 8)
+	key, iv := evpBytesToKey(password, salt, 32, 16)
+	d0 := md5.Sum(concat(password, salt))
+	d1 := md5.Sum(concat(d0[:], password, salt))
+	d2 := md5.Sum(concat(d1[:], password, salt))
+	if got, want := concat(key, iv), concat(d0[:], d1[:], d2[:]); !bytes.Equal(got, want) {
+		t.Errorf("got %x, want %x", got, want)
+	}
+
+	suites, err := opensslSaltedSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "openssl salted: aes-256-cbc", "short message")
+	blob, err := base64.StdEncoding.DecodeString(v.Fields[5].Value.(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(blob[:16]) != "Salted__"+string(salt) {
+		t.Errorf("header %q", blob[:16])
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clearText := blob[16:]
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(clearText, clearText)
+	if want := pkcs7Pad([]byte("Hello, World!"), 16); !bytes.Equal(clearText, want) {
+		t.Errorf("decrypted %q, want %q", clearText, want)
+	}
+}
@@ -0,0 +1,79 @@
+package algorithms
+
+import (
+	"fmt"
+	"sort"
+)
+
+// canonicalize sorts the suites by name and the vectors of each suite with
+// naturalLess, so the output only depends on the vectors and not on the order
+// in which generators append them. Duplicate suite, vector or field names
+// are rejected because they would make the order ambiguous.
+func canonicalize(suites []*Suite) error {
+	sort.SliceStable(suites, func(i, j int) bool {
+		return suites[i].Name < suites[j].Name
+	})
+	for i, s := range suites {
+		if i > 0 && suites[i-1].Name == s.Name {
+			return fmt.Errorf("duplicate suite %q", s.Name)
+		}
+		sort.SliceStable(s.Vectors, func(i, j int) bool {
+			return naturalLess(s.Vectors[i].Name, s.Vectors[j].Name)
+		})
+		for j, v := range s.Vectors {
+			if j > 0 && s.Vectors[j-1].Name == v.Name {
+				return fmt.Errorf("%s: duplicate vector %q", s.Name, v.Name)
+			}
+			names := make(map[string]bool, len(v.Fields))
+			for _, f := range v.Fields {
+				if names[f.Name] {
+					return fmt.Errorf("%s: %s: duplicate field %q", s.Name, v.Name, f.Name)
+				}
+				names[f.Name] = true
+			}
+		}
+	}
+	return nil
+}
+
+// naturalLess compares strings so that runs of digits are compared by their
+// numeric value ("2 bytes" < "10 bytes").
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		da, db := digitPrefix(a), digitPrefix(b)
+		if da != "" && db != "" {
+			na, nb := trimZeros(da), trimZeros(db)
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			if len(da) != len(db) {
+				return len(da) < len(db)
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func digitPrefix(s string) string {
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+func trimZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
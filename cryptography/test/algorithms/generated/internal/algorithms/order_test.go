@@ -0,0 +1,21 @@
+package algorithms
+
+import "testing"
+
+func TestNaturalLess(t *testing.T) {
+	sorted := []string{"", "0 bytes", "1 byte", "2 bytes", "10 bytes", "RFC 9382", "RFC 9382 inputs", "a", "a1", "a01", "a2"}
+	for i := range sorted {
+		for j := range sorted {
+			if got := naturalLess(sorted[i], sorted[j]); got != (i < j) {
+				t.Errorf("naturalLess(%q, %q) = %v", sorted[i], sorted[j], got)
+			}
+		}
+	}
+}
+
+func TestCanonicalizeRejectsDuplicates(t *testing.T) {
+	err := canonicalize([]*Suite{{Name: "s", Vectors: []Vector{{Name: "v"}, {Name: "v"}}}})
+	if err == nil {
+		t.Error("duplicate vectors were accepted")
+	}
+}
@@ -0,0 +1,213 @@
+package algorithms
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+)
+
+// PASETO v4 tokens with fixed keys and nonces: v4.local encrypts with
+// XChaCha20 and authenticates with keyed BLAKE2b-256, and v4.public signs
+// with Ed25519. Both cover the pre-authentication encoding (PAE) of the
+// header, the payload, the footer and the implicit assertion. Keyed BLAKE2b
+// is not implemented in package:cryptography, so the v4.local Dart tests take
+// the derived keys and the tag from the vectors and check the token layout,
+// the encryption and the PAE.
+
+const pasetoDart = `
+List<int> decode(String value) => base64Url.decode(base64Url.normalize(value));
+List<int> le64(int n) => Uint8List(8)..buffer.asByteData().setUint32(0, n, Endian.little);
+List<int> pae(List<List<int>> pieces) => [
+  ...le64(pieces.length),
+  for (final piece in pieces) ...[...le64(piece.length), ...piece],
+];
+expect(token, startsWith(header));
+final parts = token.substring(header.length).split('.');
+expect(parts, hasLength(footer.isEmpty ? 1 : 2));
+if (footer.isNotEmpty) {
+  expect(utf8.decode(decode(parts[1])), footer);
+}
+final body = decode(parts[0]);
+`
+
+const pasetoLocalBody = `const header = 'v4.local.';` + pasetoDart + `final n = body.sublist(0, 32);
+final c = body.sublist(32, body.length - 32);
+final t = body.sublist(body.length - 32);
+expect(hexFromBytes(n), hexFromBytes(nonce));
+final secretBox = await Xchacha20(macAlgorithm: MacAlgorithm.empty).encrypt(
+  utf8.encode(payload),
+  secretKey: SecretKey(encryptionKey),
+  nonce: encryptionNonce,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(c),
+);
+expect(
+  hexFromBytes(pae([utf8.encode(header), n, c, utf8.encode(footer), utf8.encode(implicit)])),
+  hexFromBytes(preAuth),
+);
+expect(hexFromBytes(t), hexFromBytes(tag));
+`
+
+const pasetoPublicBody = `const header = 'v4.public.';` + pasetoDart + `final m = body.sublist(0, body.length - 64);
+final signature = body.sublist(body.length - 64);
+expect(utf8.decode(m), payload);
+final m2 = pae([utf8.encode(header), m, utf8.encode(footer), utf8.encode(implicit)]);
+final algorithm = Ed25519();
+final keyPair = await algorithm.newKeyPairFromSeed(seed);
+final actual = await algorithm.sign(m2, keyPair: keyPair);
+expect(
+  hexFromBytes(actual.bytes),
+  hexFromBytes(signature),
+);
+expect(
+  await algorithm.verify(
+    m2,
+    signature: Signature(signature, publicKey: await keyPair.extractPublicKey()),
+  ),
+  isTrue,
+);
+`
+
+// pasetoClaims are the payload, footer and implicit assertion of every
+// suite.
+var pasetoClaims = []struct {
+	name, payload, footer, implicit string
+}{
+	{"payload only", `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`, "", ""},
+	{"empty payload", ``, "", ""},
+	{"footer", `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`, `{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`, ""},
+	{"footer and implicit assertion", `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`, `{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`, `{"test-vector":"4-E-3"}`},
+	{"non-ASCII payload", `{"name":"Jürgen 👋"}`, "", ""},
+}
+
+// pasetoPae returns the pre-authentication encoding of the pieces: the
+// number of pieces, then each piece prefixed with its length, as 64-bit
+// little-endian integers with the most significant bit cleared.
+func pasetoPae(pieces ...[]byte) []byte {
+	le64 := func(b []byte, n int) []byte {
+		return binary.LittleEndian.AppendUint64(b, uint64(n)&^(1<<63))
+	}
+	b := le64(nil, len(pieces))
+	for _, piece := range pieces {
+		b = append(le64(b, len(piece)), piece...)
+	}
+	return b
+}
+
+// pasetoToken returns the header, the body and the footer if it is not
+// empty, in base64url without padding.
+func pasetoToken(header string, body []byte, footer string) string {
+	token := header + base64.RawURLEncoding.EncodeToString(body)
+	if footer != "" {
+		token += "." + base64.RawURLEncoding.EncodeToString([]byte(footer))
+	}
+	return token
+}
+
+// pasetoBlake2b returns the keyed BLAKE2b hash with the digest length.
+func pasetoBlake2b(size int, key []byte, data ...[]byte) ([]byte, error) {
+	h, err := blake2b.New(size, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil), nil
+}
+
+// pasetoLocalVector returns a v4.local vector (PASETO v4 specification,
+// "Encrypt").
+func pasetoLocalVector(name string, key, nonce []byte, payload, footer, implicit string) (Vector, error) {
+	const header = "v4.local."
+	tmp, err := pasetoBlake2b(56, key, []byte("paseto-encryption-key"), nonce)
+	if err != nil {
+		return Vector{}, err
+	}
+	encryptionKey, encryptionNonce := tmp[:32], tmp[32:]
+	authenticationKey, err := pasetoBlake2b(32, key, []byte("paseto-auth-key-for-aead"), nonce)
+	if err != nil {
+		return Vector{}, err
+	}
+	stream, err := chacha20.NewUnauthenticatedCipher(encryptionKey, encryptionNonce)
+	if err != nil {
+		return Vector{}, err
+	}
+	cipherText := make([]byte, len(payload))
+	stream.XORKeyStream(cipherText, []byte(payload))
+	preAuth := pasetoPae([]byte(header), nonce, cipherText, []byte(footer), []byte(implicit))
+	tag, err := pasetoBlake2b(32, authenticationKey, preAuth)
+	if err != nil {
+		return Vector{}, err
+	}
+	return Vector{
+		Name: name,
+		Fields: []Field{
+			{"key", key},
+			{"nonce", nonce},
+			{"encryptionKey", encryptionKey},
+			{"encryptionNonce", encryptionNonce},
+			{"authenticationKey", authenticationKey},
+			{"payload", payload},
+			{"footer", footer},
+			{"implicit", implicit},
+			{"preAuth", preAuth},
+			{"tag", tag},
+			{"token", pasetoToken(header, concat(nonce, cipherText, tag), footer)},
+		},
+	}, nil
+}
+
+// pasetoPublicVector returns a v4.public vector (PASETO v4 specification,
+// "Sign").
+func pasetoPublicVector(name string, seed []byte, payload, footer, implicit string) Vector {
+	const header = "v4.public."
+	m2 := pasetoPae([]byte(header), []byte(payload), []byte(footer), []byte(implicit))
+	signature := ed25519.Sign(ed25519.NewKeyFromSeed(seed), m2)
+	return Vector{
+		Name: name,
+		Fields: []Field{
+			{"seed", seed},
+			{"payload", payload},
+			{"footer", footer},
+			{"implicit", implicit},
+			{"token", pasetoToken(header, concat([]byte(payload), signature), footer)},
+		},
+	}
+}
+
+func init() {
+	register(newGenerator("paseto", pasetoSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func pasetoSuites() ([]*Suite, error) {
+	local := &Suite{
+		Name:   "paseto: v4.local",
+		Body:   pasetoLocalBody,
+		Covers: []string{"Xchacha20 macAlgorithm=empty"},
+	}
+	public := &Suite{
+		Name:   "paseto: v4.public",
+		Body:   pasetoPublicBody,
+		Covers: []string{"Ed25519"},
+	}
+	key := sequence(0x70, 32)
+	nonce := sequence(0x80, 32)
+	seed := sequence(0, ed25519.SeedSize)
+	for _, c := range pasetoClaims {
+		v, err := pasetoLocalVector(c.name, key, nonce, c.payload, c.footer, c.implicit)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", local.Name, err)
+		}
+		local.Vectors = append(local.Vectors, v)
+		public.Vectors = append(public.Vectors, pasetoPublicVector(c.name, seed, c.payload, c.footer, c.implicit))
+	}
+	return []*Suite{local, public}, nil
+}
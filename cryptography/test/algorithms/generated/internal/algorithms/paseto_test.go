@@ -0,0 +1,57 @@
+package algorithms
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestPaseto(t *testing.T) {
+	// PASETO common specification, "PAE Definition".
+	for _, c := range []struct {
+		pieces [][]byte
+		want   string
+	}{
+		{nil, "0000000000000000"},
+		{[][]byte{{}}, "01000000000000000000000000000000"},
+		{[][]byte{[]byte("test")}, "0100000000000000040000000000000074657374"},
+	} {
+		if got := hex.EncodeToString(pasetoPae(c.pieces...)); got != c.want {
+			t.Errorf("PAE(%q) is %s, want %s", c.pieces, got, c.want)
+		}
+	}
+
+	// PASETO v4 test vector 4-E-1.
+	v, err := pasetoLocalVector("4-E-1", sequence(0x70, 32), make([]byte, 32),
+		`{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvSwscFlAl1pk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XJ5hOb_4v9RmDkneN0S92dx0OW4pgy7omxgf3S8c3LlQg"; v.Fields[10].Value != want {
+		t.Errorf("token is %s, want %s", v.Fields[10].Value, want)
+	}
+
+	suites, err := pasetoSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v = findVector(t, suites, "paseto: v4.public", "footer and implicit assertion")
+	token := v.Fields[4].Value.(string)
+	parts := strings.Split(strings.TrimPrefix(token, "v4.public."), ".")
+	if len(parts) != 2 {
+		t.Fatalf("token %q has %d parts after the header, want 2", token, len(parts))
+	}
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, signature := body[:len(body)-ed25519.SignatureSize], body[len(body)-ed25519.SignatureSize:]
+	publicKey := ed25519.NewKeyFromSeed(sequence(0, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	m2 := pasetoPae([]byte("v4.public."), m, []byte(v.Fields[2].Value.(string)), []byte(v.Fields[3].Value.(string)))
+	if !ed25519.Verify(publicKey, m2, signature) {
+		t.Error("v4.public signature does not verify")
+	}
+	findVector(t, suites, "paseto: v4.local", "empty payload")
+}
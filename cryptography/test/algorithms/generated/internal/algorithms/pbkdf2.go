@@ -0,0 +1,163 @@
+package algorithms
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// PBKDF2 (RFC 8018) with HMAC. Dart Pbkdf2 takes the output length in bits
+// and requires at least 64 bits.
+
+const pbkdf2Body = `
+final pbkdf2 = Pbkdf2(
+  macAlgorithm: %s,
+  iterations: iterations,
+  bits: 8 * expected.length,
+);
+final secretKey = await pbkdf2.deriveKey(
+  secretKey: SecretKey(password),
+  nonce: salt,
+);
+expect(
+  hexFromBytes(await secretKey.extractBytes()),
+  hexFromBytes(expected),
+);
+`
+
+// Passwords are strings in most applications, so the Dart tests of
+// kdfStrings encode them with utf8.encode and check the bytes first.
+const pbkdf2StringBody = `
+final passwordBytes = utf8.encode(password);
+final saltBytes = utf8.encode(salt);
+expect(hexFromBytes(passwordBytes), hexFromBytes(passwordUtf8));
+expect(hexFromBytes(saltBytes), hexFromBytes(saltUtf8));
+final pbkdf2 = Pbkdf2(
+  macAlgorithm: %s,
+  iterations: iterations,
+  bits: 8 * expected.length,
+);
+final secretKey = await pbkdf2.deriveKey(
+  secretKey: SecretKey(passwordBytes),
+  nonce: saltBytes,
+);
+expect(
+  hexFromBytes(await secretKey.extractBytes()),
+  hexFromBytes(expected),
+);
+`
+
+// kdfStrings are passwords and salts with multi-byte UTF-8 encodings,
+// characters outside the Basic Multilingual Plane (surrogate pairs in Dart
+// strings), NUL and the two Unicode forms of the same character.
+var kdfStrings = []string{
+	"p\u00e4ssw\u00f6rd",
+	"\u30d1\u30b9\u30ef\u30fc\u30c9",
+	"\U0001f511 key",
+	"pass\x00word",
+	"\u00e9",
+	"e\u0301",
+}
+
+// kdfBinaryInputs are passwords and salts that are not valid UTF-8.
+var kdfBinaryInputs = [][]byte{
+	make([]byte, 8),
+	sequence(0x80, 16),
+	{0xc3, 0x28},
+	{0xff, 0xfe, 0xfd},
+	{0xed, 0xa0, 0x80},
+}
+
+var pbkdf2Hmacs = []kdfHmac{
+	{"sha1", "Hmac(Sha1())", "Hmac.sha1", sha1.New},
+	{"sha256", "Hmac.sha256()", "Hmac.sha256", sha256.New},
+	{"sha512", "Hmac.sha512()", "Hmac.sha512", sha512.New},
+}
+
+// pbkdf2Vector returns a vector for the inputs. Every output block costs
+// two hash compressions per iteration.
+func pbkdf2Vector(h kdfHmac, name string, password, salt []byte, iterations, length int) Vector {
+	hashLength := h.new().Size()
+	blocks := (length + hashLength - 1) / hashLength
+	return Vector{
+		Name: name,
+		Fields: []Field{
+			{"password", password},
+			{"salt", salt},
+			{"iterations", iterations},
+			{"expected", pbkdf2.Key(password, salt, iterations, length, h.new)},
+		},
+		Cost: 2 * iterations * blocks,
+	}
+}
+
+func init() {
+	register(newGenerator("pbkdf2", pbkdf2Suites).
+		uses("bytes.go", "dart.go", "hkdf.go", "suite.go"))
+}
+
+func pbkdf2Suites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, h := range pbkdf2Hmacs {
+		s := &Suite{
+			Name:   "pbkdf2-hmac-" + h.name,
+			Body:   fmt.Sprintf(pbkdf2Body, h.hmac),
+			Covers: []string{"Pbkdf2 macAlgorithm=" + h.covers},
+		}
+		// The password and salt of RFC 6070.
+		password := []byte("password")
+		salt := []byte("salt")
+		hashLength := h.new().Size()
+		for _, iterations := range []int{1, 2, 4096, 100000} {
+			for _, length := range []int{8, hashLength, hashLength + 1, 2*hashLength + 3} {
+				name := fmt.Sprintf("%s, %d-byte output", describeCount(iterations, "iteration"), length)
+				s.Vectors = append(s.Vectors, pbkdf2Vector(h, name, password, salt, iterations, length))
+			}
+		}
+
+		text := &Suite{
+			Name:   "pbkdf2-hmac-" + h.name + ": utf-8 passwords and salts",
+			Body:   fmt.Sprintf(pbkdf2StringBody, h.hmac),
+			Covers: s.Covers,
+		}
+		binary := &Suite{
+			Name:   "pbkdf2-hmac-" + h.name + ": binary passwords and salts",
+			Body:   s.Body,
+			Covers: s.Covers,
+		}
+		for _, input := range kdfStrings {
+			for _, tc := range []struct {
+				name           string
+				password, salt string
+			}{
+				{fmt.Sprintf("password %+q", input), input, "salt"},
+				{fmt.Sprintf("salt %+q", input), "password", input},
+			} {
+				v := Vector{
+					Name: tc.name,
+					Fields: []Field{
+						{"password", tc.password},
+						{"salt", tc.salt},
+						{"passwordUtf8", []byte(tc.password)},
+						{"saltUtf8", []byte(tc.salt)},
+						{"iterations", 2},
+						{"expected", pbkdf2.Key([]byte(tc.password), []byte(tc.salt), 2, hashLength, h.new)},
+					},
+					Cost: 4,
+				}
+				text.Vectors = append(text.Vectors, v)
+			}
+		}
+		for _, input := range kdfBinaryInputs {
+			binary.Vectors = append(binary.Vectors,
+				pbkdf2Vector(h, fmt.Sprintf("password %x", input), input, salt, 2, hashLength),
+				pbkdf2Vector(h, fmt.Sprintf("salt %x", input), password, input, 2, hashLength),
+			)
+		}
+		suites = append(suites, s, text, binary)
+	}
+	return suites, nil
+}
@@ -0,0 +1,42 @@
+package algorithms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPbkdf2(t *testing.T) {
+	suites, err := pbkdf2Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 6070.
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{"1 iteration, 20-byte output", "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{"2 iterations, 20-byte output", "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+		{"4096 iterations, 20-byte output", "4b007901b765489abead49d926f721d065a429c1"},
+	} {
+		checkHex(t, findVector(t, suites, "pbkdf2-hmac-sha1", tc.name), "expected", tc.want)
+	}
+}
+
+func TestKdfStrings(t *testing.T) {
+	pbkdf2, err := pbkdf2Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// U+1F511 is four bytes in UTF-8.
+	v := findVector(t, pbkdf2, "pbkdf2-hmac-sha256: utf-8 passwords and salts", `password "\U0001f511 key"`)
+	checkHex(t, v, "passwordUtf8", "f09f9491206b6579")
+	hkdf, err := hkdfSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The decomposed form is a different input.
+	composed := findVector(t, hkdf, "hkdf-sha256: utf-8 inputs", fmt.Sprintf("info %+q", "\u00e9"))
+	decomposed := findVector(t, hkdf, "hkdf-sha256: utf-8 inputs", fmt.Sprintf("info %+q", "e\u0301"))
+	checkHex(t, composed, "infoUtf8", "c3a9")
+	checkHex(t, decomposed, "infoUtf8", "65cc81")
+}
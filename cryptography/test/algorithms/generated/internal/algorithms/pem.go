@@ -0,0 +1,183 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// PEM and DER encodings of the keys of the JWK suites: PKCS#8 private keys,
+// SPKI public keys, SEC1 EC private keys and PKCS#1 RSA keys, as written by
+// encoding/x509 and encoding/pem. package:cryptography does not parse them
+// yet, so the Dart tests check the PEM armor and the position of the raw key
+// in the DER, which is where a parser finds it.
+
+const pemBody = `
+final lines = pem.trimRight().split('\n');
+expect(lines.first, '-----BEGIN $label-----');
+expect(lines.last, '-----END $label-----');
+final base64Lines = lines.sublist(1, lines.length - 1);
+for (final line in base64Lines) {
+  expect(line.length, lessThanOrEqualTo(64));
+}
+expect(
+  hexFromBytes(base64Decode(base64Lines.join())),
+  hexFromBytes(der),
+);
+expect(
+  hexFromBytes(der.sublist(rawKeyOffset, rawKeyOffset + rawKey.length)),
+  hexFromBytes(rawKey),
+);
+`
+
+// pemEncoding is a key in one format.
+type pemEncoding struct {
+	// name is the vector name, such as "pkcs8".
+	name string
+
+	// label is the PEM type, such as "PRIVATE KEY".
+	label string
+
+	der []byte
+
+	// rawKey is the key value that the DER contains, such as the private
+	// scalar or the public point.
+	rawKey []byte
+}
+
+// pemVector returns a vector of the encoding.
+func pemVector(e pemEncoding) (Vector, error) {
+	offset := bytes.Index(e.der, e.rawKey)
+	if offset < 0 {
+		return Vector{}, fmt.Errorf("%s: the DER does not contain the raw key", e.name)
+	}
+	return Vector{
+		Name: e.name,
+		Fields: []Field{
+			{"label", e.label},
+			{"pem", string(pem.EncodeToMemory(&pem.Block{Type: e.label, Bytes: e.der}))},
+			{"der", e.der},
+			{"rawKey", e.rawKey},
+			{"rawKeyOffset", offset},
+		},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("pem", pemSuites).
+		uses("bytes.go", "ecdh.go", "rsa.go", "suite.go"))
+}
+
+func pemSuites() ([]*Suite, error) {
+	var suites []*Suite
+	add := func(name string, encodings ...pemEncoding) error {
+		s := &Suite{Name: "pem: " + name, Body: pemBody}
+		for _, e := range encodings {
+			v, err := pemVector(e)
+			if err != nil {
+				return fmt.Errorf("%s: %w", s.Name, err)
+			}
+			s.Vectors = append(s.Vectors, v)
+		}
+		suites = append(suites, s)
+		return nil
+	}
+
+	for _, c := range nistCurves {
+		d := c.sequenceScalar(0x40)
+		privateKey, err := ecdsa.ParseRawPrivateKey(c.elliptic, d)
+		if err != nil {
+			return nil, err
+		}
+		point, err := privateKey.PublicKey.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		spki, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		sec1, err := x509.MarshalECPrivateKey(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := add("ec p-"+strings.TrimPrefix(c.name, "p"),
+			pemEncoding{"pkcs8", "PRIVATE KEY", pkcs8, d},
+			pemEncoding{"spki", "PUBLIC KEY", spki, point},
+			pemEncoding{"sec1", "EC PRIVATE KEY", sec1, d},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	seed := sequence(0, ed25519.SeedSize)
+	ed25519Key := ed25519.NewKeyFromSeed(seed)
+	ed25519Public := ed25519Key.Public().(ed25519.PublicKey)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	if err != nil {
+		return nil, err
+	}
+	spki, err := x509.MarshalPKIXPublicKey(ed25519Public)
+	if err != nil {
+		return nil, err
+	}
+	if err := add("okp ed25519",
+		pemEncoding{"pkcs8", "PRIVATE KEY", pkcs8, seed},
+		pemEncoding{"spki", "PUBLIC KEY", spki, ed25519Public},
+	); err != nil {
+		return nil, err
+	}
+
+	x25519Key, err := ecdh.X25519().NewPrivateKey(sequence(0x40, 32))
+	if err != nil {
+		return nil, err
+	}
+	pkcs8, err = x509.MarshalPKCS8PrivateKey(x25519Key)
+	if err != nil {
+		return nil, err
+	}
+	spki, err = x509.MarshalPKIXPublicKey(x25519Key.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	if err := add("okp x25519",
+		pemEncoding{"pkcs8", "PRIVATE KEY", pkcs8, x25519Key.Bytes()},
+		pemEncoding{"spki", "PUBLIC KEY", spki, x25519Key.PublicKey().Bytes()},
+	); err != nil {
+		return nil, err
+	}
+
+	for _, bits := range rsaKeySizes {
+		key, err := rsaKey(bits)
+		if err != nil {
+			return nil, err
+		}
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		n := key.N.Bytes()
+		if err := add(fmt.Sprintf("rsa %d-bit key", bits),
+			pemEncoding{"pkcs8", "PRIVATE KEY", pkcs8, n},
+			pemEncoding{"spki", "PUBLIC KEY", spki, n},
+			pemEncoding{"pkcs1 private key", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), n},
+			pemEncoding{"pkcs1 public key", "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&key.PublicKey), n},
+		); err != nil {
+			return nil, err
+		}
+	}
+	return suites, nil
+}
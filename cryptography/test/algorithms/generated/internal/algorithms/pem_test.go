@@ -0,0 +1,33 @@
+package algorithms
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestPem(t *testing.T) {
+	suites, err := pemSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "pem: okp ed25519", "pkcs8")
+	values := map[string]any{}
+	for _, f := range v.Fields {
+		values[f.Name] = f.Value
+	}
+	block, rest := pem.Decode([]byte(values["pem"].(string)))
+	if block == nil || len(rest) != 0 {
+		t.Fatalf("pem is not one block")
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 8410 section 10.3: the seed is the last 32 bytes.
+	seed := privateKey.(ed25519.PrivateKey).Seed()
+	if offset := values["rawKeyOffset"].(int); offset != len(block.Bytes)-len(seed) {
+		t.Errorf("seed is at %d, want %d", offset, len(block.Bytes)-len(seed))
+	}
+}
@@ -0,0 +1,37 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/chacha20"
+)
+
+// Clear texts, messages and other inputs that have no special value are
+// pseudorandom, so a mistake that only shows with non-zero bytes is found.
+// They are reproducible: the bytes only depend on the -seed flag and a
+// label, such as the suite name followed by the field name. Inputs do not
+// depend on which generators run or in which order, and an input of n bytes
+// is the prefix of longer inputs with the same label. The seed is written in
+// the header of the Dart file.
+
+// DefaultSeed is the default value of the -seed flag.
+const DefaultSeed = 1
+
+// Seed is the seed of the pseudorandom inputs.
+var Seed uint64 = DefaultSeed
+
+// randomBytes returns n pseudorandom bytes for the label. They are the
+// ChaCha20 key stream with SHA-256 of the seed and label as the key and a
+// zero nonce.
+func randomBytes(label string, n int) []byte {
+	key := sha256.Sum256(concat(binary.BigEndian.AppendUint64(nil, Seed), []byte(label)))
+	c, err := chacha20.NewUnauthenticatedCipher(key[:], make([]byte, chacha20.NonceSize))
+	if err != nil {
+		// The key and nonce have valid lengths.
+		panic(err)
+	}
+	out := make([]byte, n)
+	c.XORKeyStream(out, out)
+	return out
+}
@@ -0,0 +1,36 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"slices"
+	"testing"
+)
+
+func TestRandomBytes(t *testing.T) {
+	defer func(s uint64) { Seed = s }(Seed)
+	Seed = 1
+	// The first ChaCha20 block of SHA-256 of the seed and label as the key.
+	key := sha256.Sum256(concat([]byte{0, 0, 0, 0, 0, 0, 0, 1}, []byte("label")))
+	block, err := chacha20XOR(key[:], make([]byte, 12), make([]byte, 64), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := randomBytes("label", 64); !bytes.Equal(got, block) {
+		t.Errorf("got %x, want %x", got, block)
+	}
+	// Shorter inputs are prefixes and other labels differ.
+	if got := randomBytes("label", 3); !bytes.Equal(got, block[:3]) {
+		t.Errorf("got %x, want %x", got, block[:3])
+	}
+	if bytes.Equal(randomBytes("other", 64), block) {
+		t.Error("the label does not change the bytes")
+	}
+	Seed = 2
+	if bytes.Equal(randomBytes("label", 64), block) {
+		t.Error("the seed does not change the bytes")
+	}
+	if flags := GeneratorFlags(registry); !slices.Contains(flags, "seed=2") {
+		t.Errorf("the flags do not have the seed: %q", flags)
+	}
+}
@@ -0,0 +1,222 @@
+// Package algorithms has the generators of the test vectors. A generator
+// returns suites, which are lists of vectors with a snippet of Dart code that
+// checks package:cryptography with every vector.
+package algorithms
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Generators register themselves in an init function of the file that
+// implements them:
+//
+//	func init() {
+//		register(newGenerator("aes-gcm", aesGcmSuites, spec.AesKeyLengths, spec.Seed).
+//			uses("bytes.go", "chacha20_poly1305.go", "dart.go", "prng.go", "spec.go", "suite.go"))
+//	}
+//
+// cmd/vectorgen runs every registered generator, so adding an algorithm
+// family only adds a file and its test file. The cached suites of a
+// generator are used until its file, the files in uses or its spec options
+// change.
+
+// Generator returns the suites of an algorithm family.
+type Generator interface {
+	// Name is the name of the generator in the -algorithms and -skip flags,
+	// the log and the cache. It is in kebab case, such as "aes-gcm".
+	Name() string
+
+	// Params returns what the suites depend on, except the modules. The
+	// cached suites are used while it does not change.
+	Params() Params
+
+	// Generate returns the suites.
+	Generate() ([]*Suite, error)
+}
+
+// Params are the inputs of a generator.
+type Params struct {
+	// Flags are the command line flags that change the suites, such as
+	// "cycles=1000".
+	Flags []string
+
+	// Files are the Go files of this package that the suites depend on: the
+	// file of the generator and the files of the helpers it calls, such as
+	// "aes_cbc.go". Changing another file does not run the generator again.
+	Files []string
+}
+
+// registry has the registered generators in registration order.
+var registry []Generator
+
+// generatorNamePattern matches the names of generators.
+var generatorNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// register adds a generator to the registry. It panics if the name is not in
+// kebab case or is already registered.
+func register(g Generator) {
+	if !generatorNamePattern.MatchString(g.Name()) {
+		panic(fmt.Sprintf("generator name %q is not in kebab case", g.Name()))
+	}
+	for _, r := range registry {
+		if r.Name() == g.Name() {
+			panic(fmt.Sprintf("generator %q is registered twice", g.Name()))
+		}
+	}
+	registry = append(registry, g)
+}
+
+// funcGenerator is a generator whose parameters are its spec options and
+// files.
+type funcGenerator struct {
+	name    string
+	suites  func() ([]*Suite, error)
+	options []string
+	files   []string
+}
+
+// newGenerator returns a generator that calls suites. The options are the
+// spec options that suites uses, such as spec.AesKeyLengths. The file that
+// calls newGenerator is the first of the files of the generator; the files
+// of the helpers are added with uses.
+func newGenerator(name string, suites func() ([]*Suite, error), options ...string) funcGenerator {
+	g := funcGenerator{name: name, suites: suites, options: options}
+	if _, file, _, ok := runtime.Caller(1); ok {
+		g.files = []string{filepath.Base(file)}
+	}
+	return g
+}
+
+// uses returns the generator with the files of the helpers that its suites
+// call, such as "aes_cbc.go" for aesCbcVector. TestGeneratorFiles checks
+// that the list is complete.
+func (g funcGenerator) uses(files ...string) funcGenerator {
+	g.files = append(slices.Clip(g.files), files...)
+	return g
+}
+
+func (g funcGenerator) Name() string { return g.name }
+
+func (g funcGenerator) Params() Params {
+	p := Params{Files: g.files}
+	for _, option := range g.options {
+		p.Flags = append(p.Flags, specFlag(option))
+	}
+	return p
+}
+
+func (g funcGenerator) Generate() ([]*Suite, error) { return g.suites() }
+
+// GeneratorFlags returns the sorted flags of the parameters of the
+// generators, without duplicates.
+func GeneratorFlags(generators []Generator) []string {
+	seen := map[string]bool{}
+	var flags []string
+	for _, g := range generators {
+		for _, f := range g.Params().Flags {
+			if !seen[f] {
+				seen[f] = true
+				flags = append(flags, f)
+			}
+		}
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+// SelectGenerators returns the registered generators that are named in the
+// comma-separated list algorithms, or every generator if it is empty, except
+// those named in skip.
+func SelectGenerators(algorithms, skip string) ([]Generator, error) {
+	known := map[string]bool{}
+	for _, g := range registry {
+		known[g.Name()] = true
+	}
+	parse := func(option, list string) (map[string]bool, error) {
+		names := map[string]bool{}
+		for _, name := range strings.Split(list, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				return nil, fmt.Errorf("unknown generator %q in -%s", name, option)
+			}
+			names[name] = true
+		}
+		return names, nil
+	}
+	selected, err := parse("algorithms", algorithms)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := parse("skip", skip)
+	if err != nil {
+		return nil, err
+	}
+	var result []Generator
+	for _, g := range registry {
+		if (len(selected) == 0 || selected[g.Name()]) && !skipped[g.Name()] {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+// CollectSuites runs the generators and returns the suites in canonical
+// order. If cache is not nil, generators whose hash has not changed are not
+// run and the cache is updated with the new suites.
+func CollectSuites(generators []Generator, cache *Cache) ([]*Suite, error) {
+	var suites []*Suite
+	for _, g := range generators {
+		s, err := runGenerator(g, cache)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", g.Name(), err)
+		}
+		suites = append(suites, s...)
+	}
+	if err := canonicalize(suites); err != nil {
+		return nil, err
+	}
+	return suites, nil
+}
+
+func runGenerator(g Generator, cache *Cache) ([]*Suite, error) {
+	var hash string
+	if cache != nil {
+		var err error
+		if hash, err = generatorHash(g); err != nil {
+			return nil, err
+		}
+		if cached, ok := cache.Generators[g.Name()]; ok && cached.Hash == hash {
+			slog.Info("cached", "generator", g.Name())
+			return suitesFromCache(cached.Suites)
+		}
+	}
+	start := time.Now()
+	s, err := g.Generate()
+	if err != nil {
+		return nil, err
+	}
+	vectors := 0
+	for _, x := range s {
+		vectors += len(x.Vectors)
+	}
+	slog.Info("generated", "generator", g.Name(), "suites", len(s), "vectors", vectors, "duration", time.Since(start))
+	if cache != nil {
+		cached, err := suitesToCache(s)
+		if err != nil {
+			return nil, err
+		}
+		cache.Generators[g.Name()] = cachedGenerator{Hash: hash, Suites: cached}
+	}
+	return s, nil
+}
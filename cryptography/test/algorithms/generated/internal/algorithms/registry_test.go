@@ -0,0 +1,319 @@
+package algorithms
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// registered returns the registered generator with the name.
+func registered(t *testing.T, name string) Generator {
+	t.Helper()
+	for _, g := range registry {
+		if g.Name() == name {
+			return g
+		}
+	}
+	t.Fatalf("no generator %q", name)
+	return nil
+}
+
+// TestGeneratorFiles checks that the files of every generator are the files
+// of the declarations that its suites reach, so that a change of a helper
+// runs the generators that call it and no other generator.
+func TestGeneratorFiles(t *testing.T) {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// decls has the package-level declarations by name. Methods are
+	// declarations of their receiver type.
+	type decl struct {
+		file string
+		node ast.Node
+	}
+	decls := map[string][]decl{}
+	topLevel := map[any]bool{}
+	var inits []decl
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				topLevel[d] = true
+				switch {
+				case d.Recv != nil:
+					typ := d.Recv.List[0].Type
+					if star, ok := typ.(*ast.StarExpr); ok {
+						typ = star.X
+					}
+					name := typ.(*ast.Ident).Name
+					decls[name] = append(decls[name], decl{path, d})
+				case d.Name.Name == "init":
+					inits = append(inits, decl{path, d})
+				default:
+					decls[d.Name.Name] = append(decls[d.Name.Name], decl{path, d})
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					topLevel[spec] = true
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						decls[spec.Name.Name] = append(decls[spec.Name.Name], decl{path, spec})
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							decls[name.Name] = append(decls[name.Name], decl{path, spec})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// refs returns the package-level names that the node refers to. The
+	// parser resolves local names but not those of other files.
+	refs := func(node ast.Node) []string {
+		var names []string
+		var visit func(ast.Node) bool
+		visit = func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				ast.Inspect(n.X, visit)
+				return false
+			case *ast.Ident:
+				if _, ok := decls[n.Name]; ok && (n.Obj == nil || topLevel[n.Obj.Decl]) {
+					names = append(names, n.Name)
+				}
+			}
+			return true
+		}
+		ast.Inspect(node, visit)
+		return names
+	}
+	files := func(roots []string) []string {
+		seen := map[string]bool{}
+		var result []string
+		var visit func(string)
+		visit = func(name string) {
+			if seen[name] {
+				return
+			}
+			seen[name] = true
+			for _, d := range decls[name] {
+				result = append(result, d.file)
+				for _, r := range refs(d.node) {
+					visit(r)
+				}
+			}
+		}
+		for _, r := range roots {
+			visit(r)
+		}
+		sort.Strings(result)
+		return slices.Compact(result)
+	}
+
+	checked := map[string]bool{}
+	check := func(name, file string, roots []string) {
+		got := slices.Clone(registered(t, name).Params().Files)
+		sort.Strings(got)
+		want := files(roots)
+		if !slices.Contains(want, file) {
+			want = append(want, file)
+			sort.Strings(want)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("generator %s: files %q, want %q", name, got, want)
+		}
+		checked[name] = true
+	}
+	for _, init := range inits {
+		ast.Inspect(init.node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if id, ok := n.Fun.(*ast.Ident); ok && id.Name == "newGenerator" {
+					name, err := strconv.Unquote(n.Args[0].(*ast.BasicLit).Value)
+					if err != nil {
+						t.Fatal(err)
+					}
+					check(name, init.file, refs(n.Args[1]))
+					return false
+				}
+			case *ast.CompositeLit:
+				// A generator type, such as cycleGenerator, whose suites are
+				// returned by its Generate method.
+				typ, ok := n.Type.(*ast.Ident)
+				if !ok {
+					break
+				}
+				var g Generator
+				for _, r := range registry {
+					if strings.TrimPrefix(fmt.Sprintf("%T", r), "algorithms.") == typ.Name {
+						g = r
+					}
+				}
+				for _, d := range decls[typ.Name] {
+					if f, ok := d.node.(*ast.FuncDecl); ok && g != nil && f.Name.Name == "Generate" {
+						check(g.Name(), init.file, refs(f.Body))
+					}
+				}
+			}
+			return true
+		})
+	}
+	for _, g := range registry {
+		if !checked[g.Name()] {
+			t.Errorf("generator %s is not registered in an init function", g.Name())
+		}
+	}
+}
+
+// TestGeneratorHash checks that a generator runs again when one of its files
+// changes and not when the file of another generator changes.
+func TestGeneratorHash(t *testing.T) {
+	hashes := func() map[string]string {
+		result := map[string]string{}
+		for _, g := range registry {
+			h, err := generatorHash(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result[g.Name()] = h
+		}
+		return result
+	}
+	before := hashes()
+
+	changed := fstest.MapFS{}
+	if err := fs.WalkDir(embedded, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(embedded, path)
+		if path == "aes_ctr.go" {
+			data = append(data, "\n// A change.\n"...)
+		}
+		changed[path] = &fstest.MapFile{Data: data}
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer func(fsys fs.FS) { sources = fsys }(sources)
+	sources = changed
+	after := hashes()
+
+	for name, hash := range before {
+		uses := slices.Contains(registered(t, name).Params().Files, "aes_ctr.go")
+		if (after[name] != hash) != uses {
+			t.Errorf("generator %s: hash changed %v, uses aes_ctr.go %v", name, after[name] != hash, uses)
+		}
+	}
+	if before["aes-ctr"] == after["aes-ctr"] {
+		t.Error("the hash of aes-ctr does not depend on aes_ctr.go")
+	}
+	if before["aes-monte-carlo"] != after["aes-monte-carlo"] || before["cycles"] != after["cycles"] {
+		t.Error("the hash of the Monte Carlo or cycle suites depends on aes_ctr.go")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	for _, g := range registry {
+		if _, err := generatorHash(g); err != nil {
+			t.Error(err)
+		}
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("no panic for a duplicate name")
+			}
+		}()
+		register(newGenerator("cycles", nil))
+	}()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("no panic for a name with a space")
+			}
+		}()
+		register(newGenerator("key commitment", nil))
+	}()
+
+	// Only the cycle suites depend on the -cycles flag.
+	hashes := func() (string, string) {
+		a, err := generatorHash(cycleGenerator{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := generatorHash(registered(t, "key-commitment"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a, b
+	}
+	cycles1, other1 := hashes()
+	defer func(n int) { Cycles = n }(Cycles)
+	Cycles++
+	cycles2, other2 := hashes()
+	if cycles1 == cycles2 {
+		t.Error("the hash of the cycle generator does not depend on -cycles")
+	}
+	if other1 != other2 {
+		t.Error("the hash of another generator depends on -cycles")
+	}
+}
+
+func TestSelectGenerators(t *testing.T) {
+	names := func(generators []Generator) string {
+		var names []string
+		for _, g := range generators {
+			names = append(names, g.Name())
+		}
+		return strings.Join(names, ",")
+	}
+	all, err := SelectGenerators("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(registry) {
+		t.Errorf("got %d generators, want %d", len(all), len(registry))
+	}
+	// The order is the registration order.
+	got, err := SelectGenerators("hash, blake2b", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names(got) != "blake2b,hash" {
+		t.Errorf("got %q", names(got))
+	}
+	got, err = SelectGenerators("blake2b,hash", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names(got) != "blake2b" {
+		t.Errorf("got %q", names(got))
+	}
+	if _, err := SelectGenerators("hmac-sha256", ""); err == nil {
+		t.Error("no error for an unknown generator")
+	}
+	if _, err := SelectGenerators("", "blake2"); err == nil {
+		t.Error("no error for an unknown skipped generator")
+	}
+
+}
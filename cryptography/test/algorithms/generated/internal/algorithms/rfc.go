@@ -0,0 +1,357 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/md5"
+	"fmt"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/poly1305"
+)
+
+// The test vectors of RFC 2104, RFC 5869, RFC 7748, RFC 8032, RFC 8439 and
+// RFC 9106, copied from the RFCs. Unlike the other suites, the expected
+// values are not computed by the generator: the Dart tests check them as
+// published, and selfCheck checks that Go computes them too, so a mistake
+// in the copies or in Go is found before anything is written.
+
+const rfcX25519Body = `
+final algorithm = X25519();
+final keyPair = await algorithm.newKeyPairFromSeed(scalar);
+final sharedSecretKey = await algorithm.sharedSecretKey(
+  keyPair: keyPair,
+  remotePublicKey: SimplePublicKey(u, type: KeyPairType.x25519),
+);
+expect(
+  hexFromBytes(await sharedSecretKey.extractBytes()),
+  hexFromBytes(expected),
+);
+`
+
+const rfcPoly1305Body = `
+final mac = await Poly1305().calculateMac(
+  data,
+  secretKey: SecretKey(secretKey),
+);
+expect(
+  hexFromBytes(mac.bytes),
+  hexFromBytes(expected),
+);
+`
+
+// rfcVector is a test vector of an RFC.
+type rfcVector struct {
+	Vector
+
+	// want is the output in the RFC and compute returns it with Go.
+	want    []byte
+	compute func() ([]byte, error)
+}
+
+// rfcSuite is a suite of rfcVectors.
+type rfcSuite struct {
+	Suite
+	vectors []rfcVector
+}
+
+func rfcHmacMd5Vector(name string, key, data []byte, expected string) rfcVector {
+	return rfcVector{
+		Vector: Vector{Name: name, Fields: []Field{
+			{"secretKey", key},
+			{"data", data},
+			{"expected", mustHex(expected)},
+		}},
+		want: mustHex(expected),
+		compute: func() ([]byte, error) {
+			mac := hmac.New(md5.New, key)
+			mac.Write(data)
+			return mac.Sum(nil), nil
+		},
+	}
+}
+
+func rfcHkdfVector(name string, h kdfHmac, ikm, salt, info []byte, expected string) rfcVector {
+	want := mustHex(expected)
+	return rfcVector{
+		Vector: Vector{Name: name, Fields: []Field{
+			{"secretKey", ikm},
+			{"salt", salt},
+			{"info", info},
+			{"expected", want},
+		}},
+		want: want,
+		compute: func() ([]byte, error) {
+			return hkdfDerive(h, ikm, salt, info, len(want))
+		},
+	}
+}
+
+func rfcX25519Vector(name, scalar, u, expected string) rfcVector {
+	return rfcVector{
+		Vector: Vector{Name: name, Fields: []Field{
+			{"scalar", mustHex(scalar)},
+			{"u", mustHex(u)},
+			{"expected", mustHex(expected)},
+		}},
+		want: mustHex(expected),
+		compute: func() ([]byte, error) {
+			return curve25519.X25519(mustHex(scalar), mustHex(u))
+		},
+	}
+}
+
+func rfcEd25519Vector(name, secretKey, publicKey, message, signature string) rfcVector {
+	return rfcVector{
+		Vector: Vector{Name: name, Fields: []Field{
+			{"seed", mustHex(secretKey)},
+			{"publicKey", mustHex(publicKey)},
+			{"message", mustHex(message)},
+			{"expected", mustHex(signature)},
+		}},
+		want: mustHex(publicKey + signature),
+		compute: func() ([]byte, error) {
+			privateKey := ed25519.NewKeyFromSeed(mustHex(secretKey))
+			return concat(privateKey.Public().(ed25519.PublicKey), ed25519.Sign(privateKey, mustHex(message))), nil
+		},
+	}
+}
+
+func rfcArgon2Vector(typ int, expected string) rfcVector {
+	password := bytes.Repeat([]byte{1}, 32)
+	salt := bytes.Repeat([]byte{2}, 16)
+	secret := bytes.Repeat([]byte{3}, 8)
+	data := bytes.Repeat([]byte{4}, 12)
+	return rfcVector{
+		Vector: Vector{Name: "section 5, " + argon2TypeNames[typ], Fields: []Field{
+			{"password", password},
+			{"salt", salt},
+			{"secret", secret},
+			{"associatedData", data},
+			{"memorySize", 32},
+			{"iterations", 3},
+			{"parallelism", 4},
+			{"expected", mustHex(expected)},
+		}},
+		want: mustHex(expected),
+		compute: func() ([]byte, error) {
+			return argon2Key(typ, password, salt, secret, data, 3, 32, 4, 32), nil
+		},
+	}
+}
+
+// rfc8439SunscreenText is the clear text of RFC 8439 sections 2.4.2 and
+// 2.8.2.
+const rfc8439SunscreenText = "Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it."
+
+func rfcSuiteList() []rfcSuite {
+	md5Hash, _ := findHashAlgorithm("md5")
+	sha1Hmac, sha256Hmac := pbkdf2Hmacs[0], hkdfHashes[0]
+	hkdfSha1Covers := []string{"Hkdf hmac=" + sha1Hmac.covers}
+	hkdfSha256Covers := []string{"Hkdf hmac=" + sha256Hmac.covers}
+	return []rfcSuite{
+		{Suite{Name: "rfc 2104: hmac-md5", Skip: md5Hash.skip}, []rfcVector{
+			rfcHmacMd5Vector("test vector 1", bytes.Repeat([]byte{0x0b}, 16), []byte("Hi There"), "9294727a3638bb1c13f48ef8158bfc9d"),
+			rfcHmacMd5Vector("test vector 2", []byte("Jefe"), []byte("what do ya want for nothing?"), "750c783e6ab0b503eaa86e310a5db738"),
+			rfcHmacMd5Vector("test vector 3", bytes.Repeat([]byte{0xaa}, 16), bytes.Repeat([]byte{0xdd}, 50), "56be34521d144c88dbb8c733f0e8b3f6"),
+		}},
+		{Suite{Name: "rfc 5869: hkdf-sha256", Body: fmt.Sprintf(hkdfBody, sha256Hmac.hmac), Covers: hkdfSha256Covers}, []rfcVector{
+			rfcHkdfVector("test case 1", sha256Hmac, bytes.Repeat([]byte{0x0b}, 22), sequence(0x00, 13), sequence(0xf0, 10),
+				"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"),
+			rfcHkdfVector("test case 2", sha256Hmac, sequence(0x00, 80), sequence(0x60, 80), sequence(0xb0, 80),
+				"b11e398dc80327a1c8e7f78c596a49344f012eda2d4efad8a050cc4c19afa97c59045a99cac7827271cb41c65e590e09da3275600c2f09b8367793a9aca3db71cc30c58179ec3e87c14c01d5c1f3434f1d87"),
+		}},
+		{Suite{Name: "rfc 5869: hkdf-sha256, empty salt", Body: fmt.Sprintf(hkdfBody, sha256Hmac.hmac), Skip: hkdfEmptySaltSkip, Covers: hkdfSha256Covers}, []rfcVector{
+			rfcHkdfVector("test case 3", sha256Hmac, bytes.Repeat([]byte{0x0b}, 22), nil, nil,
+				"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8"),
+		}},
+		{Suite{Name: "rfc 5869: hkdf-sha1", Body: fmt.Sprintf(hkdfBody, sha1Hmac.hmac), Covers: hkdfSha1Covers}, []rfcVector{
+			rfcHkdfVector("test case 4", sha1Hmac, bytes.Repeat([]byte{0x0b}, 11), sequence(0x00, 13), sequence(0xf0, 10),
+				"085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896"),
+			rfcHkdfVector("test case 5", sha1Hmac, sequence(0x00, 80), sequence(0x60, 80), sequence(0xb0, 80),
+				"0bd770a74d1160f7c9f12cd5912a06ebff6adcae899d92191fe4305673ba2ffe8fa3f1a4e5ad79f3f334b3b202b2173c486ea37ce3d397ed034c7f9dfeb15c5e927336d0441f4c4300e2cff0d0900b52d3b4"),
+		}},
+		{Suite{Name: "rfc 5869: hkdf-sha1, empty salt", Body: fmt.Sprintf(hkdfBody, sha1Hmac.hmac), Skip: hkdfEmptySaltSkip, Covers: hkdfSha1Covers}, []rfcVector{
+			rfcHkdfVector("test case 6", sha1Hmac, bytes.Repeat([]byte{0x0b}, 22), nil, nil,
+				"0ac1af7002b3d761d1e55298da9d0506b9ae52057220a306e07b6b87e8df21d0ea00033de03984d34918"),
+			rfcHkdfVector("test case 7", sha1Hmac, bytes.Repeat([]byte{0x0c}, 22), nil, nil,
+				"2c91117204d745f3500d636a62f64f0ab3bae548aa53d423b0d1f27ebba6f5e5673a081d70cce7acfc48"),
+		}},
+		{Suite{Name: "rfc 7748: x25519", Body: rfcX25519Body, Covers: []string{"X25519"}}, []rfcVector{
+			rfcX25519Vector("section 5.2, test vector 1",
+				"a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+				"e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+				"c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552"),
+			rfcX25519Vector("section 5.2, test vector 2",
+				"4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d",
+				"e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493",
+				"95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957"),
+			rfcX25519Vector("section 5.2, 1 iteration",
+				"0900000000000000000000000000000000000000000000000000000000000000",
+				"0900000000000000000000000000000000000000000000000000000000000000",
+				"422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079"),
+			rfcX25519Vector("section 6.1, Alice's public key",
+				"77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+				"0900000000000000000000000000000000000000000000000000000000000000",
+				"8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"),
+			rfcX25519Vector("section 6.1, Bob's public key",
+				"5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+				"0900000000000000000000000000000000000000000000000000000000000000",
+				"de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"),
+			rfcX25519Vector("section 6.1, Alice's shared secret",
+				"77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a",
+				"de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f",
+				"4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"),
+			rfcX25519Vector("section 6.1, Bob's shared secret",
+				"5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb",
+				"8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a",
+				"4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"),
+		}},
+		{Suite{Name: "rfc 8032: ed25519", Body: ed25519SignBody, Covers: []string{"Ed25519"}}, []rfcVector{
+			rfcEd25519Vector("section 7.1, test 1",
+				"9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+				"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+				"",
+				"e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"),
+			rfcEd25519Vector("section 7.1, test 2",
+				"4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+				"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+				"72",
+				"92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00"),
+			rfcEd25519Vector("section 7.1, test 3",
+				"c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+				"fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+				"af82",
+				"6291d657deec24024827e69c3abe01a30ce548a284743a445e3680d7db5ac3ac18ff9b538d16f290ae67f760984dc6594a7c15e9716ed28dc027beceea1ec40a"),
+			rfcEd25519Vector("section 7.1, test SHA(abc)",
+				"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+				"ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+				"ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+				"dc2a4459e7369633a52b1bf277839a00201009a3efbf3ecb69bea2186c26b58909351fc9ac90b3ecfdfbc7c66431e0303dca179c138ac17ad9bef1177331a704"),
+		}},
+		{Suite{Name: "rfc 8439: chacha20", Body: chacha20Body, Covers: []string{"Chacha20 macAlgorithm=empty"}}, []rfcVector{
+			rfc8439Chacha20Vector(),
+		}},
+		{Suite{Name: "rfc 8439: poly1305", Body: rfcPoly1305Body, Covers: []string{"Poly1305"}}, []rfcVector{
+			rfc8439Poly1305Vector(),
+		}},
+		{Suite{Name: "rfc 8439: chacha20-poly1305", Body: chacha20Poly1305Body, Covers: []string{"Chacha20.poly1305Aead"}}, []rfcVector{
+			rfc8439AeadVector(),
+		}},
+		{Suite{Name: "rfc 9106: argon2d", Skip: argon2VariantsSkip}, []rfcVector{
+			rfcArgon2Vector(argon2d, "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"),
+		}},
+		{Suite{Name: "rfc 9106: argon2i", Skip: argon2VariantsSkip}, []rfcVector{
+			rfcArgon2Vector(argon2i, "c814d9d1dc7f37aa13f0d77f2494bda1c8de6b016dd388d29952a4c4672b6ce8"),
+		}},
+		{Suite{Name: "rfc 9106: argon2id", Skip: argon2idSkip}, []rfcVector{
+			rfcArgon2Vector(argon2id, "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"),
+		}},
+	}
+}
+
+// RFC 8439 section 2.4.2: the key stream starts at block counter 1.
+func rfc8439Chacha20Vector() rfcVector {
+	key := sequence(0, 32)
+	nonce := mustHex("000000000000004a00000000")
+	clearText := []byte(rfc8439SunscreenText)
+	want := mustHex("6e2e359a2568f98041ba0728dd0d6981e97e7aec1d4360c20a27afccfd9fae0bf91b65c5524733ab8f593dabcd62b3571639d624e65152ab8f530c359f0861d807ca0dbf500d6a6156a38e088a22b65e52bc514d16ccf806818ce91ab77937365af90bbf74a35be6b40b8eedf2785e42874d")
+	return rfcVector{
+		Vector: Vector{Name: "section 2.4.2", Fields: []Field{
+			{"secretKey", key},
+			{"nonce", nonce},
+			{"keyStreamIndex", 64},
+			{"clearText", clearText},
+			{"cipherText", want},
+		}},
+		want: want,
+		compute: func() ([]byte, error) {
+			return chacha20XOR(key, nonce, clearText, 64)
+		},
+	}
+}
+
+// RFC 8439 section 2.5.2.
+func rfc8439Poly1305Vector() rfcVector {
+	key := mustHex("85d6be7857556d337f4452fe42d506a80103808afb0db2fd4abff6af4149f51b")
+	data := []byte("Cryptographic Forum Research Group")
+	want := mustHex("a8061dc1305136c6c22b8baf0c0127a9")
+	return rfcVector{
+		Vector: Vector{Name: "section 2.5.2", Fields: []Field{
+			{"secretKey", key},
+			{"data", data},
+			{"expected", want},
+		}},
+		want: want,
+		compute: func() ([]byte, error) {
+			var k [32]byte
+			var tag [16]byte
+			copy(k[:], key)
+			poly1305.Sum(&tag, data, &k)
+			return tag[:], nil
+		},
+	}
+}
+
+// RFC 8439 section 2.8.2.
+func rfc8439AeadVector() rfcVector {
+	key := sequence(0x80, 32)
+	nonce := mustHex("070000004041424344454647")
+	aad := mustHex("50515253c0c1c2c3c4c5c6c7")
+	clearText := []byte(rfc8439SunscreenText)
+	cipherText := mustHex("d31a8d34648e60db7b86afbc53ef7ec2a4aded51296e08fea9e2b5a736ee62d63dbea45e8ca9671282fafb69da92728b1a71de0a9e060b2905d6a5b67ecd3b3692ddbd7f2d778b8c9803aee328091b58fab324e4fad675945585808b4831d7bc3ff4def08e4b7a9de576d26586cec64b6116")
+	mac := mustHex("1ae10b594f09e26a7e902ecbd0600691")
+	return rfcVector{
+		Vector: Vector{Name: "section 2.8.2", Fields: []Field{
+			{"secretKey", key},
+			{"nonce", nonce},
+			{"aad", aad},
+			{"clearText", clearText},
+			{"cipherText", cipherText},
+			{"mac", mac},
+		}},
+		want: concat(cipherText, mac),
+		compute: func() ([]byte, error) {
+			cipherText, mac, err := chacha20Poly1305Seal(key, nonce, clearText, aad)
+			return concat(cipherText, mac), err
+		},
+	}
+}
+
+// checkRfcVectors returns an error if Go does not compute an RFC vector.
+func checkRfcVectors() error {
+	for _, s := range rfcSuiteList() {
+		for _, v := range s.vectors {
+			got, err := v.compute()
+			if err != nil {
+				return fmt.Errorf("%s: %s: %w", s.Name, v.Name, err)
+			}
+			if !bytes.Equal(got, v.want) {
+				return fmt.Errorf("%s: %s: Go computes %x, the RFC has %x", s.Name, v.Name, got, v.want)
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	register(newGenerator("rfc", rfcSuites).
+		uses("argon2.go", "bytes.go", "cavp.go", "chacha20.go", "chacha20_poly1305.go", "ed25519.go", "hash.go", "hkdf.go", "pbkdf2.go", "suite.go"))
+}
+
+// rfcSuites returns the RFC vectors. selfCheck has checked them.
+func rfcSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, r := range rfcSuiteList() {
+		s := r.Suite
+		for _, v := range r.vectors {
+			s.Vectors = append(s.Vectors, v.Vector)
+		}
+		if s.Name == "rfc 8439: chacha20-poly1305" {
+			addConcatenation(&s)
+		}
+		suites = append(suites, &s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,26 @@
+package algorithms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRfc(t *testing.T) {
+	suites, err := rfcSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := canonicalize(suites); err != nil {
+		t.Fatal(err)
+	}
+	// Suites that are skipped because of empty salts still check the keys.
+	for _, s := range suites {
+		if strings.HasPrefix(s.Name, "rfc 5869: ") && (s.Body == "" || len(s.Covers) == 0) {
+			t.Errorf("%s: no body or covers", s.Name)
+		}
+	}
+	v := findVector(t, suites, "rfc 7748: x25519", "section 6.1, Bob's shared secret")
+	checkHex(t, v, "expected", "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742")
+	v = findVector(t, suites, "rfc 8439: chacha20-poly1305", "section 2.8.2")
+	checkHex(t, v, "mac", "1ae10b594f09e26a7e902ecbd0600691")
+}
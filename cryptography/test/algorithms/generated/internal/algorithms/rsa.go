@@ -0,0 +1,225 @@
+package algorithms
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/sha3"
+)
+
+// RSA keys and signatures. crypto/rsa does not generate keys
+// deterministically, so the primes are derived from a label with SHAKE256 and
+// the first prime at or above the derived number is used.
+
+// rsaKeySizes are the modulus sizes of the RSA suites.
+var rsaKeySizes = []int{2048, 3072, 4096}
+
+// rsaKeys caches the keys of rsaKey, which are slow to find.
+var rsaKeys = map[int]*rsa.PrivateKey{}
+
+// rsaPrime returns a prime of the bits for the label. The two most
+// significant bits are set so that the product of two primes has exactly
+// twice the bits.
+func rsaPrime(label string, bits int, e int) *big.Int {
+	b := make([]byte, bits/8)
+	sha3.ShakeSum256(b, []byte(label))
+	b[0] |= 0xc0
+	b[len(b)-1] |= 1
+	p := new(big.Int).SetBytes(b)
+	two := big.NewInt(2)
+	for {
+		pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+		if p.ProbablyPrime(32) && new(big.Int).GCD(nil, nil, pMinusOne, big.NewInt(int64(e))).Cmp(big.NewInt(1)) == 0 {
+			return p
+		}
+		p.Add(p, two)
+	}
+}
+
+// rsaKey returns the RSA key of the size with public exponent 65537.
+func rsaKey(bits int) (*rsa.PrivateKey, error) {
+	if key, ok := rsaKeys[bits]; ok {
+		return key, nil
+	}
+	const e = 65537
+	p := rsaPrime(fmt.Sprintf("rsa-%d p", bits), bits/2, e)
+	q := rsaPrime(fmt.Sprintf("rsa-%d q", bits), bits/2, e)
+	one := big.NewInt(1)
+	pMinusOne := new(big.Int).Sub(p, one)
+	qMinusOne := new(big.Int).Sub(q, one)
+	gcd := new(big.Int).GCD(nil, nil, pMinusOne, qMinusOne)
+	lambda := new(big.Int).Mul(pMinusOne, qMinusOne)
+	lambda.Div(lambda, gcd)
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: e},
+		D:         new(big.Int).ModInverse(big.NewInt(e), lambda),
+		Primes:    []*big.Int{p, q},
+	}
+	key.Precompute()
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("rsa-%d: %w", bits, err)
+	}
+	rsaKeys[bits] = key
+	return key, nil
+}
+
+// rsaKeyFields returns the fields of a Dart RsaKeyPairData.
+func rsaKeyFields(key *rsa.PrivateKey) []Field {
+	return []Field{
+		{"n", key.N.Bytes()},
+		{"e", big.NewInt(int64(key.E)).Bytes()},
+		{"d", key.D.Bytes()},
+		{"p", key.Primes[0].Bytes()},
+		{"q", key.Primes[1].Bytes()},
+		{"dp", key.Precomputed.Dp.Bytes()},
+		{"dq", key.Precomputed.Dq.Bytes()},
+		{"qi", key.Precomputed.Qinv.Bytes()},
+	}
+}
+
+// Only BrowserCryptography implements RSA signatures.
+const rsaSsaPkcs1v15Body = `
+final algorithm = RsaSsaPkcs1v15(%s());
+final keyPair = RsaKeyPairData(
+  n: n,
+  e: e,
+  d: d,
+  p: p,
+  q: q,
+  dp: dp,
+  dq: dq,
+  qi: qi,
+);
+final signature = await algorithm.sign(message, keyPair: keyPair);
+expect(
+  hexFromBytes(signature.bytes),
+  hexFromBytes(expected),
+);
+expect(
+  await algorithm.verify(message, signature: signature),
+  isTrue,
+);
+`
+
+func init() {
+	register(newGenerator("rsassa-pkcs1-v1-5", rsaSsaPkcs1v15Suites, spec.Seed).
+		uses("bytes.go", "dart.go", "ecdsa.go", "prng.go", "suite.go"))
+}
+
+// RSASSA-PKCS1-v1_5 signatures are deterministic.
+func rsaSsaPkcs1v15Suites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, h := range ecdsaHashes {
+		s := &Suite{
+			Name:   "rsassa-pkcs1-v1_5-" + strings.ToLower(h.dart),
+			Body:   fmt.Sprintf(rsaSsaPkcs1v15Body, h.dart),
+			TestOn: "chrome",
+			Covers: []string{"RsaSsaPkcs1v15 hashAlgorithm=" + h.dart},
+		}
+		for _, bits := range rsaKeySizes {
+			key, err := rsaKey(bits)
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range []int{0, 1, 1000} {
+				message := randomBytes(s.Name+": message", n)
+				digest := h.hash.New()
+				digest.Write(message)
+				signature, err := rsa.SignPKCS1v15(nil, key, h.hash, digest.Sum(nil))
+				if err != nil {
+					return nil, err
+				}
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%d-bit key, %s", bits, describeBytes(message)),
+					Fields: append(rsaKeyFields(key),
+						Field{"message", message},
+						Field{"expected", signature},
+					),
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// rsaJwk returns the private JWK of an RSA key.
+func rsaJwk(key *rsa.PrivateKey) jwk {
+	b64 := func(x *big.Int) string {
+		return b64url(x.Bytes())
+	}
+	return jwk{
+		Kty: "RSA",
+		N:   b64(key.N),
+		E:   b64(big.NewInt(int64(key.E))),
+		D:   b64(key.D),
+		P:   b64(key.Primes[0]),
+		Q:   b64(key.Primes[1]),
+		Dp:  b64(key.Precomputed.Dp),
+		Dq:  b64(key.Precomputed.Dq),
+		Qi:  b64(key.Precomputed.Qinv),
+	}
+}
+
+// rsaJwks returns the private and public JWK of a key.
+func rsaJwks(key *rsa.PrivateKey) (private, public string, err error) {
+	j := rsaJwk(key)
+	publicJSON, err := json.Marshal(j.public())
+	if err != nil {
+		return "", "", err
+	}
+	privateJSON, err := json.Marshal(j)
+	if err != nil {
+		return "", "", err
+	}
+	return string(privateJSON), string(publicJSON), nil
+}
+
+func init() {
+	register(newGenerator("rsa-keys", rsaKeySuites).
+		uses("jwk.go", "suite.go"))
+}
+
+// rsaKeySuites returns the RSA keys in the DER encodings of encoding/x509 and
+// as JWK, for testing key import and export.
+func rsaKeySuites() ([]*Suite, error) {
+	s := &Suite{
+		Name: "rsa: key encodings",
+		Skip: "RSA key encodings are not implemented in package:cryptography",
+	}
+	for _, bits := range rsaKeySizes {
+		key, err := rsaKey(bits)
+		if err != nil {
+			return nil, err
+		}
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		privateJwk, publicJwk, err := rsaJwks(key)
+		if err != nil {
+			return nil, err
+		}
+		s.Vectors = append(s.Vectors, Vector{
+			Name: fmt.Sprintf("%d-bit key", bits),
+			Fields: append(rsaKeyFields(key),
+				Field{"pkcs1PrivateKey", x509.MarshalPKCS1PrivateKey(key)},
+				Field{"pkcs1PublicKey", x509.MarshalPKCS1PublicKey(&key.PublicKey)},
+				Field{"pkcs8PrivateKey", pkcs8},
+				Field{"spkiPublicKey", spki},
+				Field{"privateJwk", privateJwk},
+				Field{"publicJwk", publicJwk},
+			),
+		})
+	}
+	return []*Suite{s}, nil
+}
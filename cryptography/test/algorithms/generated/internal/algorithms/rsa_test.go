@@ -0,0 +1,57 @@
+package algorithms
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestRsaKey(t *testing.T) {
+	for _, bits := range rsaKeySizes {
+		key, err := rsaKey(bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := key.N.BitLen(); got != bits {
+			t.Errorf("got %d-bit modulus, want %d", got, bits)
+		}
+	}
+	// The primes only depend on the label.
+	if rsaPrime("x", 512, 65537).Cmp(rsaPrime("x", 512, 65537)) != 0 {
+		t.Error("rsaPrime is not deterministic")
+	}
+}
+
+func TestRsaKeySuites(t *testing.T) {
+	suites, err := rsaKeySuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := rsaKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "rsa: key encodings", "2048-bit key")
+	fields := map[string][]byte{}
+	for _, f := range v.Fields {
+		if b, ok := f.Value.([]byte); ok {
+			fields[f.Name] = b
+		}
+	}
+	// Every encoding must decode to the same key.
+	pkcs1, err := x509.ParsePKCS1PrivateKey(fields["pkcs1PrivateKey"])
+	if err != nil || !pkcs1.Equal(key) {
+		t.Errorf("pkcs1PrivateKey: %v", err)
+	}
+	pkcs8, err := x509.ParsePKCS8PrivateKey(fields["pkcs8PrivateKey"])
+	if err != nil || !key.Equal(pkcs8) {
+		t.Errorf("pkcs8PrivateKey: %v", err)
+	}
+	pkcs1Public, err := x509.ParsePKCS1PublicKey(fields["pkcs1PublicKey"])
+	if err != nil || !pkcs1Public.Equal(&key.PublicKey) {
+		t.Errorf("pkcs1PublicKey: %v", err)
+	}
+	spki, err := x509.ParsePKIXPublicKey(fields["spkiPublicKey"])
+	if err != nil || !key.PublicKey.Equal(spki) {
+		t.Errorf("spkiPublicKey: %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/salsa20"
+)
+
+// Salsa20 (8-byte nonce) and XSalsa20 (24-byte nonce) as in NaCl and
+// libsodium.
+
+func init() {
+	register(newGenerator("salsa20", salsa20Suites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func salsa20Suites() ([]*Suite, error) {
+	const skip = "Salsa20 is not implemented in package:cryptography"
+	var key [32]byte
+	copy(key[:], sequence(0, 32))
+	var suites []*Suite
+	for _, nonceLength := range []int{8, 24} {
+		name := "salsa20"
+		if nonceLength == 24 {
+			name = "xsalsa20"
+		}
+		s := &Suite{
+			Name: fmt.Sprintf("%s: %d-byte nonce", name, nonceLength),
+			Skip: skip,
+		}
+		nonce := sequence(0x80, nonceLength)
+		for _, n := range []int{0, 1, 63, 64, 65, 128, 200} {
+			clearText := randomBytes(s.Name+": clearText", n)
+			cipherText := make([]byte, n)
+			salsa20.XORKeyStream(cipherText, clearText, nonce, &key)
+			s.Vectors = append(s.Vectors, Vector{
+				Name: describeBytes(clearText),
+				Fields: []Field{
+					{"secretKey", key[:]},
+					{"nonce", nonce},
+					{"clearText", clearText},
+					{"cipherText", cipherText},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
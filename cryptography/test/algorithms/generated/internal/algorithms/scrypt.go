@@ -0,0 +1,64 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt (RFC 7914). The memory of scrypt is 128*r*N bytes and every block
+// is written once and read once per lane. The last RFC 7914 vector needs
+// 1 GiB and is left out.
+
+// scryptCost returns the cost of scrypt in KiB of memory touched once.
+func scryptCost(n, r, p int) int {
+	return 2 * 128 * r * n * p / 1024
+}
+
+func init() {
+	register(newGenerator("scrypt", scryptSuites).
+		uses("suite.go"))
+}
+
+func scryptSuites() ([]*Suite, error) {
+	s := &Suite{
+		Name: "scrypt",
+		Skip: "scrypt is not implemented in package:cryptography",
+	}
+	type input struct {
+		name           string
+		password, salt string
+		n, r, p        int
+	}
+	inputs := []input{
+		{"RFC 7914 section 12, first vector", "", "", 16, 1, 1},
+		{"RFC 7914 section 12, second vector", "password", "NaCl", 1024, 8, 16},
+		{"RFC 7914 section 12, third vector", "pleaseletmein", "SodiumChloride", 16384, 8, 1},
+	}
+	for _, n := range []int{2, 16, 1024, 16384} {
+		for _, r := range []int{1, 8} {
+			for _, p := range []int{1, 2} {
+				inputs = append(inputs, input{fmt.Sprintf("N=%d, r=%d, p=%d", n, r, p), "password", "salt", n, r, p})
+			}
+		}
+	}
+	for _, in := range inputs {
+		expected, err := scrypt.Key([]byte(in.password), []byte(in.salt), in.n, in.r, in.p, 64)
+		if err != nil {
+			return nil, fmt.Errorf("scrypt: %s: %w", in.name, err)
+		}
+		s.Vectors = append(s.Vectors, Vector{
+			Name: in.name,
+			Fields: []Field{
+				{"password", []byte(in.password)},
+				{"salt", []byte(in.salt)},
+				{"n", in.n},
+				{"r", in.r},
+				{"p", in.p},
+				{"expected", expected},
+			},
+			Cost: scryptCost(in.n, in.r, in.p),
+		})
+	}
+	return []*Suite{s}, nil
+}
@@ -0,0 +1,21 @@
+package algorithms
+
+import (
+	"testing"
+)
+
+func TestScrypt(t *testing.T) {
+	suites, err := scryptSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 7914 section 12.
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{"RFC 7914 section 12, first vector", "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906"},
+		{"RFC 7914 section 12, second vector", "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b3731622eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640"},
+	} {
+		checkHex(t, findVector(t, suites, "scrypt", tc.name), "expected", tc.want)
+	}
+}
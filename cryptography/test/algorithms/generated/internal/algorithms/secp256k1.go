@@ -0,0 +1,76 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// ECDH and ECDSA over secp256k1 in the same formats as the NIST curves.
+// Signatures use SHA-256 and RFC 6979 nonces, and s is normalized to the
+// lower half of the group order as in Bitcoin.
+
+const secp256k1Skip = "secp256k1 is not implemented in package:cryptography"
+
+// secp256k1PrivateKeys returns the private keys of the suites: 1, n-1 and
+// byte sequences.
+func secp256k1PrivateKeys() map[string][]byte {
+	var nMinusOne secp256k1.ModNScalar
+	nMinusOne.SetInt(1).Negate()
+	nMinusOneBytes := nMinusOne.Bytes()
+	keys := map[string][]byte{
+		"private key 1":   append(make([]byte, 31), 1),
+		"private key n-1": nMinusOneBytes[:],
+	}
+	for _, start := range []byte{0x00, 0x40, 0xc0} {
+		d := sequence(start, 32)
+		keys[fmt.Sprintf("private key %02x..%02x", d[0], d[len(d)-1])] = d
+	}
+	return keys
+}
+
+func init() {
+	register(newGenerator("secp256k1", secp256k1Suites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func secp256k1Suites() ([]*Suite, error) {
+	ecdh := &Suite{Name: "secp256k1: ecdh", Skip: secp256k1Skip}
+	sign := &Suite{Name: "secp256k1: ecdsa-sha256", Skip: secp256k1Skip}
+	peer := secp256k1.PrivKeyFromBytes(sequence(0x80, 32))
+	for name, d := range secp256k1PrivateKeys() {
+		privateKey := secp256k1.PrivKeyFromBytes(d)
+		publicKey := privateKey.PubKey().SerializeUncompressed()
+		ecdh.Vectors = append(ecdh.Vectors, Vector{
+			Name: name,
+			Fields: []Field{
+				{"privateKey", d},
+				{"publicKey", publicKey},
+				{"peerPublicKey", peer.PubKey().SerializeUncompressed()},
+				{"sharedSecret", secp256k1.GenerateSharedSecret(privateKey, peer.PubKey())},
+			},
+		})
+
+		for _, n := range []int{0, 1, 64, 1000} {
+			message := randomBytes(sign.Name+": message", n)
+			digest := sha256.Sum256(message)
+			signature := secp256k1ecdsa.Sign(privateKey, digest[:])
+			r, s := signature.R(), signature.S()
+			rBytes, sBytes := r.Bytes(), s.Bytes()
+			sign.Vectors = append(sign.Vectors, Vector{
+				Name: name + ", " + describeBytes(message),
+				Fields: []Field{
+					{"privateKey", d},
+					{"publicKey", publicKey},
+					{"message", message},
+					{"expected", append(rBytes[:], sBytes[:]...)},
+					{"expectedDer", signature.Serialize()},
+				},
+			})
+		}
+	}
+	return []*Suite{ecdh, sign}, nil
+}
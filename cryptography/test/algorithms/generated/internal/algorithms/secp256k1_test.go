@@ -0,0 +1,27 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestSecp256k1(t *testing.T) {
+	suites, err := secp256k1Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The public key of 1 is the base point.
+	v := findVector(t, suites, "secp256k1: ecdh", "private key 1")
+	checkHex(t, v, "publicKey", "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	// Both signature encodings must have the same r and s.
+	v = findVector(t, suites, "secp256k1: ecdsa-sha256", "private key 1, 0 bytes")
+	for _, f := range v.Fields {
+		if f.Name == "expected" {
+			der, err := ecdsaDer(f.Value.([]byte))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkHex(t, v, "expectedDer", hex.EncodeToString(der))
+		}
+	}
+}
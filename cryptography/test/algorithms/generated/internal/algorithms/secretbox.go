@@ -0,0 +1,41 @@
+package algorithms
+
+import (
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// NaCl crypto_secretbox (XSalsa20-Poly1305). The combined output of
+// crypto_secretbox_easy is the tag followed by the cipher text.
+
+func init() {
+	register(newGenerator("secretbox", secretboxSuites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func secretboxSuites() ([]*Suite, error) {
+	s := &Suite{
+		Name: "nacl-secretbox: XSalsa20-Poly1305",
+		Skip: "XSalsa20-Poly1305 is not implemented in package:cryptography",
+	}
+	var key [32]byte
+	copy(key[:], sequence(0, 32))
+	var nonce [24]byte
+	copy(nonce[:], sequence(0x80, 24))
+	for _, n := range []int{0, 1, 16, 32, 63, 64, 65, 100} {
+		clearText := randomBytes(s.Name+": clearText", n)
+		combined := secretbox.Seal(nil, clearText, &nonce, &key)
+		s.Vectors = append(s.Vectors, Vector{
+			Name: describeBytes(clearText),
+			Fields: []Field{
+				{"secretKey", key[:]},
+				{"nonce", nonce[:]},
+				{"clearText", clearText},
+				{"cipherText", combined[secretbox.Overhead:]},
+				{"mac", combined[:secretbox.Overhead]},
+				{"combined", combined},
+			},
+		})
+	}
+	return []*Suite{s}, nil
+}
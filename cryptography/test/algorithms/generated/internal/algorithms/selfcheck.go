@@ -0,0 +1,58 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+)
+
+// Known answers from RFCs and FIPS that the generator checks before it runs
+// any generator, together with the vectors of rfc.go. If the Go toolchain, a
+// module or a helper of the generator computes a wrong value, nothing is
+// written, because every generated value would be suspect.
+
+// knownAnswer is an official test vector.
+type knownAnswer struct {
+	name string
+
+	// compute returns the value with the reference function.
+	compute func() ([]byte, error)
+
+	// want is the value in the source, in hex.
+	want string
+}
+
+var knownAnswers = []knownAnswer{
+	{"FIPS 180-2 SHA-256 \"abc\"", func() ([]byte, error) {
+		sum := sha256.Sum256([]byte("abc"))
+		return sum[:], nil
+	}, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	{"FIPS 180-2 SHA-512 \"abc\"", func() ([]byte, error) {
+		sum := sha512.Sum512([]byte("abc"))
+		return sum[:], nil
+	}, "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+	{"RFC 4231 HMAC-SHA-256 test case 2", func() ([]byte, error) {
+		mac := hmac.New(sha256.New, []byte("Jefe"))
+		mac.Write([]byte("what do ya want for nothing?"))
+		return mac.Sum(nil), nil
+	}, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+}
+
+// SelfCheck returns an error if a known answer or an RFC vector is wrong.
+func SelfCheck() error {
+	for _, k := range knownAnswers {
+		got, err := k.compute()
+		if err != nil {
+			return fmt.Errorf("self-check: %s: %w", k.name, err)
+		}
+		if hex.EncodeToString(got) != k.want {
+			return fmt.Errorf("self-check: %s: got %x, want %s", k.name, got, k.want)
+		}
+	}
+	if err := checkRfcVectors(); err != nil {
+		return fmt.Errorf("self-check: %w", err)
+	}
+	return nil
+}
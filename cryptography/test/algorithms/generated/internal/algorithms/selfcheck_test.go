@@ -0,0 +1,21 @@
+package algorithms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelfCheck(t *testing.T) {
+	if err := SelfCheck(); err != nil {
+		t.Fatal(err)
+	}
+	// A wrong answer is reported.
+	saved := knownAnswers
+	defer func() { knownAnswers = saved }()
+	knownAnswers = []knownAnswer{{"wrong", func() ([]byte, error) {
+		return []byte{1}, nil
+	}, "02"}}
+	if err := SelfCheck(); err == nil || !strings.Contains(err.Error(), "wrong") {
+		t.Errorf("got %v, want an error", err)
+	}
+}
@@ -0,0 +1,262 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/poly1305"
+)
+
+// Incremental hashing with HashSink and MACs with MacSink. The "chunks" field
+// has the length of every add() call, which splits the data at and around the
+// block boundaries, including empty chunks.
+
+const hashSinkBody = `
+final sink = %s().newHashSink();
+var offset = 0;
+for (final n in chunks) {
+  sink.add(data.sublist(offset, offset + n));
+  offset += n;
+}
+sink.close();
+final hash = await sink.hash();
+expect(
+  hexFromBytes(hash.bytes),
+  hexFromBytes(expected),
+);
+`
+
+const macSinkBody = `
+final sink = await %s.newMacSink(
+  secretKey: SecretKey(secretKey),
+);
+var offset = 0;
+for (final n in chunks) {
+  sink.add(data.sublist(offset, offset + n));
+  offset += n;
+}
+sink.close();
+final mac = await sink.mac();
+expect(
+  hexFromBytes(mac.bytes),
+  hexFromBytes(expected),
+);
+`
+
+// sinkHash is a hash function of the sink suites.
+type sinkHash struct {
+	name, dart string
+	new        func() hash.Hash
+}
+
+var sinkHashes = []sinkHash{
+	{"sha-1", "Sha1", sha1.New},
+	{"sha-224", "Sha224", sha256.New224},
+	{"sha-256", "Sha256", sha256.New},
+	{"sha-384", "Sha384", sha512.New384},
+	{"sha-512", "Sha512", sha512.New},
+	{"blake2b", "Blake2b", func() hash.Hash {
+		h, _ := blake2b.New512(nil)
+		return h
+	}},
+	{"blake2s", "Blake2s", func() hash.Hash {
+		h, _ := blake2s.New256(nil)
+		return h
+	}},
+}
+
+// chunkSplits returns the lengths of add() calls for a block size.
+func chunkSplits(blockSize int) [][]int {
+	b := blockSize
+	ones := make([]int, 2*b+1)
+	for i := range ones {
+		ones[i] = 1
+	}
+	return [][]int{
+		{},
+		{0},
+		{1, b - 1, b},
+		{b - 1, 1},
+		{b, 0, 1},
+		{b + 1, b - 1},
+		{b - 9, 1, 8},
+		{3 * b},
+		{b / 2, b, b/2 + 3},
+		{7, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+		ones,
+	}
+}
+
+// describeChunks returns a name for chunk lengths, such as "1+63+64".
+func describeChunks(chunks []int) string {
+	if len(chunks) == 0 {
+		return "no chunks"
+	}
+	if len(chunks) > 10 {
+		return fmt.Sprintf("%d chunks of %d byte", len(chunks), chunks[0])
+	}
+	s := make([]string, len(chunks))
+	for i, n := range chunks {
+		s[i] = fmt.Sprint(n)
+	}
+	return strings.Join(s, "+")
+}
+
+// sum returns the sum of the numbers.
+func sum(numbers []int) int {
+	total := 0
+	for _, n := range numbers {
+		total += n
+	}
+	return total
+}
+
+// writeChunks writes the data to w in chunks of the lengths.
+func writeChunks(w io.Writer, data []byte, chunks []int) {
+	offset := 0
+	for _, n := range chunks {
+		w.Write(data[offset : offset+n])
+		offset += n
+	}
+}
+
+func init() {
+	register(newGenerator("hash-sinks", hashSinkSuites).
+		uses("bytes.go", "suite.go"))
+}
+
+func hashSinkSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, h := range sinkHashes {
+		s := &Suite{
+			Name:   h.name + ": hash sink",
+			Body:   fmt.Sprintf(hashSinkBody, h.dart),
+			Covers: []string{h.dart},
+		}
+		for _, chunks := range chunkSplits(h.new().BlockSize()) {
+			data := sequence(0, sum(chunks))
+			d := h.new()
+			writeChunks(d, data, chunks)
+			s.Vectors = append(s.Vectors, Vector{
+				Name: describeChunks(chunks),
+				Fields: []Field{
+					{"data", data},
+					{"chunks", chunks},
+					{"expected", d.Sum(nil)},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// sinkMac is a MAC of the MAC sink suites.
+type sinkMac struct {
+	name string
+
+	// dart is a Dart expression for the MacAlgorithm. It is empty for
+	// skipped MACs.
+	dart   string
+	covers string
+	skip   string
+
+	keyLength, blockSize int
+
+	// mac returns the MAC of the data written in chunks.
+	mac func(key, data []byte, chunks []int) []byte
+}
+
+// hmacSinkMac returns the HMAC of the hash as a sinkMac.
+func hmacSinkMac(h sinkHash) sinkMac {
+	blockSize := h.new().BlockSize()
+	return sinkMac{
+		name:      "hmac-" + h.name,
+		dart:      fmt.Sprintf("Hmac(%s())", h.dart),
+		covers:    "Hmac hashAlgorithm=" + h.dart,
+		keyLength: 32,
+		blockSize: blockSize,
+		mac: func(key, data []byte, chunks []int) []byte {
+			mac := hmac.New(h.new, key)
+			writeChunks(mac, data, chunks)
+			return mac.Sum(nil)
+		},
+	}
+}
+
+func init() {
+	register(newGenerator("mac-sinks", macSinkSuites).
+		uses("blake2.go", "bytes.go", "suite.go"))
+}
+
+func macSinkSuites() ([]*Suite, error) {
+	var macs []sinkMac
+	for _, h := range sinkHashes {
+		if h.name != "blake2b" && h.name != "blake2s" {
+			macs = append(macs, hmacSinkMac(h))
+		}
+	}
+	macs = append(macs,
+		sinkMac{
+			name: "poly1305", dart: "Poly1305()", covers: "Poly1305",
+			keyLength: 32, blockSize: 16,
+			mac: func(key, data []byte, chunks []int) []byte {
+				var k [32]byte
+				copy(k[:], key)
+				mac := poly1305.New(&k)
+				writeChunks(mac, data, chunks)
+				return mac.Sum(nil)
+			},
+		},
+		sinkMac{
+			name: "blake2b: keyed", skip: blake2ParametersSkip,
+			keyLength: 64, blockSize: blake2b.BlockSize,
+			mac: func(key, data []byte, chunks []int) []byte {
+				mac, _ := blake2b.New512(key)
+				writeChunks(mac, data, chunks)
+				return mac.Sum(nil)
+			},
+		},
+		sinkMac{
+			name: "blake2s: keyed", skip: blake2ParametersSkip,
+			keyLength: 32, blockSize: blake2s.BlockSize,
+			mac: func(key, data []byte, chunks []int) []byte {
+				mac, _ := blake2s.New256(key)
+				writeChunks(mac, data, chunks)
+				return mac.Sum(nil)
+			},
+		},
+	)
+
+	var suites []*Suite
+	for _, m := range macs {
+		s := &Suite{Name: m.name + ": mac sink", Skip: m.skip}
+		if m.dart != "" {
+			s.Body = fmt.Sprintf(macSinkBody, m.dart)
+			s.Covers = []string{m.covers}
+		}
+		key := sequence(0x40, m.keyLength)
+		for _, chunks := range chunkSplits(m.blockSize) {
+			data := sequence(0, sum(chunks))
+			s.Vectors = append(s.Vectors, Vector{
+				Name: describeChunks(chunks),
+				Fields: []Field{
+					{"secretKey", key},
+					{"data", data},
+					{"chunks", chunks},
+					{"expected", m.mac(key, data, chunks)},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,42 @@
+package algorithms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/poly1305"
+)
+
+func TestHashSink(t *testing.T) {
+	suites, err := hashSinkSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The hash does not depend on the chunks.
+	v := findVector(t, suites, "sha-256: hash sink", "1+63+64")
+	want := sha256.Sum256(sequence(0, 128))
+	checkHex(t, v, "expected", hex.EncodeToString(want[:]))
+	findVector(t, suites, "sha-512: hash sink", "1+127+128")
+	findVector(t, suites, "sha-1: hash sink", "129 chunks of 1 byte")
+}
+
+func TestMacSink(t *testing.T) {
+	suites, err := macSinkSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The MAC does not depend on the chunks.
+	v := findVector(t, suites, "hmac-sha-256: mac sink", "1+63+64")
+	mac := hmac.New(sha256.New, sequence(0x40, 32))
+	mac.Write(sequence(0, 128))
+	checkHex(t, v, "expected", hex.EncodeToString(mac.Sum(nil)))
+	v = findVector(t, suites, "poly1305: mac sink", "33 chunks of 1 byte")
+	var key [32]byte
+	copy(key[:], sequence(0x40, 32))
+	var want [16]byte
+	poly1305.Sum(&want, sequence(0, 33), &key)
+	checkHex(t, v, "expected", hex.EncodeToString(want[:]))
+	findVector(t, suites, "blake2b: keyed: mac sink", "127+1")
+}
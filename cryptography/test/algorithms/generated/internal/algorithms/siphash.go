@@ -0,0 +1,91 @@
+package algorithms
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// SipHash with a configurable number of compression and finalization rounds.
+// SipHash-2-4 is the original function and SipHash-1-3 the faster variant
+// used by some hash tables. The output is the 64-bit value in little-endian
+// order.
+
+const siphashSkip = "SipHash is not implemented in package:cryptography"
+
+func siphash(c, d int, key, data []byte) []byte {
+	k0 := binary.LittleEndian.Uint64(key[:8])
+	k1 := binary.LittleEndian.Uint64(key[8:16])
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+	compress := func(m uint64) {
+		v3 ^= m
+		for i := 0; i < c; i++ {
+			round()
+		}
+		v0 ^= m
+	}
+	n := len(data)
+	for ; len(data) >= 8; data = data[8:] {
+		compress(binary.LittleEndian.Uint64(data))
+	}
+	var last [8]byte
+	copy(last[:], data)
+	last[7] = byte(n)
+	compress(binary.LittleEndian.Uint64(last[:]))
+	v2 ^= 0xff
+	for i := 0; i < d; i++ {
+		round()
+	}
+	return binary.LittleEndian.AppendUint64(nil, v0^v1^v2^v3)
+}
+
+func init() {
+	register(newGenerator("siphash", siphashSuites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func siphashSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, rounds := range [][2]int{{2, 4}, {1, 3}} {
+		c, d := rounds[0], rounds[1]
+		s := &Suite{
+			Name: fmt.Sprintf("siphash-%d-%d", c, d),
+			Skip: siphashSkip,
+		}
+		key := sequence(0, 16)
+		for n := 0; n <= 64; n++ {
+			data := randomBytes(s.Name+": data", n)
+			s.Vectors = append(s.Vectors, Vector{
+				Name: describeBytes(data),
+				Fields: []Field{
+					{"secretKey", key},
+					{"data", data},
+					{"mac", siphash(c, d, key, data)},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,20 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestSiphash(t *testing.T) {
+	// Appendix A of the SipHash paper: key 00..0f and message 00..0e.
+	got := siphash(2, 4, sequence(0, 16), sequence(0, 15))
+	if want := "e545be4961ca29a1"; hex.EncodeToString(got) != want {
+		t.Errorf("SipHash-2-4: got %x, want %s", got, want)
+	}
+	// SipHash-1-3 of the empty message with the same key, as in the
+	// reference implementation.
+	got = siphash(1, 3, sequence(0, 16), nil)
+	if want := "dcc40f055801acab"; hex.EncodeToString(got) != want {
+		t.Errorf("SipHash-1-3: got %x, want %s", got, want)
+	}
+}
@@ -0,0 +1,263 @@
+package algorithms
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// SPAKE2 (RFC 9382).
+//
+// The password is given as the scalar "w" (the output of the memory-hard
+// function reduced modulo the group order). Scalars are encoded big-endian
+// for P-256 and little-endian for edwards25519.
+
+// spake2Group is the prime-order group used by a SPAKE2 ciphersuite.
+type spake2Group interface {
+	// mulAdd returns (a * P + b * Q) where P is the generator and Q is given
+	// in encoded form.
+	mulAdd(a, b, q []byte) ([]byte, error)
+
+	// shared returns h * a * (p - b * Q).
+	shared(a, p, b, q []byte) ([]byte, error)
+}
+
+type spake2P256 struct{}
+
+func (spake2P256) mulAdd(a, b, q []byte) ([]byte, error) {
+	curve := elliptic.P256()
+	ax, ay := curve.ScalarBaseMult(a)
+	qx, qy := elliptic.UnmarshalCompressed(curve, q)
+	if qx == nil {
+		return nil, errors.New("invalid P-256 point")
+	}
+	bx, by := curve.ScalarMult(qx, qy, b)
+	x, y := curve.Add(ax, ay, bx, by)
+	return elliptic.Marshal(curve, x, y), nil
+}
+
+func (spake2P256) shared(a, p, b, q []byte) ([]byte, error) {
+	curve := elliptic.P256()
+	px, py := elliptic.Unmarshal(curve, p)
+	qx, qy := elliptic.UnmarshalCompressed(curve, q)
+	if px == nil || qx == nil {
+		return nil, errors.New("invalid P-256 point")
+	}
+	bx, by := curve.ScalarMult(qx, qy, b)
+	by = new(big.Int).Sub(curve.Params().P, by)
+	x, y := curve.Add(px, py, bx, by)
+	x, y = curve.ScalarMult(x, y, a)
+	return elliptic.Marshal(curve, x, y), nil
+}
+
+type spake2Edwards25519 struct{}
+
+// edwardsScalarsAndPoint decodes scalars and a point.
+func edwardsScalarsAndPoint(a, b, q []byte) (sa, sb *edwards25519.Scalar, pq *edwards25519.Point, err error) {
+	if sa, err = edwards25519.NewScalar().SetCanonicalBytes(a); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding scalar: %w", err)
+	}
+	if sb, err = edwards25519.NewScalar().SetCanonicalBytes(b); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding scalar: %w", err)
+	}
+	if pq, err = edwards25519.NewIdentityPoint().SetBytes(q); err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding point: %w", err)
+	}
+	return sa, sb, pq, nil
+}
+
+func (spake2Edwards25519) mulAdd(a, b, q []byte) ([]byte, error) {
+	sa, sb, pq, err := edwardsScalarsAndPoint(a, b, q)
+	if err != nil {
+		return nil, err
+	}
+	return edwards25519.NewIdentityPoint().VarTimeDoubleScalarBaseMult(sb, pq, sa).Bytes(), nil
+}
+
+func (spake2Edwards25519) shared(a, p, b, q []byte) ([]byte, error) {
+	sa, sb, pq, err := edwardsScalarsAndPoint(a, b, q)
+	if err != nil {
+		return nil, err
+	}
+	pp, err := edwards25519.NewIdentityPoint().SetBytes(p)
+	if err != nil {
+		return nil, fmt.Errorf("decoding point: %w", err)
+	}
+	bq := edwards25519.NewIdentityPoint().ScalarMult(sb, pq)
+	k := edwards25519.NewIdentityPoint().Subtract(pp, bq)
+	k.ScalarMult(sa, k)
+	k.MultByCofactor(k)
+	return k.Bytes(), nil
+}
+
+// spake2Append appends data prefixed with its length as 8-byte little-endian
+// integer.
+func spake2Append(tt, data []byte) []byte {
+	tt = binary.LittleEndian.AppendUint64(tt, uint64(len(data)))
+	return append(tt, data...)
+}
+
+type spake2Input struct {
+	name     string
+	idA, idB string
+	aad      []byte
+	w, x, y  []byte
+}
+
+func spake2Vector(g spake2Group, m, n []byte, in spake2Input) (Vector, error) {
+	pA, err := g.mulAdd(in.x, in.w, m)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing pA: %w", err)
+	}
+	pB, err := g.mulAdd(in.y, in.w, n)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing pB: %w", err)
+	}
+	k, err := g.shared(in.x, pB, in.w, n)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing K for A: %w", err)
+	}
+	kB, err := g.shared(in.y, pA, in.w, m)
+	if err != nil {
+		return Vector{}, fmt.Errorf("computing K for B: %w", err)
+	}
+	if !hmac.Equal(k, kB) {
+		return Vector{}, errors.New("A and B disagree on K")
+	}
+
+	var tt []byte
+	tt = spake2Append(tt, []byte(in.idA))
+	tt = spake2Append(tt, []byte(in.idB))
+	tt = spake2Append(tt, pA)
+	tt = spake2Append(tt, pB)
+	tt = spake2Append(tt, k)
+	tt = spake2Append(tt, in.w)
+
+	hashTT := sha256.Sum256(tt)
+	ke := hashTT[:16]
+	ka := hashTT[16:]
+	confirmationKeys := make([]byte, 32)
+	r := hkdf.New(sha256.New, ka, nil, concat([]byte("ConfirmationKeys"), in.aad))
+	if _, err := io.ReadFull(r, confirmationKeys); err != nil {
+		return Vector{}, fmt.Errorf("deriving confirmation keys: %w", err)
+	}
+	kcA := confirmationKeys[:16]
+	kcB := confirmationKeys[16:]
+	macA := hmac.New(sha256.New, kcA)
+	macA.Write(tt)
+	macB := hmac.New(sha256.New, kcB)
+	macB.Write(tt)
+
+	return Vector{
+		Name: in.name,
+		Fields: []Field{
+			{"idA", in.idA},
+			{"idB", in.idB},
+			{"aad", in.aad},
+			{"m", m},
+			{"n", n},
+			{"w", in.w},
+			{"x", in.x},
+			{"y", in.y},
+			{"pA", pA},
+			{"pB", pB},
+			{"k", k},
+			{"transcript", tt},
+			{"ke", ke},
+			{"ka", ka},
+			{"kcA", kcA},
+			{"kcB", kcB},
+			{"confirmationA", macA.Sum(nil)},
+			{"confirmationB", macB.Sum(nil)},
+		},
+	}, nil
+}
+
+// edwardsScalarFromLabel derives a uniformly random looking scalar.
+func edwardsScalarFromLabel(label string) []byte {
+	h := sha512.Sum512([]byte(label))
+	// SetUniformBytes only fails if the input is not 64 bytes.
+	s, _ := edwards25519.NewScalar().SetUniformBytes(h[:])
+	return s.Bytes()
+}
+
+func init() {
+	register(newGenerator("spake2", spake2Suites).
+		uses("bytes.go", "suite.go"))
+}
+
+func spake2Suites() ([]*Suite, error) {
+	const skip = "SPAKE2 is not implemented in package:cryptography"
+
+	p256 := &Suite{
+		Name: "spake2: P256-SHA256-HKDF-HMAC-SHA256",
+		Skip: skip,
+	}
+	p256M := mustHex("02886e2f97ace46e55ba9dd7242579f2993b64e16ef3dcab95afd497333d8fa12f")
+	p256N := mustHex("03d8bbd6c639c62937b04d997f38c3770719c629d7014d49a24b4f98baa1292b49")
+	// Inputs of the test vector in RFC 9382 appendix B.
+	rfc := spake2Input{
+		name: "RFC 9382",
+		idA:  "server",
+		idB:  "client",
+		w:    mustHex("2ee57912099d31560b3a44b1184b9b4866e904c49d12ac5042c97dca461b1a5f"),
+		x:    mustHex("43dd0fd7215bdcb482879fca3220c6a968e66d70b1356cac18bb26c84a78d729"),
+		y:    mustHex("dcb60106f276b02606d8ef0a328c02e4b629f84f89786af5befb0bc75b6e66be"),
+	}
+	withAAD := rfc
+	withAAD.name = "RFC 9382 inputs, with AAD"
+	withAAD.aad = []byte("device pairing")
+	withoutIdentities := rfc
+	withoutIdentities.name = "RFC 9382 inputs, without identities"
+	withoutIdentities.idA = ""
+	withoutIdentities.idB = ""
+	for _, in := range []spake2Input{rfc, withAAD, withoutIdentities} {
+		v, err := spake2Vector(spake2P256{}, p256M, p256N, in)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", p256.Name, in.name, err)
+		}
+		p256.Vectors = append(p256.Vectors, v)
+	}
+
+	ed25519 := &Suite{
+		Name: "spake2: edwards25519-SHA256-HKDF-HMAC-SHA256",
+		Skip: skip,
+	}
+	ed25519M := mustHex("d048032c6ea0b6d697ddc2e86bda85a33adac920f1bf18e1b0c6d166a5cecdaf")
+	ed25519N := mustHex("d3bfb518f44f3430f29d0c92af503865a1ed3281dc69b35dd868ba85f886c4ab")
+	for _, in := range []spake2Input{
+		{
+			name: "with identities",
+			idA:  "server",
+			idB:  "client",
+		},
+		{
+			name: "with identities and AAD",
+			idA:  "server",
+			idB:  "client",
+			aad:  []byte("device pairing"),
+		},
+		{
+			name: "without identities",
+		},
+	} {
+		in.w = edwardsScalarFromLabel("spake2 w")
+		in.x = edwardsScalarFromLabel("spake2 x")
+		in.y = edwardsScalarFromLabel("spake2 y")
+		v, err := spake2Vector(spake2Edwards25519{}, ed25519M, ed25519N, in)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", ed25519.Name, in.name, err)
+		}
+		ed25519.Vectors = append(ed25519.Vectors, v)
+	}
+	return []*Suite{p256, ed25519}, nil
+}
@@ -0,0 +1,85 @@
+package algorithms
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519"
+)
+
+func TestSPAKE2(t *testing.T) {
+	suites, err := spake2Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 9382 appendix B.
+	v := findVector(t, suites, "spake2: P256-SHA256-HKDF-HMAC-SHA256", "RFC 9382")
+	checkHex(t, v, "ke", "0e0672dc86f8e45565d338b0540abe69")
+	checkHex(t, v, "confirmationA", "58ad4aa88e0b60d5061eb6b5dd93e80d9c4f00d127c65b3b35b1b5281fee38f0")
+	checkHex(t, v, "confirmationB", "d3e2e547f1ae04f2dbdbf0fc4b79f8ecff2dff314b5d32fe9fcef2fb26dc459b")
+
+	// RFC 9382 has no edwards25519 test vector. M and N are derived with the
+	// procedure of appendix A: the first iterated SHA-256 of the seed that
+	// is a point of prime order.
+	v = findVector(t, suites, "spake2: edwards25519-SHA256-HKDF-HMAC-SHA256", "with identities")
+	for _, c := range []struct{ name, seed string }{
+		{"m", "edwards25519 point generation seed (M)"},
+		{"n", "edwards25519 point generation seed (N)"},
+	} {
+		checkHex(t, v, c.name, hex.EncodeToString(spake2SeedPoint(t, c.seed)))
+	}
+	// K is h*x*y*G.
+	x, err := edwards25519.NewScalar().SetCanonicalBytes(fieldValue(t, v, "x").([]byte))
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := edwards25519.NewScalar().SetCanonicalBytes(fieldValue(t, v, "y").([]byte))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := edwards25519.NewIdentityPoint().ScalarBaseMult(edwards25519.NewScalar().Multiply(x, y))
+	checkHex(t, v, "k", hex.EncodeToString(k.MultByCofactor(k).Bytes()))
+	var tt []byte
+	for _, name := range []string{"idA", "idB", "pA", "pB", "k", "w"} {
+		var b []byte
+		switch value := fieldValue(t, v, name).(type) {
+		case string:
+			b = []byte(value)
+		case []byte:
+			b = value
+		}
+		tt = binary.LittleEndian.AppendUint64(tt, uint64(len(b)))
+		tt = append(tt, b...)
+	}
+	checkHex(t, v, "transcript", hex.EncodeToString(tt))
+	hashTT := sha256.Sum256(tt)
+	checkHex(t, v, "ke", hex.EncodeToString(hashTT[:16]))
+}
+
+// spake2SeedPoint returns the edwards25519 point of RFC 9382 appendix A for
+// the seed.
+func spake2SeedPoint(t *testing.T, seed string) []byte {
+	t.Helper()
+	// The order of the prime-order subgroup minus one.
+	orderMinusOne, err := edwards25519.NewScalar().SetCanonicalBytes(mustHex("ecd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := []byte(seed)
+	for i := 1; i < 1000; i++ {
+		sum := sha256.Sum256(h)
+		h = sum[:]
+		p, err := edwards25519.NewIdentityPoint().SetBytes(h)
+		if err != nil || p.Equal(edwards25519.NewIdentityPoint()) == 1 {
+			continue
+		}
+		q := edwards25519.NewIdentityPoint().ScalarMult(orderMinusOne, p)
+		if q.Add(q, p).Equal(edwards25519.NewIdentityPoint()) == 1 {
+			return h
+		}
+	}
+	t.Fatalf("no point for seed %q", seed)
+	return nil
+}
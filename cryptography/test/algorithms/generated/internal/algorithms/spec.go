@@ -0,0 +1,44 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+// Spec is the spec file given with -spec, or the zero value.
+var Spec spec.Spec
+
+// specFlag returns an option of Spec as "name=value" for Params.
+func specFlag(option string) string {
+	switch option {
+	case spec.AesKeyLengths:
+		return fmt.Sprintf("%s=%v", option, aesKeyLengths())
+	case spec.InputLengths:
+		if len(Spec.InputLengths) == 0 {
+			return option + "=default"
+		}
+		return fmt.Sprintf("%s=%v", option, Spec.InputLengths)
+	case spec.Seed:
+		return fmt.Sprintf("%s=%d", option, Seed)
+	}
+	panic("unknown spec option " + option)
+}
+
+// aesKeyLengths returns the AES key lengths of Spec, or 16, 24 and 32 bytes
+// if it has none.
+func aesKeyLengths() []int {
+	if len(Spec.AesKeyLengths) > 0 {
+		return Spec.AesKeyLengths
+	}
+	return []int{16, 24, 32}
+}
+
+// inputLengths returns the input lengths of Spec, or defaults if it has
+// none.
+func inputLengths(defaults ...int) []int {
+	if len(Spec.InputLengths) > 0 {
+		return Spec.InputLengths
+	}
+	return defaults
+}
@@ -0,0 +1,47 @@
+package algorithms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+)
+
+func TestSpec(t *testing.T) {
+	hash := func(name string) string {
+		for _, g := range registry {
+			if g.Name() == name {
+				h, err := generatorHash(g)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return h
+			}
+		}
+		t.Fatalf("no generator %q", name)
+		return ""
+	}
+	aesCtr, blake2b, hashes := hash("aes-ctr"), hash("blake2b"), hash("hash")
+	defer func() { Spec = spec.Spec{} }()
+	Spec = spec.Spec{AesKeyLengths: []int{32}}
+	if hash("aes-ctr") == aesCtr || hash("blake2b") != blake2b {
+		t.Error("the AES key lengths change the wrong hashes")
+	}
+	Spec = spec.Spec{AesKeyLengths: []int{32}, InputLengths: []int{0, 5}}
+	if hash("blake2b") == blake2b || hash("hash") != hashes {
+		t.Error("the input lengths change the wrong hashes")
+	}
+	suites, err := aesCtrSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range suites {
+		for _, v := range s.Vectors {
+			names = append(names, s.Name+", "+v.Name)
+		}
+	}
+	if got := strings.Join(names, "; "); !strings.Contains(got, "256-bit key, counter block ending in 4 zero bytes, 5 bytes") || strings.Contains(got, "128-bit") {
+		t.Errorf("got vectors %s", got)
+	}
+}
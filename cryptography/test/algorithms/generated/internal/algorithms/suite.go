@@ -0,0 +1,46 @@
+package algorithms
+
+// Suite is a group of vectors that share the same Dart test body.
+type Suite struct {
+	// Name is the name of the Dart test group.
+	Name string
+
+	// Body is Dart code that runs for each vector. Fields of the vector are
+	// available as local variables.
+	Body string
+
+	// Skip is the reason for skipping the tests. It is used when
+	// package:cryptography does not implement the algorithm yet.
+	Skip string
+
+	// TestOn is the platform selector of the Dart test group, such as
+	// "chrome" for algorithms that only the browser implementation has.
+	TestOn string
+
+	// Covers lists the package:cryptography algorithms that the suite tests,
+	// such as "AesGcm secretKeyLength=16". Parameters that are not listed
+	// have their default value in algorithms.json.
+	Covers []string
+
+	Vectors []Vector
+}
+
+// Vector is a single test case.
+type Vector struct {
+	// Name is the name of the Dart test.
+	Name string
+
+	// Fields are declared (in this order) as Dart local variables.
+	Fields []Field
+
+	// Cost is the estimated work of the Dart test (see costPerSecond). Slow
+	// tests get a longer timeout, the "slow" tag and are not run on the web.
+	Cost int
+}
+
+// Field is a named value. Supported value types are []byte, string, []string,
+// int, []int and bool.
+type Field struct {
+	Name  string
+	Value interface{}
+}
@@ -0,0 +1,54 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// findVector returns the vector with the name in the suite with the name.
+func findVector(t *testing.T, suites []*Suite, suiteName, vectorName string) Vector {
+	t.Helper()
+	for _, s := range suites {
+		if s.Name != suiteName {
+			continue
+		}
+		for _, v := range s.Vectors {
+			if v.Name == vectorName {
+				return v
+			}
+		}
+	}
+	t.Fatalf("no vector %q in suite %q", vectorName, suiteName)
+	return Vector{}
+}
+
+// checkHex checks that a []byte field of the vector has the expected value.
+func checkHex(t *testing.T, v Vector, name, want string) {
+	t.Helper()
+	for _, f := range v.Fields {
+		if f.Name != name {
+			continue
+		}
+		b, ok := f.Value.([]byte)
+		if !ok {
+			t.Fatalf("%s: field %q is %T, not []byte", v.Name, name, f.Value)
+		}
+		if got := hex.EncodeToString(b); got != want {
+			t.Errorf("%s: %s = %s, want %s", v.Name, name, got, want)
+		}
+		return
+	}
+	t.Fatalf("%s: no field %q", v.Name, name)
+}
+
+// fieldValue returns the value of the field of the vector.
+func fieldValue(t *testing.T, v Vector, name string) interface{} {
+	t.Helper()
+	for _, f := range v.Fields {
+		if f.Name == name {
+			return f.Value
+		}
+	}
+	t.Fatalf("%s: no field %q", v.Name, name)
+	return nil
+}
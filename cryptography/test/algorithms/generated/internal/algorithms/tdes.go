@@ -0,0 +1,71 @@
+package algorithms
+
+import (
+	"crypto/cipher"
+	"crypto/des"
+	"fmt"
+)
+
+// Triple DES (TDEA) in CBC mode with PKCS7 padding, for interoperability with
+// legacy systems. Keying option 1 has three independent keys. Keying option 2
+// has a 16-byte key where the third key is the first; the "secretKey" field
+// has the 16 bytes and "expandedKey" the 24 bytes that most APIs take.
+
+const tdesSkip = "Triple DES is not implemented in package:cryptography"
+
+// tdesCbcEncrypt pads and encrypts the clear text with a 24-byte key.
+func tdesCbcEncrypt(key, iv, clearText []byte) ([]byte, error) {
+	block, err := des.NewTripleDESCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	cipherText := pkcs7Pad(clearText, des.BlockSize)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(cipherText, cipherText)
+	return cipherText, nil
+}
+
+func init() {
+	register(newGenerator("3des", tdesSuites).
+		uses("aes_cbc.go", "bytes.go", "dart.go", "suite.go"))
+}
+
+func tdesSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, option := range []struct {
+		keyingOption int
+		key          []byte
+	}{
+		{1, sequence(0x01, 24)},
+		{2, sequence(0x01, 16)},
+	} {
+		s := &Suite{
+			Name: fmt.Sprintf("3des-cbc: keying option %d", option.keyingOption),
+			Skip: tdesSkip,
+		}
+		expandedKey := option.key
+		if len(expandedKey) == 16 {
+			expandedKey = concat(option.key, option.key[:8])
+		}
+		nonce := sequence(0x80, des.BlockSize)
+		for _, n := range []int{0, 1, 7, 8, 9, 15, 16, 17, 24, 100} {
+			clearText := sequence(0x01, n)
+			cipherText, err := tdesCbcEncrypt(expandedKey, nonce, clearText)
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: describeBytes(clearText),
+				Fields: []Field{
+					{"keyingOption", option.keyingOption},
+					{"secretKey", option.key},
+					{"expandedKey", expandedKey},
+					{"nonce", nonce},
+					{"clearText", clearText},
+					{"cipherText", cipherText},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
@@ -0,0 +1,35 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/des"
+	"encoding/hex"
+	"testing"
+)
+
+func TestTdes(t *testing.T) {
+	// With three equal keys, Triple DES is single DES.
+	key := sequence(0x01, 8)
+	block, err := des.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := sequence(0x80, 8)
+	want := pkcs7Pad(sequence(0x01, 9), 8)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(want, want)
+	got, err := tdesCbcEncrypt(concat(key, key, key), iv, sequence(0x01, 9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %x, want %x", got, want)
+	}
+
+	suites, err := tdesSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "3des-cbc: keying option 2", "8 bytes")
+	checkHex(t, v, "expandedKey", hex.EncodeToString(concat(sequence(0x01, 16), sequence(0x01, 8))))
+}
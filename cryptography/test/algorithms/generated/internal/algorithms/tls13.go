@@ -0,0 +1,153 @@
+package algorithms
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF-Expand-Label and Derive-Secret of the TLS 1.3 key schedule (RFC 8446
+// section 7.1). The label is prefixed with "tls13 " and the HkdfLabel
+// structure is the info of HKDF-Expand. QUIC (RFC 9001) uses the same
+// function with its own labels.
+
+const tls13Skip = "HKDF-Expand-Label is not implemented in package:cryptography"
+
+// tls13HkdfLabel returns the encoded HkdfLabel structure.
+func tls13HkdfLabel(label string, context []byte, length int) []byte {
+	fullLabel := "tls13 " + label
+	return concat(
+		[]byte{byte(length >> 8), byte(length)},
+		[]byte{byte(len(fullLabel))}, []byte(fullLabel),
+		[]byte{byte(len(context))}, context,
+	)
+}
+
+func tls13ExpandLabel(h kdfHmac, secret []byte, label string, context []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(h.new, secret, tls13HkdfLabel(label, context, length)), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// tls13DeriveSecret hashes the messages and expands the secret with the
+// transcript hash as the context.
+func tls13DeriveSecret(h kdfHmac, secret []byte, label string, messages []byte) ([]byte, error) {
+	transcript := h.new()
+	transcript.Write(messages)
+	return tls13ExpandLabel(h, secret, label, transcript.Sum(nil), transcript.Size())
+}
+
+// tls13Labels are the labels of RFC 8446 and RFC 9001.
+var tls13Labels = []string{
+	"ext binder", "res binder", "c e traffic", "e exp master", "derived",
+	"c hs traffic", "s hs traffic", "c ap traffic", "s ap traffic",
+	"exp master", "res master", "resumption", "key", "iv", "finished",
+	"traffic upd", "quic key", "quic iv", "quic hp", "quic ku",
+}
+
+func init() {
+	register(newGenerator("tls13", tls13Suites).
+		uses("bytes.go", "hkdf.go", "suite.go"))
+}
+
+func tls13Suites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, h := range hkdfHashes[:2] {
+		hashLength := h.new().Size()
+		secret := sequence(0x20, hashLength)
+
+		expand := &Suite{Name: "tls13-" + h.name + ": hkdf-expand-label", Skip: tls13Skip}
+		type input struct {
+			label   string
+			context []byte
+			length  int
+		}
+		var inputs []input
+		for _, label := range tls13Labels {
+			inputs = append(inputs, input{label, nil, hashLength})
+		}
+		// Traffic keys and IVs of AES-128-GCM, AES-256-GCM and
+		// ChaCha20-Poly1305, and a context with a transcript hash.
+		for _, length := range []int{12, 16, 32} {
+			if length != hashLength {
+				inputs = append(inputs, input{"key", nil, length}, input{"iv", nil, length})
+			}
+		}
+		inputs = append(inputs,
+			input{"c hs traffic", sequence(0x80, hashLength), hashLength},
+			input{"exporter", sequence(0x80, hashLength), 100},
+		)
+		for _, in := range inputs {
+			expected, err := tls13ExpandLabel(h, secret, in.label, in.context, in.length)
+			if err != nil {
+				return nil, err
+			}
+			expand.Vectors = append(expand.Vectors, Vector{
+				Name: fmt.Sprintf("label %q, %d-byte context, %d-byte output", in.label, len(in.context), in.length),
+				Fields: []Field{
+					{"secret", secret},
+					{"label", in.label},
+					{"context", in.context},
+					{"length", in.length},
+					{"hkdfLabel", tls13HkdfLabel(in.label, in.context, in.length)},
+					{"expected", expected},
+				},
+			})
+		}
+
+		derive := &Suite{Name: "tls13-" + h.name + ": derive-secret", Skip: tls13Skip}
+		for _, label := range []string{"derived", "c hs traffic", "s hs traffic", "c ap traffic", "s ap traffic", "exp master", "res master"} {
+			for _, messages := range [][]byte{nil, sequence(0, 200)} {
+				expected, err := tls13DeriveSecret(h, secret, label, messages)
+				if err != nil {
+					return nil, err
+				}
+				derive.Vectors = append(derive.Vectors, Vector{
+					Name: fmt.Sprintf("label %q, %d-byte messages", label, len(messages)),
+					Fields: []Field{
+						{"secret", secret},
+						{"label", label},
+						{"messages", messages},
+						{"expected", expected},
+					},
+				})
+			}
+		}
+
+		// The secrets before the first transcript-dependent one, without a
+		// PSK. The shared secret of the RFC 8448 "Simple 1-RTT Handshake"
+		// gives its handshake secret with SHA-256.
+		schedule := &Suite{Name: "tls13-" + h.name + ": key schedule", Skip: tls13Skip}
+		for _, shared := range [][]byte{
+			mustHex("8bd4054fb55b9d63fdfbacf9f04b9f0d35e6d63f537563efd46272900f89492d"),
+			sequence(0x40, 48),
+		} {
+			zeros := make([]byte, hashLength)
+			earlySecret := hkdf.Extract(h.new, zeros, zeros)
+			derived, err := tls13DeriveSecret(h, earlySecret, "derived", nil)
+			if err != nil {
+				return nil, err
+			}
+			handshakeSecret := hkdf.Extract(h.new, shared, derived)
+			derived, err = tls13DeriveSecret(h, handshakeSecret, "derived", nil)
+			if err != nil {
+				return nil, err
+			}
+			masterSecret := hkdf.Extract(h.new, zeros, derived)
+			schedule.Vectors = append(schedule.Vectors, Vector{
+				Name: fmt.Sprintf("%d-byte shared secret", len(shared)),
+				Fields: []Field{
+					{"sharedSecret", shared},
+					{"earlySecret", earlySecret},
+					{"handshakeSecret", handshakeSecret},
+					{"masterSecret", masterSecret},
+				},
+			})
+		}
+		suites = append(suites, expand, derive, schedule)
+	}
+	return suites, nil
+}
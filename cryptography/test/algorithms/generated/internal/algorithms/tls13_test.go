@@ -0,0 +1,24 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestTls13(t *testing.T) {
+	suites, err := tls13Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 8448 section 3.
+	v := findVector(t, suites, "tls13-sha256: key schedule", "32-byte shared secret")
+	checkHex(t, v, "earlySecret", "33ad0a1c607ec03b09e6cd9893680ce210adf300aa1f2660e1b22e10f170f92a")
+	checkHex(t, v, "handshakeSecret", "1dc826e93606aa6fdc0aadc12f741b01046aa6b99f691ed221a9f0ca043fbeac")
+	derived, err := tls13DeriveSecret(hkdfHashes[0], mustHex("33ad0a1c607ec03b09e6cd9893680ce210adf300aa1f2660e1b22e10f170f92a"), "derived", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(derived), "6f2615a108c702c5678f54fc9dbab69716c076189c48250cebeac3576c3611ba"; got != want {
+		t.Errorf("derived = %s, want %s", got, want)
+	}
+}
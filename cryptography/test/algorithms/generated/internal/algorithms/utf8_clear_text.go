@@ -0,0 +1,116 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+)
+
+// Clear texts that are strings, in the shape of strings from user reports.
+// The Dart test encodes them with utf8.encode and decodes the decrypted bytes
+// with utf8.decode, which is where reported bugs were: lengths counted in
+// UTF-16 code units instead of bytes, and the last block being dropped.
+// Add new reports to utf8ClearTexts.
+
+const utf8ClearTextBody = `
+final clearTextBytes = utf8.encode(clearText);
+expect(hexFromBytes(clearTextBytes), hexFromBytes(clearTextUtf8));
+final algorithm = %s;
+final secretBox = await algorithm.encrypt(
+  clearTextBytes,
+  secretKey: SecretKey(secretKey),
+  nonce: nonce,
+);
+expect(
+  hexFromBytes(secretBox.cipherText),
+  hexFromBytes(cipherText),
+);
+expect(
+  hexFromBytes(secretBox.mac.bytes),
+  hexFromBytes(mac),
+);
+final decrypted = await algorithm.decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac(mac)),
+  secretKey: SecretKey(secretKey),
+);
+expect(utf8.decode(decrypted), clearText);
+`
+
+// utf8ClearTexts are the clear texts and what they reproduce.
+var utf8ClearTexts = []struct {
+	name, text string
+}{
+	// AES-CBC on Android returned a truncated string for a pipe-delimited
+	// list of UUIDs that is several blocks long.
+	{"pipe-delimited UUIDs", "0f8fad5b-d9cb-469f-a165-70867728950e|7c9e6679-7425-40de-944b-e07fc1f90ae7|6ba7b810-9dad-11d1-80b4-00c04fd430c8|3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+	// 8 characters, 16 bytes.
+	{"one block of two-byte characters", "äöüßäöüß"},
+	// Surrogate pairs in Dart strings.
+	{"emoji in JSON", `{"message":"hello \U0001f44b","user":"ユーザー"}`},
+	{"CRLF line endings", "line 1\r\nline 2\r\n"},
+	{"trailing spaces and NUL", "secret   \x00"},
+}
+
+func init() {
+	register(newGenerator("utf8-clear-texts", utf8ClearTextSuites).
+		uses("aes_cbc.go", "bytes.go", "chacha20_poly1305.go", "suite.go"))
+}
+
+func utf8ClearTextSuites() ([]*Suite, error) {
+	key := sequence(0, 32)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	var suites []*Suite
+	for _, c := range []struct {
+		name, dart, covers string
+		nonceLength        int
+		encrypt            func(nonce, clearText []byte) (cipherText, mac []byte, err error)
+	}{
+		{"aes-cbc", "AesCbc.with256bits(macAlgorithm: Hmac.sha256())", "AesCbc macAlgorithm=Hmac.sha256", 16, func(nonce, clearText []byte) ([]byte, []byte, error) {
+			cipherText, err := aesCbcEncrypt(key, nonce, clearText)
+			if err != nil {
+				return nil, nil, err
+			}
+			return cipherText, aesCbcMacs[0].mac(key, cipherText), nil
+		}},
+		{"aes-gcm", "AesGcm.with256bits()", "AesGcm", 12, func(nonce, clearText []byte) ([]byte, []byte, error) {
+			sealed := gcm.Seal(nil, nonce, clearText, nil)
+			return sealed[:len(clearText)], sealed[len(clearText):], nil
+		}},
+		{"chacha20-poly1305", "Chacha20.poly1305Aead()", "Chacha20.poly1305Aead", 12, func(nonce, clearText []byte) ([]byte, []byte, error) {
+			return chacha20Poly1305Seal(key, nonce, clearText, nil)
+		}},
+	} {
+		s := &Suite{
+			Name:   c.name + ": utf-8 clear texts",
+			Body:   fmt.Sprintf(utf8ClearTextBody, c.dart),
+			Covers: []string{c.covers},
+		}
+		nonce := sequence(0x80, c.nonceLength)
+		for _, t := range utf8ClearTexts {
+			cipherText, mac, err := c.encrypt(nonce, []byte(t.text))
+			if err != nil {
+				return nil, err
+			}
+			s.Vectors = append(s.Vectors, Vector{
+				Name: t.name,
+				Fields: []Field{
+					{"secretKey", key},
+					{"nonce", nonce},
+					{"clearText", t.text},
+					{"clearTextUtf8", []byte(t.text)},
+					{"cipherText", cipherText},
+					{"mac", mac},
+				},
+			})
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
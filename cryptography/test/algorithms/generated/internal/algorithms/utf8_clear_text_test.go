@@ -0,0 +1,18 @@
+package algorithms
+
+import (
+	"testing"
+)
+
+func TestUtf8ClearTexts(t *testing.T) {
+	suites, err := utf8ClearTextSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := findVector(t, suites, "aes-cbc: utf-8 clear texts", "one block of two-byte characters")
+	checkHex(t, v, "clearTextUtf8", "c3a4c3b6c3bcc39fc3a4c3b6c3bcc39f")
+	// PKCS7 adds a whole block.
+	if n := len(v.Fields[4].Value.([]byte)); n != 32 {
+		t.Errorf("cipher text is %d bytes, want 32", n)
+	}
+}
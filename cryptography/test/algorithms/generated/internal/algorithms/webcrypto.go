@@ -0,0 +1,175 @@
+package algorithms
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// What the Web Cryptography API produces with its defaults: AES-GCM with a
+// 12-byte IV and a 128-bit tag, HMAC keys as long as the block size, ECDSA
+// signatures in IEEE P1363 format (r || s), and keys in the "raw", "spki" and
+// "pkcs8" formats of exportKey. The suites run only on the platforms where
+// package:cryptography uses BrowserCryptography: AES and HMAC on every
+// browser, and ECDSA and ECDH on Chrome like the other EC suites.
+
+// webCryptoEcHashes are the hashes that are used with each curve.
+var webCryptoEcHashes = map[string]ecdsaHash{
+	"p256": {"Sha256", crypto.SHA256},
+	"p384": {"Sha384", crypto.SHA384},
+	"p521": {"Sha512", crypto.SHA512},
+}
+
+// webCryptoEcKeyFields returns the fields of an EC key pair in the formats of
+// exportKey.
+func webCryptoEcKeyFields(c nistCurve, d []byte) ([]Field, error) {
+	privateKey, err := ecdsa.ParseRawPrivateKey(c.elliptic, d)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := privateKey.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	spki, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return []Field{
+		{"privateKey", d},
+		{"privateKeyPkcs8", pkcs8},
+		{"publicKey", raw},
+		{"publicKeySpki", spki},
+	}, nil
+}
+
+func init() {
+	register(newGenerator("webcrypto", webCryptoSuites).
+		uses("aes_cbc.go", "aes_gcm.go", "bytes.go", "chacha20_poly1305.go", "dart.go", "ecdh.go", "ecdsa.go", "hash.go", "suite.go"))
+}
+
+func webCryptoSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, keyLength := range []int{16, 32} {
+		s := &Suite{
+			Name:   fmt.Sprintf("webcrypto: aes-gcm, %d-bit key", 8*keyLength),
+			Body:   fmt.Sprintf(aesGcmBody, 8*keyLength),
+			TestOn: "browser",
+			Covers: []string{fmt.Sprintf("AesGcm secretKeyLength=%d nonceLength=12", keyLength)},
+		}
+		key := sequence(0, keyLength)
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		nonce := sequence(0x80, gcm.NonceSize())
+		for _, n := range []int{0, 1, 16, 17, 100} {
+			for _, aad := range [][]byte{nil, sequence(0xa0, 13)} {
+				clearText := sequence(0x01, n)
+				sealed := gcm.Seal(nil, nonce, clearText, aad)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%s, %s of AAD", describeBytes(clearText), describeBytes(aad)),
+					Fields: []Field{
+						{"secretKey", key},
+						{"nonce", nonce},
+						{"aad", aad},
+						{"clearText", clearText},
+						{"cipherText", sealed[:n]},
+						{"mac", sealed[n:]},
+					},
+				})
+			}
+		}
+		addConcatenation(s)
+		suites = append(suites, s)
+	}
+
+	hmacSuite := &Suite{
+		Name:   "webcrypto: hmac-sha-256",
+		Body:   fmt.Sprintf(hmacBody, "Sha256"),
+		TestOn: "browser",
+		Covers: []string{"Hmac hashAlgorithm=Sha256"},
+	}
+	hmacKey := sequence(0x40, sha256.BlockSize)
+	for _, n := range []int{0, 1, 64, 100} {
+		hmacSuite.Vectors = append(hmacSuite.Vectors, Vector{
+			Name: describeLength(n) + ", 64-byte key",
+			Fields: []Field{
+				{"secretKey", hmacKey},
+				{"data", sequence(0x01, n)},
+				{"expected", aesCbcMacs[0].mac(hmacKey, sequence(0x01, n))},
+			},
+		})
+	}
+	suites = append(suites, hmacSuite)
+
+	for _, c := range nistCurves {
+		h := webCryptoEcHashes[c.name]
+		keyFields, err := webCryptoEcKeyFields(c, c.sequenceScalar(0x40))
+		if err != nil {
+			return nil, err
+		}
+		verify := &Suite{
+			Name:   fmt.Sprintf("webcrypto: ecdsa-%s-%s: verify", c.name, strings.ToLower(h.dart)),
+			Body:   fmt.Sprintf(ecdsaVerifyBody, c.name, h.dart),
+			TestOn: "chrome",
+			Covers: []string{fmt.Sprintf("Ecdsa curve=%s hashAlgorithm=%s", c.name, h.dart)},
+		}
+		for _, message := range [][]byte{nil, []byte("sample"), sequence(0x01, 100)} {
+			signature, err := ecdsaSign(c, h.hash, c.sequenceScalar(0x40), message)
+			if err != nil {
+				return nil, err
+			}
+			verify.Vectors = append(verify.Vectors, Vector{
+				Name: describeBytes(message),
+				Fields: append(append([]Field(nil), keyFields...),
+					Field{"message", message},
+					Field{"signature", signature},
+					Field{"valid", true},
+				),
+			})
+		}
+		suites = append(suites, verify)
+
+		ecdh := &Suite{
+			Name:   "webcrypto: ecdh-" + c.name,
+			Body:   fmt.Sprintf(ecdhBody, c.name),
+			TestOn: "chrome",
+			Covers: []string{"Ecdh curve=" + c.name},
+		}
+		privateKey, err := c.ecdh.NewPrivateKey(c.sequenceScalar(0x40))
+		if err != nil {
+			return nil, err
+		}
+		peer, err := c.ecdh.NewPrivateKey(c.sequenceScalar(0x80))
+		if err != nil {
+			return nil, err
+		}
+		sharedSecret, err := privateKey.ECDH(peer.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		ecdh.Vectors = append(ecdh.Vectors, Vector{
+			Name: "sequence keys",
+			Fields: append(append([]Field(nil), keyFields...),
+				Field{"peerPublicKey", peer.PublicKey().Bytes()},
+				Field{"sharedSecret", sharedSecret},
+			),
+		})
+		suites = append(suites, ecdh)
+	}
+	return suites, nil
+}
@@ -0,0 +1,49 @@
+package algorithms
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"testing"
+)
+
+func TestWebCrypto(t *testing.T) {
+	suites, err := webCryptoSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The exported formats contain the raw keys.
+	v := findVector(t, suites, "webcrypto: ecdsa-p256-sha256: verify", "6 bytes")
+	values := map[string][]byte{}
+	for _, f := range v.Fields {
+		if b, ok := f.Value.([]byte); ok {
+			values[f.Name] = b
+		}
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(values["publicKeySpki"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := publicKey.(*ecdsa.PublicKey).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, values["publicKey"]) {
+		t.Errorf("spki has %x, want %x", raw, values["publicKey"])
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(values["privateKeyPkcs8"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := privateKey.(*ecdsa.PrivateKey).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(d, values["privateKey"]) {
+		t.Errorf("pkcs8 has %x, want %x", d, values["privateKey"])
+	}
+	// P1363 signatures are r || s.
+	if len(values["signature"]) != 64 {
+		t.Errorf("signature has %d bytes, want 64", len(values["signature"]))
+	}
+}
@@ -0,0 +1,356 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+)
+
+// Wycheproof results are "valid", "invalid" or "acceptable". Acceptable test
+// cases may be accepted or rejected, so they are not converted.
+
+// The AEAD and X25519 bodies run the algorithm in closures, so that errors
+// thrown by constructors, such as for unsupported nonce lengths, count as
+// rejection too.
+const wycheproofAeadBody = `
+Cipher algorithm() => %s;
+if (valid) {
+  final secretBox = await algorithm().encrypt(
+    clearText,
+    secretKey: SecretKey(secretKey),
+    nonce: nonce,
+    aad: aad,
+  );
+  expect(
+    hexFromBytes(secretBox.cipherText),
+    hexFromBytes(cipherText),
+  );
+  expect(
+    hexFromBytes(secretBox.mac.bytes),
+    hexFromBytes(mac),
+  );
+}
+final decrypt = () => algorithm().decrypt(
+  SecretBox(cipherText, nonce: nonce, mac: Mac(mac)),
+  secretKey: SecretKey(secretKey),
+  aad: aad,
+);
+if (valid) {
+  expect(
+    hexFromBytes(await decrypt()),
+    hexFromBytes(clearText),
+  );
+} else {
+  await expectLater(decrypt, throwsA(anything));
+}
+`
+
+const wycheproofX25519Body = `
+final sharedSecretKey = () async {
+  final algorithm = X25519();
+  final keyPair = await algorithm.newKeyPairFromSeed(privateKey);
+  return algorithm.sharedSecretKey(
+    keyPair: keyPair,
+    remotePublicKey: SimplePublicKey(publicKey, type: KeyPairType.x25519),
+  );
+};
+if (valid) {
+  expect(
+    hexFromBytes(await (await sharedSecretKey()).extractBytes()),
+    hexFromBytes(sharedSecret),
+  );
+} else {
+  await expectLater(sharedSecretKey, throwsA(anything));
+}
+`
+
+// Like ed25519RejectBody, ArgumentError counts as rejection.
+const wycheproofEd25519Body = `
+var isValid = false;
+try {
+  isValid = await Ed25519().verify(
+    message,
+    signature: Signature(
+      signature,
+      publicKey: SimplePublicKey(publicKey, type: KeyPairType.ed25519),
+    ),
+  );
+} on ArgumentError {
+  isValid = false;
+}
+expect(isValid, valid);
+`
+
+const wycheproofRsaSsaPkcs1v15Body = `
+var isValid = false;
+try {
+  isValid = await RsaSsaPkcs1v15(%s()).verify(
+    message,
+    signature: Signature(signature, publicKey: RsaPublicKey(e: e, n: n)),
+  );
+} on ArgumentError {
+  isValid = false;
+}
+expect(isValid, valid);
+`
+
+// wycheproofHex is a hex-encoded byte string of a Wycheproof file.
+type wycheproofHex []byte
+
+func (h *wycheproofHex) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(s)
+	*h = b
+	return err
+}
+
+// wycheproofFile has the members of the test vector schemas that the
+// importer uses. Unknown members are ignored.
+type wycheproofFile struct {
+	TestGroups []wycheproofGroup `json:"testGroups"`
+}
+
+type wycheproofGroup struct {
+	KeySize int    `json:"keySize"`
+	TagSize int    `json:"tagSize"`
+	Sha     string `json:"sha"`
+
+	PublicKey struct {
+		Pk             wycheproofHex `json:"pk"`
+		Uncompressed   wycheproofHex `json:"uncompressed"`
+		Modulus        wycheproofHex `json:"modulus"`
+		PublicExponent wycheproofHex `json:"publicExponent"`
+	} `json:"publicKey"`
+
+	Tests []wycheproofTest `json:"tests"`
+}
+
+type wycheproofTest struct {
+	TcID    int    `json:"tcId"`
+	Comment string `json:"comment"`
+	Result  string `json:"result"`
+
+	Key     wycheproofHex `json:"key"`
+	Iv      wycheproofHex `json:"iv"`
+	Aad     wycheproofHex `json:"aad"`
+	Msg     wycheproofHex `json:"msg"`
+	Ct      wycheproofHex `json:"ct"`
+	Tag     wycheproofHex `json:"tag"`
+	Public  wycheproofHex `json:"public"`
+	Private wycheproofHex `json:"private"`
+	Shared  wycheproofHex `json:"shared"`
+	Sig     wycheproofHex `json:"sig"`
+}
+
+// wycheproofVector returns a vector of the test case with the fields and a
+// "valid" field, or false if the result is "acceptable".
+func wycheproofVector(t wycheproofTest, fields ...Field) (Vector, bool, error) {
+	switch t.Result {
+	case "valid", "invalid":
+	case "acceptable":
+		return Vector{}, false, nil
+	default:
+		return Vector{}, false, fmt.Errorf("tcId %d: unknown result %q", t.TcID, t.Result)
+	}
+	name := fmt.Sprintf("tcId %d", t.TcID)
+	if t.Comment != "" {
+		name += ": " + t.Comment
+	}
+	return Vector{
+		Name:   name,
+		Fields: append(fields, Field{"valid", t.Result == "valid"}),
+	}, true, nil
+}
+
+// wycheproofConverter converts a Wycheproof file to suites.
+type wycheproofConverter struct {
+	// corpus is the name of the file in corporaPath.
+	corpus string
+
+	convert func(f *wycheproofFile) ([]*Suite, error)
+}
+
+var wycheproofConverters = []wycheproofConverter{
+	{"wycheproof/aes_gcm_test.json", wycheproofAesGcm},
+	{"wycheproof/chacha20_poly1305_test.json", wycheproofChacha20Poly1305},
+	{"wycheproof/x25519_test.json", wycheproofX25519},
+	{"wycheproof/ed25519_test.json", wycheproofEd25519},
+	{"wycheproof/ecdsa_secp256r1_sha256_p1363_test.json", wycheproofEcdsaP256},
+	{"wycheproof/rsa_signature_2048_sha256_test.json", wycheproofRsaSsaPkcs1v15},
+}
+
+// wycheproofConvert adds the vectors of every test case of the groups for
+// which accept returns true to s. The vector fields are returned by fields.
+func wycheproofConvert(s *Suite, f *wycheproofFile, accept func(g *wycheproofGroup) bool, fields func(g *wycheproofGroup, t wycheproofTest) []Field) error {
+	skipped := 0
+	for i := range f.TestGroups {
+		g := &f.TestGroups[i]
+		if !accept(g) {
+			skipped += len(g.Tests)
+			continue
+		}
+		for _, t := range g.Tests {
+			v, ok, err := wycheproofVector(t, fields(g, t)...)
+			if err != nil {
+				return fmt.Errorf("%s: %w", s.Name, err)
+			}
+			if !ok {
+				skipped++
+				continue
+			}
+			s.Vectors = append(s.Vectors, v)
+		}
+	}
+	if len(s.Vectors) == 0 {
+		return fmt.Errorf("%s: no test cases", s.Name)
+	}
+	slog.Info("converted", "suite", s.Name, "vectors", len(s.Vectors), "skipped", skipped)
+	return nil
+}
+
+func wycheproofAeadFields(g *wycheproofGroup, t wycheproofTest) []Field {
+	return []Field{
+		{"secretKey", []byte(t.Key)},
+		{"nonce", []byte(t.Iv)},
+		{"aad", []byte(t.Aad)},
+		{"clearText", []byte(t.Msg)},
+		{"cipherText", []byte(t.Ct)},
+		{"mac", []byte(t.Tag)},
+	}
+}
+
+// Dart secret boxes have 16-byte MACs, so groups with truncated tags are
+// skipped.
+func wycheproofAesGcm(f *wycheproofFile) ([]*Suite, error) {
+	var suites []*Suite
+	for _, keySize := range []int{128, 192, 256} {
+		s := &Suite{
+			Name: fmt.Sprintf("wycheproof: aes-gcm, %d-bit key", keySize),
+			Body: fmt.Sprintf(wycheproofAeadBody, fmt.Sprintf("AesGcm.with%dbits(nonceLength: nonce.length)", keySize)),
+		}
+		if err := wycheproofConvert(s, f, func(g *wycheproofGroup) bool {
+			return g.KeySize == keySize && g.TagSize == 128
+		}, wycheproofAeadFields); err != nil {
+			return nil, err
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+func wycheproofChacha20Poly1305(f *wycheproofFile) ([]*Suite, error) {
+	s := &Suite{
+		Name: "wycheproof: chacha20-poly1305",
+		Body: fmt.Sprintf(wycheproofAeadBody, "Chacha20.poly1305Aead()"),
+	}
+	if err := wycheproofConvert(s, f, func(g *wycheproofGroup) bool {
+		return g.TagSize == 128
+	}, wycheproofAeadFields); err != nil {
+		return nil, err
+	}
+	return []*Suite{s}, nil
+}
+
+func wycheproofX25519(f *wycheproofFile) ([]*Suite, error) {
+	s := &Suite{Name: "wycheproof: x25519", Body: wycheproofX25519Body}
+	if err := wycheproofConvert(s, f, func(g *wycheproofGroup) bool {
+		return true
+	}, func(g *wycheproofGroup, t wycheproofTest) []Field {
+		return []Field{
+			{"privateKey", []byte(t.Private)},
+			{"publicKey", []byte(t.Public)},
+			{"sharedSecret", []byte(t.Shared)},
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return []*Suite{s}, nil
+}
+
+func wycheproofEd25519(f *wycheproofFile) ([]*Suite, error) {
+	s := &Suite{Name: "wycheproof: ed25519", Body: wycheproofEd25519Body}
+	if err := wycheproofConvert(s, f, func(g *wycheproofGroup) bool {
+		return true
+	}, func(g *wycheproofGroup, t wycheproofTest) []Field {
+		return []Field{
+			{"publicKey", []byte(g.PublicKey.Pk)},
+			{"message", []byte(t.Msg)},
+			{"signature", []byte(t.Sig)},
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return []*Suite{s}, nil
+}
+
+// The P1363 file has r || s signatures like Ecdsa in package:cryptography.
+func wycheproofEcdsaP256(f *wycheproofFile) ([]*Suite, error) {
+	s := &Suite{
+		Name:   "wycheproof: ecdsa p-256, sha-256",
+		Body:   fmt.Sprintf(ecdsaVerifyBody, "p256", "Sha256"),
+		TestOn: "chrome",
+	}
+	if err := wycheproofConvert(s, f, func(g *wycheproofGroup) bool {
+		return g.Sha == "SHA-256"
+	}, func(g *wycheproofGroup, t wycheproofTest) []Field {
+		return []Field{
+			{"publicKey", []byte(g.PublicKey.Uncompressed)},
+			{"message", []byte(t.Msg)},
+			{"signature", []byte(t.Sig)},
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return []*Suite{s}, nil
+}
+
+func wycheproofRsaSsaPkcs1v15(f *wycheproofFile) ([]*Suite, error) {
+	s := &Suite{
+		Name:   "wycheproof: rsassa-pkcs1-v1_5 2048-bit key, sha-256",
+		Body:   fmt.Sprintf(wycheproofRsaSsaPkcs1v15Body, "Sha256"),
+		TestOn: "chrome",
+	}
+	if err := wycheproofConvert(s, f, func(g *wycheproofGroup) bool {
+		return g.Sha == "SHA-256"
+	}, func(g *wycheproofGroup, t wycheproofTest) []Field {
+		// The modulus has a leading zero byte, like a DER integer.
+		return []Field{
+			{"n", new(big.Int).SetBytes(g.PublicKey.Modulus).Bytes()},
+			{"e", new(big.Int).SetBytes(g.PublicKey.PublicExponent).Bytes()},
+			{"message", []byte(t.Msg)},
+			{"signature", []byte(t.Sig)},
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return []*Suite{s}, nil
+}
+
+// WycheproofSuites converts the Wycheproof files in the cache directory.
+func WycheproofSuites(cacheDir string) ([]*Suite, error) {
+	var suites []*Suite
+	for _, c := range wycheproofConverters {
+		data, err := openCorpus(cacheDir, c.corpus)
+		if err != nil {
+			return nil, err
+		}
+		var f wycheproofFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.corpus, err)
+		}
+		s, err := c.convert(&f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.corpus, err)
+		}
+		suites = append(suites, s...)
+	}
+	if err := canonicalize(suites); err != nil {
+		return nil, err
+	}
+	return suites, nil
+}
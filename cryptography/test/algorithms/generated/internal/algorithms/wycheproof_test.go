@@ -0,0 +1,91 @@
+package algorithms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"testing"
+)
+
+func TestWycheproofAesGcm(t *testing.T) {
+	const data = `{
+  "algorithm": "AES-GCM",
+  "testGroups": [
+    {
+      "type": "AeadTest",
+      "keySize": 128,
+      "ivSize": 96,
+      "tagSize": 128,
+      "tests": [
+        {"tcId": 1, "comment": "", "flags": [], "key": "5b9604fe14eadba931b0ccf34843dab9", "iv": "028318abc1824029138141a2", "aad": "", "msg": "001d0c231287c1182784554ca3a21908", "ct": "26073cc1d851beff176384dc9896d5ff", "tag": "0a3ea7a5487cb5f7d70fb6c58d038554", "result": "valid"},
+        {"tcId": 2, "comment": "Flipped bit 0 in tag", "flags": ["ModifiedTag"], "key": "5b9604fe14eadba931b0ccf34843dab9", "iv": "028318abc1824029138141a2", "aad": "", "msg": "001d0c231287c1182784554ca3a21908", "ct": "26073cc1d851beff176384dc9896d5ff", "tag": "0b3ea7a5487cb5f7d70fb6c58d038554", "result": "invalid"},
+        {"tcId": 3, "comment": "acceptable", "flags": [], "key": "5b9604fe14eadba931b0ccf34843dab9", "iv": "", "aad": "", "msg": "", "ct": "", "tag": "", "result": "acceptable"}
+      ]
+    },
+    {
+      "type": "AeadTest",
+      "keySize": 128,
+      "ivSize": 96,
+      "tagSize": 96,
+      "tests": [
+        {"tcId": 4, "comment": "truncated tag", "flags": [], "key": "", "iv": "", "aad": "", "msg": "", "ct": "", "tag": "", "result": "valid"}
+      ]
+    },
+    {
+      "type": "AeadTest",
+      "keySize": 256,
+      "ivSize": 96,
+      "tagSize": 128,
+      "tests": [
+        {"tcId": 5, "comment": "", "flags": [], "key": "", "iv": "", "aad": "", "msg": "", "ct": "", "tag": "", "result": "valid"}
+      ]
+    }
+  ]
+}`
+	var f wycheproofFile
+	if err := json.Unmarshal([]byte(data), &f); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wycheproofAesGcm(&f); err == nil {
+		t.Fatal("no error for a key size without test cases")
+	}
+	f.TestGroups = append(f.TestGroups, wycheproofGroup{KeySize: 192, TagSize: 128, Tests: []wycheproofTest{{TcID: 6, Result: "invalid"}}})
+	suites, err := wycheproofAesGcm(&f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := canonicalize(suites); err != nil {
+		t.Fatal(err)
+	}
+	s := suites[0]
+	if s.Name != "wycheproof: aes-gcm, 128-bit key" || len(s.Vectors) != 2 {
+		t.Fatalf("suite %q has %d vectors, want 2", s.Name, len(s.Vectors))
+	}
+	valid := findVector(t, suites, s.Name, "tcId 1")
+	checkHex(t, valid, "mac", "0a3ea7a5487cb5f7d70fb6c58d038554")
+	invalid := findVector(t, suites, s.Name, "tcId 2: Flipped bit 0 in tag")
+	if last := invalid.Fields[len(invalid.Fields)-1]; last.Name != "valid" || last.Value != false {
+		t.Errorf("last field is %v, want valid = false", last)
+	}
+
+	// The sample test cases are checked with crypto/cipher too.
+	for _, c := range f.TestGroups[0].Tests[:2] {
+		block, err := aes.NewCipher(c.Key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = gcm.Open(nil, c.Iv, concat(c.Ct, c.Tag), c.Aad)
+		if (err == nil) != (c.Result == "valid") {
+			t.Errorf("tcId %d: crypto/cipher returned %v for a %s test case", c.TcID, err, c.Result)
+		}
+	}
+
+	f.TestGroups[0].Tests[0].Result = "unknown"
+	if _, err := wycheproofAesGcm(&f); err == nil {
+		t.Error("no error for an unknown result")
+	}
+}
@@ -0,0 +1,152 @@
+package algorithms
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// X25519 (RFC 7748). Private keys are clamped when they are used and the
+// most significant bit of a public key is ignored, so different encodings
+// give the same result.
+
+const x25519Body = `
+final algorithm = X25519();
+final keyPair = await algorithm.newKeyPairFromSeed(privateKey);
+final actualPublicKey = await keyPair.extractPublicKey() as SimplePublicKey;
+expect(
+  hexFromBytes(actualPublicKey.bytes),
+  hexFromBytes(publicKey),
+);
+final sharedSecretKey = await algorithm.sharedSecretKey(
+  keyPair: keyPair,
+  remotePublicKey: SimplePublicKey(
+    peerPublicKey,
+    type: KeyPairType.x25519,
+  ),
+);
+expect(
+  hexFromBytes(await sharedSecretKey.extractBytes()),
+  hexFromBytes(sharedSecret),
+);
+`
+
+// RFC 7748 allows implementations to reject the all-zero shared secret of a
+// low-order public key, so an ArgumentError is accepted too.
+const x25519LowOrderBody = `
+final algorithm = X25519();
+final keyPair = await algorithm.newKeyPairFromSeed(privateKey);
+try {
+  final sharedSecretKey = await algorithm.sharedSecretKey(
+    keyPair: keyPair,
+    remotePublicKey: SimplePublicKey(
+      peerPublicKey,
+      type: KeyPairType.x25519,
+    ),
+  );
+  expect(
+    hexFromBytes(await sharedSecretKey.extractBytes()),
+    hexFromBytes(sharedSecret),
+  );
+} on ArgumentError {
+  // Rejected.
+}
+`
+
+// x25519LowOrderPoints are the canonical encodings of the public keys of
+// small order: 0, 1, two points of order 8, p-1, p and p+1.
+var x25519LowOrderPoints = []string{
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"0100000000000000000000000000000000000000000000000000000000000000",
+	"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800",
+	"5f9c95bca3508c24b1d0b1559c83ef5b04445cc4581c8e86d8224eddd09f1157",
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+}
+
+// x25519 returns the shared secret. Unlike curve25519.X25519, it does not
+// reject the all-zero output.
+func x25519(privateKey, peerPublicKey []byte) []byte {
+	var dst, scalar, point [32]byte
+	copy(scalar[:], privateKey)
+	copy(point[:], peerPublicKey)
+	curve25519.ScalarMult(&dst, &scalar, &point)
+	return dst[:]
+}
+
+func init() {
+	register(newGenerator("x25519", x25519Suites).
+		uses("bytes.go", "suite.go"))
+}
+
+func x25519Suites() ([]*Suite, error) {
+	clamping := &Suite{
+		Name:   "x25519: clamping",
+		Body:   x25519Body,
+		Covers: []string{"X25519"},
+	}
+	peerPublicKey := x25519(sequence(0x80, 32), curve25519.Basepoint)
+	withHighBit := append([]byte(nil), peerPublicKey...)
+	withHighBit[31] |= 0x80
+	clamped := sequence(0x40, 32)
+	clamped[0] &= 0xf8
+	clamped[31] = clamped[31]&0x7f | 0x40
+	for _, tc := range []struct {
+		name          string
+		privateKey    []byte
+		peerPublicKey []byte
+	}{
+		{"clamped private key", clamped, peerPublicKey},
+		{"unclamped private key", sequence(0x40, 32), peerPublicKey},
+		{"low bits set", append([]byte{clamped[0] | 7}, clamped[1:]...), peerPublicKey},
+		{"bit 254 clear", append(append([]byte(nil), clamped[:31]...), clamped[31]&^0x40), peerPublicKey},
+		{"bit 255 set", append(append([]byte(nil), clamped[:31]...), clamped[31]|0x80), peerPublicKey},
+		{"all-zero private key", make([]byte, 32), peerPublicKey},
+		{"all-0xff private key", bytes.Repeat([]byte{0xff}, 32), peerPublicKey},
+		{"peer public key with bit 255 set", clamped, withHighBit},
+	} {
+		clamping.Vectors = append(clamping.Vectors, Vector{
+			Name: tc.name,
+			Fields: []Field{
+				{"privateKey", tc.privateKey},
+				{"publicKey", x25519(tc.privateKey, curve25519.Basepoint)},
+				{"peerPublicKey", tc.peerPublicKey},
+				{"sharedSecret", x25519(tc.privateKey, tc.peerPublicKey)},
+			},
+		})
+	}
+
+	// Every low-order point is also tested with the most significant bit
+	// set, which is ignored.
+	lowOrder := &Suite{
+		Name:   "x25519: low-order public keys",
+		Body:   x25519LowOrderBody,
+		Covers: []string{"X25519"},
+	}
+	privateKey := sequence(0x40, 32)
+	for _, h := range x25519LowOrderPoints {
+		for _, highBit := range []bool{false, true} {
+			point := mustHex(h)
+			name := h
+			if highBit {
+				point[31] |= 0x80
+				name += " with bit 255 set"
+			}
+			sharedSecret := x25519(privateKey, point)
+			if !bytes.Equal(sharedSecret, make([]byte, 32)) {
+				return nil, fmt.Errorf("x25519: %s: shared secret is not zero", name)
+			}
+			lowOrder.Vectors = append(lowOrder.Vectors, Vector{
+				Name: name,
+				Fields: []Field{
+					{"privateKey", privateKey},
+					{"peerPublicKey", point},
+					{"sharedSecret", sharedSecret},
+				},
+			})
+		}
+	}
+	return []*Suite{clamping, lowOrder}, nil
+}
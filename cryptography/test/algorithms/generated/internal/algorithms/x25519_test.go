@@ -0,0 +1,17 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestX25519(t *testing.T) {
+	// RFC 7748 section 5.2, first test vector.
+	got := x25519(
+		mustHex("a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4"),
+		mustHex("e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c"),
+	)
+	if want := "c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552"; hex.EncodeToString(got) != want {
+		t.Errorf("got %x, want %s", got, want)
+	}
+}
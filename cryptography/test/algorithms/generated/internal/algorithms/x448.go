@@ -0,0 +1,84 @@
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/cloudflare/circl/dh/x448"
+)
+
+// X448 (RFC 7748). The RFC 7748 inputs are included so that the Dart
+// implementation can be checked against the RFC before the generated keys.
+
+const x448Skip = "X448 is not implemented in package:cryptography"
+
+// x448Vector returns a vector for the private key and the peer public key.
+func x448Vector(name string, privateKey, peerPublicKey []byte) (Vector, error) {
+	var secret, public, peer, shared x448.Key
+	copy(secret[:], privateKey)
+	copy(peer[:], peerPublicKey)
+	x448.KeyGen(&public, &secret)
+	if !x448.Shared(&shared, &secret, &peer) {
+		return Vector{}, fmt.Errorf("x448: %s: low-order public key", name)
+	}
+	return Vector{
+		Name: name,
+		Fields: []Field{
+			{"privateKey", privateKey},
+			{"publicKey", public[:]},
+			{"peerPublicKey", peerPublicKey},
+			{"sharedSecret", shared[:]},
+		},
+	}, nil
+}
+
+// x448PublicKey returns the public key of a private key.
+func x448PublicKey(privateKey []byte) []byte {
+	var secret, public x448.Key
+	copy(secret[:], privateKey)
+	x448.KeyGen(&public, &secret)
+	return public[:]
+}
+
+func init() {
+	register(newGenerator("x448", x448Suites).
+		uses("bytes.go", "suite.go"))
+}
+
+func x448Suites() ([]*Suite, error) {
+	s := &Suite{Name: "x448", Skip: x448Skip}
+	alice := mustHex("9a8f4925d1519f5775cf46b04b5800d4ee9ee8bae8bc5565d498c28dd9c9baf574a9419744897391006382a6f127ab1d9ac2d8c0a598726b")
+	bob := mustHex("1c306a7ac2a0e2e0990b294470cba339e6453772b075811d8fad0d1d6927c120bb5ee8972b0d3e21374c9c921b09d1b0366f10b65173992d")
+	type input struct {
+		name          string
+		privateKey    []byte
+		peerPublicKey []byte
+	}
+	inputs := []input{
+		{
+			"RFC 7748 section 5.2, first vector",
+			mustHex("3d262fddf9ec8e88495266fea19a34d28882acef045104d0d1aae121700a779c984c24f8cdd78fbff44943eba368f54b29259a4f1c600ad3"),
+			mustHex("06fce640fa3487bfda5f6cf2d5263f8aad88334cbd07437f020f08f9814dc031ddbdc38c19c6da2583fa5429db94ada18aa7a7fb4ef8a086"),
+		},
+		{
+			"RFC 7748 section 5.2, second vector",
+			mustHex("203d494428b8399352665ddca42f9de8fef600908e0d461cb021f8c538345dd77c3e4806e25f46d3315c44e0a5b4371282dd2c8d5be3095f"),
+			mustHex("0fbcc2f993cd56d3305b0b7d9e55d4c1a8fb5dbb52f8e9a1e9b6201b165d015894e56c4d3570bee52fe205e28a78b91cdfbde71ce8d157db"),
+		},
+		{"RFC 7748 section 6.2, Alice", alice, x448PublicKey(bob)},
+		{"RFC 7748 section 6.2, Bob", bob, x448PublicKey(alice)},
+	}
+	peerPublicKey := x448PublicKey(sequence(0xc0, x448.Size))
+	for _, start := range []byte{0x00, 0x40, 0x80} {
+		privateKey := sequence(start, x448.Size)
+		name := fmt.Sprintf("private key %02x..%02x", privateKey[0], privateKey[len(privateKey)-1])
+		inputs = append(inputs, input{name, privateKey, peerPublicKey})
+	}
+	for _, in := range inputs {
+		v, err := x448Vector(in.name, in.privateKey, in.peerPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		s.Vectors = append(s.Vectors, v)
+	}
+	return []*Suite{s}, nil
+}
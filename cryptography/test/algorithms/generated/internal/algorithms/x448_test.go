@@ -0,0 +1,23 @@
+package algorithms
+
+import (
+	"testing"
+)
+
+func TestX448(t *testing.T) {
+	suites, err := x448Suites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// RFC 7748 sections 5.2 and 6.2.
+	for _, tc := range []struct {
+		name, want string
+	}{
+		{"RFC 7748 section 5.2, first vector", "ce3e4ff95a60dc6697da1db1d85e6afbdf79b50a2412d7546d5f239fe14fbaadeb445fc66a01b0779d98223961111e21766282f73dd96b6f"},
+		{"RFC 7748 section 5.2, second vector", "884a02576239ff7a2f2f63b2db6a9ff37047ac13568e1e30fe63c4a7ad1b3ee3a5700df34321d62077e63633c575c1c954514e99da7c179d"},
+		{"RFC 7748 section 6.2, Alice", "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d"},
+		{"RFC 7748 section 6.2, Bob", "07fff4181ac6cc95ec1c16a94a0f74d12da232ce40a77552281d282bb60c0b56fd2464c335543936521c24403085d59a449a5037514a879d"},
+	} {
+		checkHex(t, findVector(t, suites, "x448", tc.name), "sharedSecret", tc.want)
+	}
+}
@@ -0,0 +1,151 @@
+package algorithms
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/spec"
+	"golang.org/x/crypto/sha3"
+)
+
+// Extendable-output functions (FIPS 202 and SP 800-185).
+
+const xofSkip = "XOFs are not implemented in package:cryptography"
+
+// xofOutputLengths are the output lengths of every XOF input.
+var xofOutputLengths = []int{16, 32, 64, 500}
+
+// xofInputLengths returns input lengths around the rate of the XOF.
+func xofInputLengths(rate int) []int {
+	return []int{0, 1, rate - 1, rate, rate + 1}
+}
+
+func init() {
+	register(newGenerator("xof", xofSuites, spec.Seed).
+		uses("bytes.go", "dart.go", "prng.go", "suite.go"))
+}
+
+func xofSuites() ([]*Suite, error) {
+	var suites []*Suite
+	for _, x := range []struct {
+		name string
+		new  func() sha3.ShakeHash
+	}{
+		{"shake128", sha3.NewShake128},
+		{"shake256", sha3.NewShake256},
+	} {
+		s := &Suite{Name: x.name, Skip: xofSkip}
+		for _, n := range xofInputLengths(x.new().BlockSize()) {
+			for _, outputLength := range xofOutputLengths {
+				data := randomBytes(s.Name+": data", n)
+				h := x.new()
+				h.Write(data)
+				expected := make([]byte, outputLength)
+				h.Read(expected)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("%s, %d-byte output", describeBytes(data), outputLength),
+					Fields: []Field{
+						{"data", data},
+						{"outputLength", outputLength},
+						{"expected", expected},
+					},
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+
+	cshakes := []struct {
+		name string
+		new  func(n, s []byte) sha3.ShakeHash
+	}{
+		{"128", sha3.NewCShake128},
+		{"256", sha3.NewCShake256},
+	}
+	for _, x := range cshakes {
+		s := &Suite{Name: "cshake" + x.name, Skip: xofSkip}
+		for _, c := range []struct{ functionName, customization string }{
+			{"", "Email Signature"},
+			{"example", ""},
+			{"example", "Email Signature"},
+		} {
+			for _, n := range []int{0, 4, 200} {
+				data := sequence(0, n)
+				h := x.new([]byte(c.functionName), []byte(c.customization))
+				h.Write(data)
+				expected := make([]byte, 32)
+				h.Read(expected)
+				s.Vectors = append(s.Vectors, Vector{
+					Name: fmt.Sprintf("N=%q, S=%q, %s", c.functionName, c.customization, describeBytes(data)),
+					Fields: []Field{
+						{"functionName", c.functionName},
+						{"customization", c.customization},
+						{"data", data},
+						{"expected", expected},
+					},
+				})
+			}
+		}
+		suites = append(suites, s)
+	}
+	for _, x := range cshakes {
+		s := &Suite{Name: "kmac" + x.name, Skip: xofSkip}
+		for _, customization := range []string{"", "My Tagged Application"} {
+			for _, keyLength := range []int{16, 32, 200} {
+				for _, outputLength := range []int{32, 64} {
+					key := sequence(0x40, keyLength)
+					data := sequence(0, 4)
+					s.Vectors = append(s.Vectors, Vector{
+						Name: fmt.Sprintf("S=%q, %d-byte key, %d-byte output", customization, keyLength, outputLength),
+						Fields: []Field{
+							{"secretKey", key},
+							{"customization", customization},
+							{"data", data},
+							{"outputLength", outputLength},
+							{"expected", kmac(x.new, key, data, []byte(customization), outputLength)},
+						},
+					})
+				}
+			}
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// leftEncode and rightEncode are the integer encodings of SP 800-185.
+func leftEncode(x uint64) []byte {
+	b := binary.BigEndian.AppendUint64(nil, x)
+	i := 0
+	for i < 7 && b[i] == 0 {
+		i++
+	}
+	return append([]byte{byte(8 - i)}, b[i:]...)
+}
+
+func rightEncode(x uint64) []byte {
+	b := leftEncode(x)
+	return append(b[1:], b[0])
+}
+
+// bytepad prepends the encoded width and pads the data with zeros to a
+// multiple of the width.
+func bytepad(data []byte, width int) []byte {
+	out := concat(leftEncode(uint64(width)), data)
+	for len(out)%width != 0 {
+		out = append(out, 0)
+	}
+	return out
+}
+
+// kmac computes KMAC128 or KMAC256 (SP 800-185 section 4) with cSHAKE.
+func kmac(newCShake func(n, s []byte) sha3.ShakeHash, key, data, customization []byte, outputLength int) []byte {
+	h := newCShake([]byte("KMAC"), customization)
+	encodedKey := concat(leftEncode(uint64(8*len(key))), key)
+	h.Write(bytepad(encodedKey, h.BlockSize()))
+	h.Write(data)
+	h.Write(rightEncode(uint64(8 * outputLength)))
+	out := make([]byte, outputLength)
+	h.Read(out)
+	return out
+}
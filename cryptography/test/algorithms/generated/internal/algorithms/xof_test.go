@@ -0,0 +1,36 @@
+package algorithms
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func TestXOF(t *testing.T) {
+	suites, err := xofSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// FIPS 202 SHAKE128 of the empty message.
+	v := findVector(t, suites, "shake128", "0 bytes, 32-byte output")
+	checkHex(t, v, "expected", "7f9c2ba4e88f827d616045507605853ed73b8093f6efbc88eb1a6eacfa66ef26")
+}
+
+func TestKMAC(t *testing.T) {
+	// SP 800-185 KMAC128 sample #1.
+	got := kmac(sha3.NewCShake128, sequence(0x40, 32), mustHex("00010203"), nil, 32)
+	if want := "e5780b0d3ea6f7d3a429c5706aa43a00fadbd7d49628839e3187243f456ee14e"; hex.EncodeToString(got) != want {
+		t.Errorf("got %x, want %s", got, want)
+	}
+}
+
+func TestCShake(t *testing.T) {
+	suites, err := xofSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SP 800-185 cSHAKE128 sample #1.
+	v := findVector(t, suites, "cshake128", `N="", S="Email Signature", 4 bytes`)
+	checkHex(t, v, "expected", "c1c36925b6409a04f1b504fcbca9d82b4017277cb5ed2b2065fc1d3814d5aaf5")
+}
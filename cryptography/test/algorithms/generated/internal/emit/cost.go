@@ -0,0 +1,57 @@
+package emit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+// Cost of a vector is an estimate of the work that the Dart test does, in
+// units of one hash compression or one KiB of memory-hard function memory
+// touched once. For example, PBKDF2-HMAC-SHA256 with 100000 iterations costs
+// about 200000 units and Argon2id with 64 MiB and 3 iterations about 196608.
+const (
+	// costPerSecond is the throughput assumed for a slow device running
+	// the pure Dart implementations.
+	costPerSecond = 100000
+
+	// slowCost is the cost above which a test is tagged "slow" and skipped
+	// on the web.
+	slowCost = 10 * costPerSecond
+)
+
+// defaultTimeoutSeconds is the default timeout of package:test.
+const defaultTimeoutSeconds = 30
+
+// isSlow reports whether a vector with the cost should be tagged "slow".
+func isSlow(cost int) bool {
+	return cost >= slowCost
+}
+
+// timeoutSeconds returns a timeout for a vector, or 0 if the default timeout
+// of package:test is enough. The timeout has a 4x margin over the estimated
+// running time.
+func timeoutSeconds(cost int) int {
+	seconds := 4 * ((cost + costPerSecond - 1) / costPerSecond)
+	if seconds <= defaultTimeoutSeconds {
+		return 0
+	}
+	return seconds
+}
+
+// testOptions returns the named arguments of a Dart test() call, starting
+// with a comma, or an empty string.
+func testOptions(v algorithms.Vector, skip string) string {
+	var sb strings.Builder
+	if skip != "" {
+		sb.WriteString(", skip: " + stringToDart(skip))
+	}
+	if isSlow(v.Cost) {
+		sb.WriteString(", testOn: 'vm', tags: ['slow']")
+	}
+	if seconds := timeoutSeconds(v.Cost); seconds > 0 {
+		fmt.Fprintf(&sb, ", timeout: Timeout(Duration(seconds: %d))", seconds)
+	}
+	return sb.String()
+}
@@ -0,0 +1,161 @@
+// Package emit writes suites as Dart test files, or as JSON data and a Dart
+// test file that reads it.
+package emit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+// Comment returns the comment at the beginning of every generated Dart
+// file. It has the command line arguments that change the output and the
+// parameters of the generators (see algorithms.GeneratorFlags), such as the
+// seed of the pseudorandom inputs, so the file can be reproduced.
+func Comment(args, params []string) string {
+	var b strings.Builder
+	b.WriteString("// GENERATED CODE - DO NOT MODIFY BY HAND.\n//\n")
+	fmt.Fprintf(&b, "// Generated by %q in test/algorithms/generated.\n", strings.Join(append([]string{"go", "run", "./cmd/vectorgen"}, args...), " "))
+	if len(params) > 0 {
+		b.WriteString("// The parameters of the generators were:\n//\n")
+		for _, p := range params {
+			fmt.Fprintf(&b, "//   %s\n", p)
+		}
+		b.WriteString("//\n")
+	}
+	b.WriteString(`// The expected values were computed with the Go standard library and the
+// modules in test/algorithms/generated/go.mod: golang.org/x/crypto,
+// github.com/cloudflare/circl, filippo.io/edwards25519,
+// github.com/decred/dcrd/dcrec/secp256k1/v4 and github.com/dchest/blake2b.
+
+// ignore_for_file: unused_element, unused_local_variable
+
+`)
+	return b.String()
+}
+
+// Imports are the imports of every generated Dart test file.
+const Imports = `import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:cryptography/cryptography.dart';
+import 'package:cryptography/src/utils.dart';
+import 'package:test/test.dart';
+
+`
+
+var dartTemplate = template.Must(template.New("dart").Funcs(template.FuncMap{
+	"dart":    valueToDart,
+	"string":  stringToDart,
+	"indent":  algorithms.Indent,
+	"options": testOptions,
+}).Parse(`void main() {
+{{- range .}}
+  group({{string .Name}}, () {
+{{- $body := .Body}}
+{{- $skip := .Skip}}
+{{- range .Vectors}}
+    test({{string .Name}}, () async {
+{{- range .Fields}}
+      final {{.Name}} = {{dart .Value}};
+{{- end}}
+{{- if $body}}
+{{indent 6 $body}}
+{{- end}}
+    }{{options . $skip}});
+{{- end}}
+  }{{if .TestOn}}, testOn: {{string .TestOn}}{{end}});
+{{- end}}
+}
+`))
+
+// WriteDart writes a Dart test file that contains the suites and
+// begins with the comment, such as a Comment.
+func WriteDart(w io.Writer, comment string, suites []*algorithms.Suite) error {
+	return WriteDartFile(w, comment, Imports, suites)
+}
+
+// WriteDartFile writes a Dart test file that contains the suites, with the
+// comment and imports at the beginning.
+func WriteDartFile(w io.Writer, comment, imports string, suites []*algorithms.Suite) error {
+	if _, err := io.WriteString(w, comment+imports); err != nil {
+		return err
+	}
+	return dartTemplate.Execute(w, suites)
+}
+
+// bytesToDart returns a Dart expression that evaluates to the bytes.
+func bytesToDart(b []byte) string {
+	if len(b) == 0 {
+		return "<int>[]"
+	}
+	if len(b) <= 32 {
+		return "hexToBytes('" + hex.EncodeToString(b) + "')"
+	}
+	var sb strings.Builder
+	sb.WriteString("hexToBytes('''\n")
+	for i := 0; i < len(b); i += 32 {
+		end := i + 32
+		if end > len(b) {
+			end = len(b)
+		}
+		sb.WriteString(hex.EncodeToString(b[i:end]))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("''')")
+	return sb.String()
+}
+
+// stringToDart returns a Dart string literal.
+func stringToDart(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for _, r := range s {
+		switch {
+		case r == '\'' || r == '\\' || r == '$':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r < 0x20 || r > 0x7e:
+			fmt.Fprintf(&sb, `\u{%x}`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+// valueToDart returns a Dart expression for a field value.
+func valueToDart(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case []byte:
+		return bytesToDart(v), nil
+	case string:
+		return stringToDart(v), nil
+	case []string:
+		items := make([]string, len(v))
+		for i, s := range v {
+			items[i] = stringToDart(s)
+		}
+		return "<String>[" + strings.Join(items, ", ") + "]", nil
+	case int:
+		return strconv.Itoa(v), nil
+	case []int:
+		items := make([]string, len(v))
+		for i, n := range v {
+			items[i] = strconv.Itoa(n)
+		}
+		return "<int>[" + strings.Join(items, ", ") + "]", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %T", value)
+	}
+}
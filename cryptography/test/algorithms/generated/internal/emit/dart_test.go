@@ -0,0 +1,127 @@
+package emit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+func TestBytesToDart(t *testing.T) {
+	for _, tc := range []struct {
+		in   []byte
+		want string
+	}{
+		{nil, "<int>[]"},
+		{[]byte{0x00, 0xff}, "hexToBytes('00ff')"},
+		{bytes.Repeat([]byte{0xab}, 32), "hexToBytes('" + strings.Repeat("ab", 32) + "')"},
+		{bytes.Repeat([]byte{0xab}, 33), "hexToBytes('''\n" + strings.Repeat("ab", 32) + "\nab\n''')"},
+	} {
+		if got := bytesToDart(tc.in); got != tc.want {
+			t.Errorf("bytesToDart(%x) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestStringToDart(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"", "''"},
+		{"it's $1 \\", `'it\'s \$1 \\'`},
+		{"a\nb\x00", `'a\nb\u{0}'`},
+		{"é", `'\u{e9}'`},
+	} {
+		if got := stringToDart(tc.in); got != tc.want {
+			t.Errorf("stringToDart(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestValueToDart(t *testing.T) {
+	for _, tc := range []struct {
+		in   interface{}
+		want string
+	}{
+		{[]string{"a", "b"}, "<String>['a', 'b']"},
+		{[]string(nil), "<String>[]"},
+		{42, "42"},
+		{[]int{1, 63, 64}, "<int>[1, 63, 64]"},
+		{[]int(nil), "<int>[]"},
+		{true, "true"},
+	} {
+		got, err := valueToDart(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("valueToDart(%#v) = %q, %v, want %q", tc.in, got, err, tc.want)
+		}
+	}
+	if _, err := valueToDart(1.5); err == nil {
+		t.Error("valueToDart(1.5) did not fail")
+	}
+}
+
+func TestWriteDart(t *testing.T) {
+	var buf bytes.Buffer
+	comment := Comment([]string{"-seed", "2"}, []string{"cycles=1000", "seed=2"})
+	err := WriteDart(&buf, comment, []*algorithms.Suite{{
+		Name: "example",
+		Body: "expect(x, y);",
+		Skip: "not implemented",
+		Vectors: []algorithms.Vector{
+			{Name: "fast", Fields: []algorithms.Field{{Name: "x", Value: []byte{1}}, {Name: "y", Value: "y"}}},
+			{Name: "slow", Fields: []algorithms.Field{{Name: "x", Value: []byte{2}}, {Name: "y", Value: "z"}}, Cost: 100 * costPerSecond},
+		},
+	}, {
+		Name:    "browser",
+		TestOn:  "chrome",
+		Vectors: []algorithms.Vector{{Name: "only", Fields: []algorithms.Field{{Name: "x", Value: 1}}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := comment + Imports + `void main() {
+  group('example', () {
+    test('fast', () async {
+      final x = hexToBytes('01');
+      final y = 'y';
+      expect(x, y);
+    }, skip: 'not implemented');
+    test('slow', () async {
+      final x = hexToBytes('02');
+      final y = 'z';
+      expect(x, y);
+    }, skip: 'not implemented', testOn: 'vm', tags: ['slow'], timeout: Timeout(Duration(seconds: 400)));
+  });
+  group('browser', () {
+    test('only', () async {
+      final x = 1;
+    });
+  }, testOn: 'chrome');
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestCommentModules checks that the comment names every module that go.mod
+// requires directly.
+func TestCommentModules(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment := Comment(nil, nil)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.Contains(fields[0], ".") {
+			continue
+		}
+		if !strings.Contains(comment, fields[0]) {
+			t.Errorf("the comment does not name %s", fields[0])
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package emit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"text/template"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+// DataPath is the JSON file written by "-emit data".
+const DataPath = "generated_vectors.json"
+
+// generatorDir is the directory of the generator relative to the package
+// root, which is the working directory of "dart test".
+const generatorDir = "test/algorithms/generated"
+
+// dataDartPath returns the path of DataPath in the output directory out for
+// the Dart test. A relative out is relative to generatorDir.
+func dataDartPath(out string) string {
+	p := filepath.Join(out, DataPath)
+	if filepath.IsAbs(p) {
+		return filepath.ToSlash(p)
+	}
+	return path.Join(generatorDir, filepath.ToSlash(p))
+}
+
+type dataSuite struct {
+	Name    string       `json:"name"`
+	Skip    string       `json:"skip,omitempty"`
+	TestOn  string       `json:"testOn,omitempty"`
+	Vectors []dataVector `json:"vectors"`
+}
+
+type dataVector struct {
+	Name    string                 `json:"name"`
+	Slow    bool                   `json:"slow,omitempty"`
+	Timeout int                    `json:"timeout,omitempty"`
+	Fields  map[string]interface{} `json:"fields"`
+}
+
+// valueToJSON returns a JSON-encodable value for a field value. Bytes are
+// encoded as hex strings.
+func valueToJSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []byte:
+		return hex.EncodeToString(v), nil
+	case []string:
+		if v == nil {
+			return []string{}, nil
+		}
+		return v, nil
+	case []int:
+		if v == nil {
+			return []int{}, nil
+		}
+		return v, nil
+	case string, int, bool:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %T", value)
+	}
+}
+
+// WriteData writes the vectors of the suites as JSON.
+func WriteData(w io.Writer, suites []*algorithms.Suite) error {
+	var data struct {
+		Suites []dataSuite `json:"suites"`
+	}
+	for _, s := range suites {
+		ds := dataSuite{Name: s.Name, Skip: s.Skip, TestOn: s.TestOn}
+		for _, v := range s.Vectors {
+			dv := dataVector{
+				Name:    v.Name,
+				Slow:    isSlow(v.Cost),
+				Timeout: timeoutSeconds(v.Cost),
+				Fields:  map[string]interface{}{},
+			}
+			for _, f := range v.Fields {
+				value, err := valueToJSON(f.Value)
+				if err != nil {
+					return fmt.Errorf("%s: %s: %s: %w", s.Name, v.Name, f.Name, err)
+				}
+				dv.Fields[f.Name] = value
+			}
+			ds.Vectors = append(ds.Vectors, dv)
+		}
+		data.Suites = append(data.Suites, ds)
+	}
+	// Fields are a map, which encoding/json writes with sorted keys.
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(data)
+}
+
+// fieldFromJSON returns a Dart expression that reads the field from the
+// decoded JSON map "v".
+func fieldFromJSON(f algorithms.Field) (string, error) {
+	key := "v[" + stringToDart(f.Name) + "]"
+	switch f.Value.(type) {
+	case []byte:
+		return "hexToBytes(" + key + " as String)", nil
+	case string:
+		return key + " as String", nil
+	case []string:
+		return "(" + key + " as List).cast<String>()", nil
+	case int:
+		return key + " as int", nil
+	case []int:
+		return "(" + key + " as List).cast<int>()", nil
+	case bool:
+		return key + " as bool", nil
+	default:
+		return "", fmt.Errorf("unsupported field type %T", f.Value)
+	}
+}
+
+var dataDartTemplate = template.Must(template.New("data").Funcs(template.FuncMap{
+	"string": stringToDart,
+	"indent": algorithms.Indent,
+	"json":   fieldFromJSON,
+	"dataPath": func() string {
+		return dataDartPath(".")
+	},
+}).Parse(`@TestOn('vm')
+library generated_test;
+
+import 'dart:convert';
+import 'dart:io';
+import 'dart:typed_data';
+
+import 'package:cryptography/cryptography.dart';
+import 'package:cryptography/src/utils.dart';
+import 'package:test/test.dart';
+
+typedef _Runner = Future<void> Function(Map<String, Object?> v);
+
+const _runners = <String, _Runner>{
+{{- range $i, $suite := .}}
+  {{string $suite.Name}}: _suite{{$i}},
+{{- end}}
+};
+
+void main() {
+  final data = jsonDecode(
+    File({{string dataPath}}).readAsStringSync(),
+  ) as Map<String, Object?>;
+  for (var suite in (data['suites'] as List).cast<Map<String, Object?>>()) {
+    final name = suite['name'] as String;
+    final runner = _runners[name]!;
+    group(name, () {
+      for (var vector in (suite['vectors'] as List).cast<Map<String, Object?>>()) {
+        final timeout = vector['timeout'] as int?;
+        test(
+          vector['name'] as String,
+          () => runner((vector['fields'] as Map).cast<String, Object?>()),
+          skip: suite['skip'] as String?,
+          tags: vector['slow'] == true ? ['slow'] : null,
+          timeout: timeout == null ? null : Timeout(Duration(seconds: timeout)),
+        );
+      }
+    }, testOn: suite['testOn'] as String?);
+  }
+}
+{{- range $i, $suite := .}}
+
+Future<void> _suite{{$i}}(Map<String, Object?> v) async {
+{{- if $suite.Vectors}}
+{{- range (index $suite.Vectors 0).Fields}}
+  final {{.Name}} = {{json .}};
+{{- end}}
+{{- end}}
+{{- if $suite.Body}}
+{{indent 2 $suite.Body}}
+{{- end}}
+}
+{{- end}}
+`))
+
+// WriteDataDart writes a Dart test file that runs the vectors in DataPath in
+// the output directory out.
+func WriteDataDart(w io.Writer, comment string, suites []*algorithms.Suite, out string) error {
+	t, err := dataDartTemplate.Clone()
+	if err != nil {
+		return err
+	}
+	t.Funcs(template.FuncMap{"dataPath": func() string {
+		return dataDartPath(out)
+	}})
+	if _, err := io.WriteString(w, comment); err != nil {
+		return err
+	}
+	return t.Execute(w, suites)
+}
@@ -0,0 +1,60 @@
+package emit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/dint-dev/cryptography/cryptography/test/algorithms/generated/internal/algorithms"
+)
+
+func TestDataDartPath(t *testing.T) {
+	for out, want := range map[string]string{
+		".":            "test/algorithms/generated/generated_vectors.json",
+		"../vectors":   "test/algorithms/vectors/generated_vectors.json",
+		"/tmp/vectors": "/tmp/vectors/generated_vectors.json",
+	} {
+		if got := dataDartPath(out); got != want {
+			t.Errorf("dataDartPath(%q) = %q, want %q", out, got, want)
+		}
+	}
+}
+
+func TestWriteData(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteData(&buf, []*algorithms.Suite{{
+		Name: "example",
+		Skip: "not implemented",
+		Vectors: []algorithms.Vector{
+			{Name: "fast", Fields: []algorithms.Field{{Name: "x", Value: []byte{1}}, {Name: "y", Value: []int(nil)}}},
+			{Name: "slow", Fields: []algorithms.Field{{Name: "x", Value: []byte{2}}, {Name: "y", Value: []int{3}}}, Cost: 100 * costPerSecond},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, want interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"suites": [{
+		"name": "example",
+		"skip": "not implemented",
+		"vectors": [
+			{"name": "fast", "fields": {"x": "01", "y": []}},
+			{"name": "slow", "slow": true, "timeout": 400, "fields": {"x": "02", "y": [3]}}
+		]
+	}]}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %s", buf.String())
+	}
+	if err := WriteData(&buf, []*algorithms.Suite{{
+		Name:    "invalid",
+		Vectors: []algorithms.Vector{{Name: "float", Fields: []algorithms.Field{{Name: "x", Value: 1.5}}}},
+	}}); err == nil {
+		t.Error("no error for a float field")
+	}
+}
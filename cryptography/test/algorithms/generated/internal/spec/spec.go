@@ -0,0 +1,98 @@
+// Package spec reads spec files.
+//
+// A spec file customizes the vectors without changing the generators, for
+// example in a fork that implements other key lengths. It is a JSON object
+// that is read with "-spec":
+//
+//	{
+//	  "algorithms": ["aes-gcm", "aes-ctr", "blake2b"],
+//	  "skip": [],
+//	  "aesKeyLengths": [16, 32],
+//	  "inputLengths": [0, 1, 64, 1000],
+//	  "cycles": 1000,
+//	  "seed": 2
+//	}
+//
+// Every option is optional and a flag on the command line overrides the
+// option of the same name. The lengths replace the defaults of the
+// generators that use them (see the options of the generators), so only
+// those generators are run again.
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Spec is the content of a spec file.
+type Spec struct {
+	// Algorithms and Skip are like the -algorithms and -skip flags.
+	Algorithms []string `json:"algorithms"`
+	Skip       []string `json:"skip"`
+
+	// AesKeyLengths are the AES key lengths in bytes. The default is 16, 24
+	// and 32.
+	AesKeyLengths []int `json:"aesKeyLengths"`
+
+	// InputLengths are the lengths in bytes of clear texts and messages.
+	// Generators whose lengths are chosen around padding or block
+	// boundaries, such as hash, keep their own.
+	InputLengths []int `json:"inputLengths"`
+
+	// Cycles is like the -cycles flag.
+	Cycles int `json:"cycles"`
+
+	// Seed is like the -seed flag.
+	Seed *uint64 `json:"seed"`
+}
+
+// The options that change the suites of a generator.
+const (
+	AesKeyLengths = "aesKeyLengths"
+	InputLengths  = "inputLengths"
+	Seed          = "seed"
+)
+
+// Read reads a spec file.
+func Read(path string) (Spec, error) {
+	var s Spec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := s.validate(); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *Spec) validate() error {
+	if s.Cycles < 0 {
+		return fmt.Errorf("invalid cycles %d", s.Cycles)
+	}
+	seen := map[int]bool{}
+	for _, n := range s.AesKeyLengths {
+		if n != 16 && n != 24 && n != 32 {
+			return fmt.Errorf("invalid AES key length %d", n)
+		}
+		if seen[n] {
+			return fmt.Errorf("duplicate AES key length %d", n)
+		}
+		seen[n] = true
+	}
+	seen = map[int]bool{}
+	for _, n := range s.InputLengths {
+		if n < 0 {
+			return fmt.Errorf("invalid input length %d", n)
+		}
+		if seen[n] {
+			return fmt.Errorf("duplicate input length %d", n)
+		}
+		seen[n] = true
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.json")
+	if err := os.WriteFile(path, []byte(`{"algorithms": ["aes-ctr"], "aesKeyLengths": [32], "inputLengths": [0, 5], "cycles": 10, "seed": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Algorithms) != 1 || len(s.AesKeyLengths) != 1 || len(s.InputLengths) != 2 || s.Cycles != 10 || s.Seed == nil || *s.Seed != 2 {
+		t.Errorf("got %+v", s)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"aesKeyLengths": [20]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil {
+		t.Error("no error for an invalid spec file")
+	}
+	if _, err := Read(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("no error for a missing spec file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, invalid := range []Spec{
+		{AesKeyLengths: []int{20}},
+		{AesKeyLengths: []int{16, 16}},
+		{InputLengths: []int{-1}},
+		{InputLengths: []int{3, 3}},
+		{Cycles: -1},
+	} {
+		if err := invalid.validate(); err == nil {
+			t.Errorf("no error for %+v", invalid)
+		}
+	}
+	valid := Spec{AesKeyLengths: []int{16, 24, 32}, InputLengths: []int{0, 1000}, Cycles: 1}
+	if err := valid.validate(); err != nil {
+		t.Errorf("%+v: %v", valid, err)
+	}
+}